@@ -119,6 +119,12 @@ var (
 		Category: traceCategory,
 	}
 
+	TraceGasProfileFlag = &cli.StringFlag{
+		Name:     "trace.gasprofile",
+		Usage:    "Write a pprof profile of the gas used to the given file",
+		Category: traceCategory,
+	}
+
 	// Deprecated flags.
 	DebugFlag = &cli.BoolFlag{
 		Name:     "debug",
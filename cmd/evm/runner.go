@@ -32,6 +32,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
@@ -39,6 +40,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/internal/flags"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/triedb"
@@ -66,6 +68,7 @@ var runCommand = &cli.Command{
 		ValueFlag,
 		StatDumpFlag,
 		DumpFlag,
+		TraceGasProfileFlag,
 	}, traceFlags),
 }
 
@@ -297,6 +300,23 @@ func runCmd(ctx *cli.Context) error {
 		runtimeConfig.ChainConfig = params.AllEthashProtocolChanges
 	}
 
+	// The gas profiler doesn't produce any output while tracing, it is written
+	// to the profile file after execution.
+	var profiler *tracers.Tracer
+	profileFile := ctx.String(TraceGasProfileFlag.Name)
+	if profileFile != "" {
+		if tracer != nil {
+			fmt.Println("--trace.gasprofile can't be used together with --trace")
+			os.Exit(1)
+		}
+		var err error
+		if profiler, err = tracers.DefaultDirectory.New("gasProfiler", new(tracers.Context), nil, runtimeConfig.ChainConfig); err != nil {
+			fmt.Printf("Could not create gas profiler: %v\n", err)
+			os.Exit(1)
+		}
+		runtimeConfig.EVMConfig.Tracer = profiler.Hooks
+	}
+
 	var hexInput []byte
 	if inputFileFlag := ctx.String(InputFileFlag.Name); inputFileFlag != "" {
 		var err error
@@ -338,6 +358,13 @@ func runCmd(ctx *cli.Context) error {
 	bench := ctx.Bool(BenchFlag.Name)
 	output, stats, err := timedExec(bench, execFunc)
 
+	if profiler != nil {
+		if err := writeGasProfile(profiler, profileFile); err != nil {
+			fmt.Printf("Could not write gas profile: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	if ctx.Bool(DumpFlag.Name) {
 		root, err := runtimeConfig.State.Commit(genesisConfig.Number, true, false)
 		if err != nil {
@@ -376,6 +403,21 @@ allocated bytes: %d
 	return nil
 }
 
+// writeGasProfile writes the pprof profile collected by the gas profiler to a file.
+func writeGasProfile(profiler *tracers.Tracer, file string) error {
+	res, err := profiler.GetResult()
+	if err != nil {
+		return err
+	}
+	var result struct {
+		Profile hexutil.Bytes `json:"profile"`
+	}
+	if err := json.Unmarshal(res, &result); err != nil {
+		return err
+	}
+	return os.WriteFile(file, result.Profile, 0644)
+}
+
 // writeLogs writes vm logs in a readable format to the given writer
 func writeLogs(writer io.Writer, logs []*types.Log) {
 	for _, log := range logs {
@@ -66,6 +66,9 @@ var (
 		utils.TxPoolNoLocalsFlag,
 		utils.TxPoolJournalFlag,
 		utils.TxPoolRejournalFlag,
+		utils.TxPoolSnapshotFlag,
+		utils.TxPoolSnapshotIntervalFlag,
+		utils.TxPoolSnapshotLimitFlag,
 		utils.TxPoolPriceLimitFlag,
 		utils.TxPoolPriceBumpFlag,
 		utils.TxPoolAccountSlotsFlag,
@@ -83,6 +86,8 @@ var (
 		utils.GCModeFlag,
 		utils.SnapshotFlag,
 		utils.TransactionHistoryFlag,
+		utils.AddressIndexFlag,
+		utils.AddressHistoryFlag,
 		utils.ChainHistoryFlag,
 		utils.LogHistoryFlag,
 		utils.LogNoHistoryFlag,
@@ -113,6 +118,8 @@ var (
 		utils.MinerGasPriceFlag,
 		utils.MinerExtraDataFlag,
 		utils.MinerMaxBlobsFlag,
+		utils.MinerTxOrderingFlag,
+		utils.MinerTxSenderCapFlag,
 		utils.MinerRecommitIntervalFlag,
 		utils.MinerPendingFeeRecipientFlag,
 		utils.NATFlag,
@@ -158,6 +165,9 @@ var (
 		utils.AuthListenFlag,
 		utils.AuthPortFlag,
 		utils.AuthVirtualHostsFlag,
+		utils.AuthTLSCertFlag,
+		utils.AuthTLSKeyFlag,
+		utils.AuthTLSClientCAFlag,
 		utils.JWTSecretFlag,
 		utils.HTTPVirtualHostsFlag,
 		utils.GraphQLEnabledFlag,
@@ -165,12 +175,18 @@ var (
 		utils.GraphQLVirtualHostsFlag,
 		utils.HTTPApiFlag,
 		utils.HTTPPathPrefixFlag,
+		utils.HTTPTLSCertFlag,
+		utils.HTTPTLSKeyFlag,
+		utils.HTTPTLSClientCAFlag,
 		utils.WSEnabledFlag,
 		utils.WSListenAddrFlag,
 		utils.WSPortFlag,
 		utils.WSApiFlag,
 		utils.WSAllowedOriginsFlag,
 		utils.WSPathPrefixFlag,
+		utils.WSTLSCertFlag,
+		utils.WSTLSKeyFlag,
+		utils.WSTLSClientCAFlag,
 		utils.IPCDisabledFlag,
 		utils.IPCPathFlag,
 		utils.RPCGlobalGasCapFlag,
@@ -182,6 +198,7 @@ var (
 		utils.BatchRequestLimit,
 		utils.BatchResponseMaxSize,
 		utils.HTTPBodyLimitFlag,
+		utils.RPCRecordFlag,
 		utils.RPCTxSyncDefaultTimeoutFlag,
 		utils.RPCTxSyncMaxTimeoutFlag,
 		utils.RPCGlobalRangeLimitFlag,
@@ -0,0 +1,115 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+// rpcreplay replays recorded JSON-RPC traffic against a node.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	endpoint   = flag.String("rpc", "", "RPC endpoint of the node to replay against")
+	scriptMode = flag.Bool("script", false, "convert the recording to an rpc/testdata test script")
+	method     = flag.String("method", "", "only replay calls of the given method")
+)
+
+func init() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage:", os.Args[0], "[-rpc <url>] [-method <name>] [-script] <recording.jsonl>")
+		flag.PrintDefaults()
+		fmt.Fprintln(os.Stderr, `
+Replays a recording made with --rpc.record (or rpc.Recorder) against a node
+and prints every call whose response differs from the recorded one. The exit
+status is 1 if any difference was found.
+
+With -script, the recording is printed in the format of the test scripts in
+rpc/testdata instead.`)
+	}
+}
+
+func main() {
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	fd, err := os.Open(flag.Arg(0))
+	if err != nil {
+		die(err)
+	}
+	calls, err := rpc.ReadRecording(fd)
+	fd.Close()
+	if err != nil {
+		die(err)
+	}
+	if *method != "" {
+		calls = filterMethod(calls, *method)
+	}
+
+	if *scriptMode {
+		if err := rpc.WriteTestScript(os.Stdout, calls); err != nil {
+			die(err)
+		}
+		return
+	}
+	if *endpoint == "" {
+		die("missing -rpc endpoint")
+	}
+	client, err := rpc.Dial(*endpoint)
+	if err != nil {
+		die(err)
+	}
+	defer client.Close()
+
+	mismatches, err := rpc.Replay(context.Background(), client, calls)
+	for _, m := range mismatches {
+		fmt.Printf("--> %s\n", m.Call.Request)
+		fmt.Printf("recorded: %s\n", m.Call.Response)
+		fmt.Printf("replayed: %s\n\n", m.Response)
+	}
+	if err != nil {
+		die(err)
+	}
+	fmt.Fprintf(os.Stderr, "Replayed %d calls, %d differences\n", len(calls), len(mismatches))
+	if len(mismatches) > 0 {
+		os.Exit(1)
+	}
+}
+
+func filterMethod(calls []*rpc.RecordedCall, method string) []*rpc.RecordedCall {
+	var filtered []*rpc.RecordedCall
+	for _, call := range calls {
+		var req struct {
+			Method string `json:"method"`
+		}
+		if json.Unmarshal(call.Request, &req) == nil && req.Method == method {
+			filtered = append(filtered, call)
+		}
+	}
+	return filtered
+}
+
+func die(args ...interface{}) {
+	fmt.Fprintln(os.Stderr, args...)
+	os.Exit(1)
+}
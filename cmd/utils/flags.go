@@ -43,6 +43,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/txpool/txorder"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
@@ -339,6 +340,17 @@ var (
 		Value:    ethconfig.Defaults.TransactionHistory,
 		Category: flags.StateCategory,
 	}
+	AddressIndexFlag = &cli.BoolFlag{
+		Name:     "history.addresses.enable",
+		Usage:    "Maintain an index of the transactions of each sender, recipient and created contract",
+		Category: flags.StateCategory,
+	}
+	AddressHistoryFlag = &cli.Uint64Flag{
+		Name:     "history.addresses",
+		Usage:    "Number of recent blocks to maintain the address index for, if enabled (default = about one year, 0 = entire chain)",
+		Value:    ethconfig.Defaults.AddressHistory,
+		Category: flags.StateCategory,
+	}
 	ChainHistoryFlag = &cli.StringFlag{
 		Name:     "history.chain",
 		Usage:    `Blockchain history retention ("all", "postmerge", or "postprague")`,
@@ -442,6 +454,24 @@ var (
 		Value:    ethconfig.Defaults.TxPool.Rejournal,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolSnapshotFlag = &cli.StringFlag{
+		Name:     "txpool.snapshot",
+		Usage:    "Disk snapshot of the pending and queued transactions to survive node restarts (empty = disabled)",
+		Value:    ethconfig.Defaults.TxPool.Snapshot,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolSnapshotIntervalFlag = &cli.DurationFlag{
+		Name:     "txpool.snapshotinterval",
+		Usage:    "Time interval to regenerate the transaction pool snapshot",
+		Value:    ethconfig.Defaults.TxPool.SnapshotInterval,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolSnapshotLimitFlag = &cli.Uint64Flag{
+		Name:     "txpool.snapshotlimit",
+		Usage:    "Maximum size of the transaction pool snapshot in bytes (0 = unlimited)",
+		Value:    ethconfig.Defaults.TxPool.SnapshotLimit,
+		Category: flags.TxPoolCategory,
+	}
 	TxPoolPriceLimitFlag = &cli.Uint64Flag{
 		Name:     "txpool.pricelimit",
 		Usage:    "Minimum gas price tip to enforce for acceptance into the pool",
@@ -613,6 +643,17 @@ var (
 		Usage:    "Maximum number of blobs per block (falls back to protocol maximum if unspecified)",
 		Category: flags.MinerCategory,
 	}
+	MinerTxOrderingFlag = &cli.StringFlag{
+		Name:     "miner.txordering",
+		Usage:    "Transaction ordering strategy for block building (price, fcfs, sender)",
+		Value:    txorder.PriceOrdering,
+		Category: flags.MinerCategory,
+	}
+	MinerTxSenderCapFlag = &cli.IntFlag{
+		Name:     "miner.txsendercap",
+		Usage:    "Maximum number of transactions per sender in a block with the sender ordering (0 = unlimited)",
+		Category: flags.MinerCategory,
+	}
 
 	// Account settings
 	PasswordFileFlag = &cli.PathFlag{
@@ -722,6 +763,21 @@ var (
 		Value:    strings.Join(node.DefaultConfig.AuthVirtualHosts, ","),
 		Category: flags.APICategory,
 	}
+	AuthTLSCertFlag = &flags.DirectoryFlag{
+		Name:     "authrpc.tls.cert",
+		Usage:    "Path to the PEM encoded TLS certificate of the authenticated API server, enables TLS",
+		Category: flags.APICategory,
+	}
+	AuthTLSKeyFlag = &flags.DirectoryFlag{
+		Name:     "authrpc.tls.key",
+		Usage:    "Path to the PEM encoded TLS private key of the authenticated API server",
+		Category: flags.APICategory,
+	}
+	AuthTLSClientCAFlag = &flags.DirectoryFlag{
+		Name:     "authrpc.tls.clientca",
+		Usage:    "Path to the PEM encoded CA certificates of the authenticated API server, requires clients to authenticate with a certificate issued by them",
+		Category: flags.APICategory,
+	}
 	JWTSecretFlag = &flags.DirectoryFlag{
 		Name:     "authrpc.jwtsecret",
 		Usage:    "Path to a JWT secret to use for authenticated RPC endpoints",
@@ -806,6 +862,21 @@ var (
 		Value:    "",
 		Category: flags.APICategory,
 	}
+	HTTPTLSCertFlag = &flags.DirectoryFlag{
+		Name:     "http.tls.cert",
+		Usage:    "Path to the PEM encoded TLS certificate of the HTTP-RPC server, enables TLS",
+		Category: flags.APICategory,
+	}
+	HTTPTLSKeyFlag = &flags.DirectoryFlag{
+		Name:     "http.tls.key",
+		Usage:    "Path to the PEM encoded TLS private key of the HTTP-RPC server",
+		Category: flags.APICategory,
+	}
+	HTTPTLSClientCAFlag = &flags.DirectoryFlag{
+		Name:     "http.tls.clientca",
+		Usage:    "Path to the PEM encoded CA certificates of the HTTP-RPC server, requires clients to authenticate with a certificate issued by them",
+		Category: flags.APICategory,
+	}
 	GraphQLEnabledFlag = &cli.BoolFlag{
 		Name:     "graphql",
 		Usage:    "Enable GraphQL on the HTTP-RPC server. Note that GraphQL can only be started if an HTTP server is started as well.",
@@ -858,6 +929,21 @@ var (
 		Value:    "",
 		Category: flags.APICategory,
 	}
+	WSTLSCertFlag = &flags.DirectoryFlag{
+		Name:     "ws.tls.cert",
+		Usage:    "Path to the PEM encoded TLS certificate of the WS-RPC server, enables TLS",
+		Category: flags.APICategory,
+	}
+	WSTLSKeyFlag = &flags.DirectoryFlag{
+		Name:     "ws.tls.key",
+		Usage:    "Path to the PEM encoded TLS private key of the WS-RPC server",
+		Category: flags.APICategory,
+	}
+	WSTLSClientCAFlag = &flags.DirectoryFlag{
+		Name:     "ws.tls.clientca",
+		Usage:    "Path to the PEM encoded CA certificates of the WS-RPC server, requires clients to authenticate with a certificate issued by them",
+		Category: flags.APICategory,
+	}
 	ExecFlag = &cli.StringFlag{
 		Name:     "exec",
 		Usage:    "Execute JavaScript statement",
@@ -891,6 +977,11 @@ var (
 		Value:    node.DefaultConfig.HTTPBodyLimit / (1024 * 1024),
 		Category: flags.APICategory,
 	}
+	RPCRecordFlag = &cli.StringFlag{
+		Name:     "rpc.record",
+		Usage:    "Record all calls handled by the HTTP and WS endpoints to the given file (JSONL)",
+		Category: flags.APICategory,
+	}
 
 	// Network Settings
 	MaxPeersFlag = &cli.IntFlag{
@@ -1380,6 +1471,8 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(HTTPPathPrefixFlag.Name) {
 		cfg.HTTPPathPrefix = ctx.String(HTTPPathPrefixFlag.Name)
 	}
+	setTLS(ctx, &cfg.HTTPTLS, HTTPTLSCertFlag, HTTPTLSKeyFlag, HTTPTLSClientCAFlag)
+	setTLS(ctx, &cfg.AuthTLS, AuthTLSCertFlag, AuthTLSKeyFlag, AuthTLSClientCAFlag)
 	if ctx.IsSet(AllowUnprotectedTxs.Name) {
 		cfg.AllowUnprotectedTxs = ctx.Bool(AllowUnprotectedTxs.Name)
 	}
@@ -1395,6 +1488,10 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(HTTPBodyLimitFlag.Name) {
 		cfg.HTTPBodyLimit = ctx.Int(HTTPBodyLimitFlag.Name) * 1024 * 1024
 	}
+
+	if ctx.IsSet(RPCRecordFlag.Name) {
+		cfg.RPCRecordFile = ctx.String(RPCRecordFlag.Name)
+	}
 }
 
 // setGraphQL creates the GraphQL listener interface string from the set
@@ -1434,6 +1531,20 @@ func setWS(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(WSPathPrefixFlag.Name) {
 		cfg.WSPathPrefix = ctx.String(WSPathPrefixFlag.Name)
 	}
+	setTLS(ctx, &cfg.WSTLS, WSTLSCertFlag, WSTLSKeyFlag, WSTLSClientCAFlag)
+}
+
+// setTLS applies the TLS flags of an RPC endpoint to its configuration.
+func setTLS(ctx *cli.Context, cfg *node.TLSConfig, certFlag, keyFlag, clientCAFlag cli.Flag) {
+	if name := certFlag.Names()[0]; ctx.IsSet(name) {
+		cfg.CertFile = ctx.String(name)
+	}
+	if name := keyFlag.Names()[0]; ctx.IsSet(name) {
+		cfg.KeyFile = ctx.String(name)
+	}
+	if name := clientCAFlag.Names()[0]; ctx.IsSet(name) {
+		cfg.ClientCAFile = ctx.String(name)
+	}
 }
 
 // setIPC creates an IPC path configuration from the set command line flags,
@@ -1560,6 +1671,10 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(DeveloperFlag.Name) {
 		cfg.UseLightweightKDF = ctx.Bool(DeveloperFlag.Name)
 	}
+	if ctx.Bool(DeveloperFlag.Name) {
+		// Dev mode mines on demand, the head may be arbitrarily old.
+		cfg.Health.MaxHeadAge = 0
+	}
 	if ctx.IsSet(LightKDFFlag.Name) {
 		cfg.UseLightweightKDF = ctx.Bool(LightKDFFlag.Name)
 	}
@@ -1675,6 +1790,15 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	if ctx.IsSet(TxPoolRejournalFlag.Name) {
 		cfg.Rejournal = ctx.Duration(TxPoolRejournalFlag.Name)
 	}
+	if ctx.IsSet(TxPoolSnapshotFlag.Name) {
+		cfg.Snapshot = ctx.String(TxPoolSnapshotFlag.Name)
+	}
+	if ctx.IsSet(TxPoolSnapshotIntervalFlag.Name) {
+		cfg.SnapshotInterval = ctx.Duration(TxPoolSnapshotIntervalFlag.Name)
+	}
+	if ctx.IsSet(TxPoolSnapshotLimitFlag.Name) {
+		cfg.SnapshotLimit = ctx.Uint64(TxPoolSnapshotLimitFlag.Name)
+	}
 	if ctx.IsSet(TxPoolPriceLimitFlag.Name) {
 		cfg.PriceLimit = ctx.Uint64(TxPoolPriceLimitFlag.Name)
 	}
@@ -1729,6 +1853,12 @@ func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	if ctx.IsSet(MinerMaxBlobsFlag.Name) {
 		cfg.MaxBlobsPerBlock = ctx.Int(MinerMaxBlobsFlag.Name)
 	}
+	if ctx.IsSet(MinerTxOrderingFlag.Name) {
+		cfg.TxOrdering = ctx.String(MinerTxOrderingFlag.Name)
+	}
+	if ctx.IsSet(MinerTxSenderCapFlag.Name) {
+		cfg.TxOrderingSenderCap = ctx.Int(MinerTxSenderCapFlag.Name)
+	}
 }
 
 func setRequiredBlocks(ctx *cli.Context, cfg *ethconfig.Config) {
@@ -1878,6 +2008,12 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 			log.Warn("Disabled transaction unindexing for archive node")
 		}
 	}
+	if ctx.IsSet(AddressIndexFlag.Name) {
+		cfg.AddressIndex = ctx.Bool(AddressIndexFlag.Name)
+	}
+	if ctx.IsSet(AddressHistoryFlag.Name) {
+		cfg.AddressHistory = ctx.Uint64(AddressHistoryFlag.Name)
+	}
 	if ctx.IsSet(LogHistoryFlag.Name) {
 		cfg.LogHistory = ctx.Uint64(LogHistoryFlag.Name)
 	}
@@ -0,0 +1,434 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// errAddrIndexStopped is returned if the address indexing was interrupted.
+var errAddrIndexStopped = errors.New("address indexing stopped")
+
+// addrIndexer is the module responsible for maintaining the address index,
+// which tracks the transactions sent, received or deployed by each account,
+// along with the transaction of each sender and nonce.
+//
+// Unlike the transaction lookup entries, the address index entries are keyed
+// by block position rather than by hash, so the blocks of a reorged branch are
+// unindexed explicitly. The indexer tracks the hash of its last indexed block
+// for this purpose.
+type addrIndexer struct {
+	// limit is the maximum number of blocks from head whose transactions are
+	// indexed, 0 meaning the entire chain.
+	limit uint64
+
+	// The current head of the blockchain and the indexed range. These fields
+	// are accessed by both the indexer and the indexing progress queries.
+	head    atomic.Uint64
+	indexed atomic.Pointer[rawdb.AddrIndexRange]
+
+	// cutoff denotes the block number before which the chain segment should
+	// be pruned and not available locally.
+	cutoff     uint64
+	hashScheme bool // Whether trie nodes are keyed by hash, ruling out range deletions
+	config     *params.ChainConfig
+	db         ethdb.Database
+	term       chan chan struct{}
+	closed     chan struct{}
+
+	headCh  chan ChainHeadEvent
+	headSub event.Subscription
+}
+
+// newAddrIndexer initializes the address indexer.
+func newAddrIndexer(limit uint64, chain *BlockChain) *addrIndexer {
+	cutoff, _ := chain.HistoryPruningCutoff()
+	indexer := &addrIndexer{
+		limit:      limit,
+		cutoff:     cutoff,
+		hashScheme: chain.triedb.Scheme() == rawdb.HashScheme,
+		config:     chain.chainConfig,
+		db:         chain.db,
+		term:       make(chan chan struct{}),
+		closed:     make(chan struct{}),
+		headCh:     make(chan ChainHeadEvent),
+	}
+	indexer.headSub = chain.SubscribeChainHeadEvent(indexer.headCh)
+	indexer.head.Store(chain.CurrentBlock().Number.Uint64())
+	indexer.indexed.Store(rawdb.ReadAddrIndexRange(chain.db))
+
+	go indexer.loop()
+
+	var msg string
+	if limit == 0 {
+		if indexer.cutoff == 0 {
+			msg = "entire chain"
+		} else {
+			msg = fmt.Sprintf("blocks since #%d", indexer.cutoff)
+		}
+	} else {
+		msg = fmt.Sprintf("last %d blocks", limit)
+	}
+	log.Info("Initialized address indexer", "range", msg)
+
+	return indexer
+}
+
+// from returns the first block to index for the given chain head.
+func (indexer *addrIndexer) from(head uint64) uint64 {
+	var from uint64
+	if indexer.limit != 0 && head >= indexer.limit {
+		from = head - indexer.limit + 1
+	}
+	return max(from, indexer.cutoff)
+}
+
+// run executes the scheduled indexing/unindexing task in a separate thread.
+// If the stop channel is closed, the task should terminate as soon as possible.
+// The done channel will be closed once the task is complete.
+//
+// The blocks of reorged branches are unindexed first, then the new blocks are
+// indexed up to the head, and finally the tail is moved according to the limit.
+func (indexer *addrIndexer) run(head uint64, stop chan struct{}, done chan struct{}) {
+	defer close(done)
+
+	// Purge the index entirely if the chain is below the cutoff point, or if
+	// the index reaches below it, the pruned blocks can't be unindexed
+	indexed := rawdb.ReadAddrIndexRange(indexer.db)
+	if indexed != nil && (head < indexer.cutoff || indexed.Tail < indexer.cutoff) {
+		indexer.purge(stop)
+		indexed = nil
+	}
+	if head < indexer.cutoff {
+		return
+	}
+	from := indexer.from(head)
+
+	// Unwind the blocks which are not canonical anymore, the index is rebuilt
+	// from scratch if the dropped blocks are not available
+	if indexed != nil {
+		var err error
+		if indexed, err = indexer.unwind(indexed, stop); err != nil {
+			if errors.Is(err, errAddrIndexStopped) {
+				return
+			}
+			log.Warn("Failed to unwind address index", "err", err)
+			if !indexer.purge(stop) {
+				return
+			}
+			indexed = nil
+		}
+	}
+	// Start from an empty range at the first block to index if nothing is
+	// indexed yet, or if the index doesn't overlap the range to keep
+	if indexed != nil && indexed.Head+1 < from {
+		if !indexer.purge(stop) {
+			return
+		}
+		indexed = nil
+	}
+	if indexed == nil {
+		// The genesis block contains no transactions, it's marked as indexed
+		// right away to keep the head within the range
+		indexed = &rawdb.AddrIndexRange{Tail: from}
+		if from > 0 {
+			indexed.Head = from - 1
+		}
+		indexed.HeadHash = rawdb.ReadCanonicalHash(indexer.db, indexed.Head)
+		rawdb.WriteAddrIndexRange(indexer.db, *indexed)
+		indexer.indexed.Store(indexed)
+	}
+	if err := indexer.index(indexed, head, from, stop); err != nil && !errors.Is(err, errAddrIndexStopped) {
+		log.Error("Failed to update address index", "err", err)
+	}
+}
+
+// unwind unindexes the blocks at the head of the index which were reorged out
+// of the canonical chain.
+func (indexer *addrIndexer) unwind(indexed *rawdb.AddrIndexRange, stop chan struct{}) (*rawdb.AddrIndexRange, error) {
+	var (
+		batch = indexer.db.NewBatch()
+		next  = *indexed
+		count int
+	)
+	for next.Head >= next.Tail && rawdb.ReadCanonicalHash(indexer.db, next.Head) != next.HeadHash {
+		select {
+		case <-stop:
+			return nil, errAddrIndexStopped
+		default:
+		}
+		if next.Head == 0 {
+			return nil, errors.New("indexed genesis block not canonical")
+		}
+		header := rawdb.ReadHeader(indexer.db, next.HeadHash, next.Head)
+		body := rawdb.ReadBody(indexer.db, next.HeadHash, next.Head)
+		if header == nil || body == nil {
+			return nil, fmt.Errorf("reorged block #%d [%x] unavailable", next.Head, next.HeadHash)
+		}
+		rawdb.DeleteAddrIndexEntries(batch, next.Head, body.Transactions, types.MakeSigner(indexer.config, header.Number, header.Time))
+		next.Head, next.HeadHash = next.Head-1, header.ParentHash
+		count++
+
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := indexer.commit(batch, next); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// Continue from the canonical block below the index if everything was
+	// unwound, the entries of the dropped blocks are gone
+	if next.Head < next.Tail {
+		next.HeadHash = rawdb.ReadCanonicalHash(indexer.db, next.Head)
+	}
+	if count > 0 {
+		if err := indexer.commit(batch, next); err != nil {
+			return nil, err
+		}
+		log.Info("Unindexed reorged blocks from address index", "blocks", count, "head", next.Head)
+	}
+	return &next, nil
+}
+
+// index extends the index up to the given head, then moves its tail to the
+// given first block, reindexing or unindexing the blocks in between.
+func (indexer *addrIndexer) index(indexed *rawdb.AddrIndexRange, head uint64, from uint64, stop chan struct{}) error {
+	var (
+		batch  = indexer.db.NewBatch()
+		next   = *indexed
+		start  = time.Now()
+		logged = time.Now()
+		blocks int
+	)
+	for next.Head < head {
+		select {
+		case <-stop:
+			return errAddrIndexStopped
+		default:
+		}
+		number := next.Head + 1
+		hash := rawdb.ReadCanonicalHash(indexer.db, number)
+		header := rawdb.ReadHeader(indexer.db, hash, number)
+		body := rawdb.ReadBody(indexer.db, hash, number)
+		if header == nil || body == nil {
+			return fmt.Errorf("canonical block #%d unavailable", number)
+		}
+		// Leave the rest to the next run if the chain was reorged meanwhile
+		if header.ParentHash != next.HeadHash {
+			break
+		}
+		rawdb.WriteAddrIndexEntries(batch, number, body.Transactions, types.MakeSigner(indexer.config, header.Number, header.Time))
+		next.Head, next.HeadHash = number, hash
+		blocks++
+
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := indexer.commit(batch, next); err != nil {
+				return err
+			}
+			if time.Since(logged) > 8*time.Second {
+				log.Info("Indexing addresses", "head", next.Head, "target", head, "elapsed", common.PrettyDuration(time.Since(start)))
+				logged = time.Now()
+			}
+		}
+	}
+	// Index the blocks below the tail if the range to keep grew
+	for next.Tail > from {
+		select {
+		case <-stop:
+			return errAddrIndexStopped
+		default:
+		}
+		number := next.Tail - 1
+		hash := rawdb.ReadCanonicalHash(indexer.db, number)
+		header := rawdb.ReadHeader(indexer.db, hash, number)
+		body := rawdb.ReadBody(indexer.db, hash, number)
+		if header == nil || body == nil {
+			return fmt.Errorf("canonical block #%d unavailable", number)
+		}
+		rawdb.WriteAddrIndexEntries(batch, number, body.Transactions, types.MakeSigner(indexer.config, header.Number, header.Time))
+		next.Tail = number
+		blocks++
+
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := indexer.commit(batch, next); err != nil {
+				return err
+			}
+			if time.Since(logged) > 8*time.Second {
+				log.Info("Indexing addresses", "tail", next.Tail, "target", from, "elapsed", common.PrettyDuration(time.Since(start)))
+				logged = time.Now()
+			}
+		}
+	}
+	// Unindex the blocks below the range to keep
+	for next.Tail < from && next.Tail <= next.Head {
+		select {
+		case <-stop:
+			return errAddrIndexStopped
+		default:
+		}
+		number := next.Tail
+		hash := rawdb.ReadCanonicalHash(indexer.db, number)
+		header := rawdb.ReadHeader(indexer.db, hash, number)
+		body := rawdb.ReadBody(indexer.db, hash, number)
+		if header == nil || body == nil {
+			return fmt.Errorf("canonical block #%d unavailable", number)
+		}
+		rawdb.DeleteAddrIndexEntries(batch, number, body.Transactions, types.MakeSigner(indexer.config, header.Number, header.Time))
+		next.Tail = number + 1
+
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := indexer.commit(batch, next); err != nil {
+				return err
+			}
+		}
+	}
+	if err := indexer.commit(batch, next); err != nil {
+		return err
+	}
+	if blocks > 0 {
+		log.Debug("Indexed addresses", "blocks", blocks, "tail", next.Tail, "head", next.Head, "elapsed", common.PrettyDuration(time.Since(start)))
+	}
+	return nil
+}
+
+// commit writes the batch of index changes along with the new indexed range.
+func (indexer *addrIndexer) commit(batch ethdb.Batch, indexed rawdb.AddrIndexRange) error {
+	rawdb.WriteAddrIndexRange(batch, indexed)
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	batch.Reset()
+	indexer.indexed.Store(&indexed)
+	return nil
+}
+
+// purge removes the entire address index, returning whether it completed.
+func (indexer *addrIndexer) purge(stop chan struct{}) bool {
+	indexer.indexed.Store(nil)
+	err := rawdb.DeleteAddrIndex(indexer.db, indexer.hashScheme, func(bool) bool {
+		select {
+		case <-stop:
+			return true
+		default:
+			return false
+		}
+	})
+	if err != nil {
+		log.Warn("Failed to purge address index", "err", err)
+		return false
+	}
+	log.Info("Purged address index")
+	return true
+}
+
+// loop is the scheduler of the indexer, assigning indexing/unindexing tasks depending
+// on the received chain event.
+func (indexer *addrIndexer) loop() {
+	defer close(indexer.closed)
+	defer indexer.headSub.Unsubscribe()
+
+	var (
+		stop = make(chan struct{}) // Non-nil if background routine is active
+		done = make(chan struct{}) // Non-nil if background routine is active
+		head = indexer.head.Load()
+	)
+	go indexer.run(head, stop, done)
+
+	// Rerun the indexer after each task if the head moved meanwhile
+	var pending bool
+	for {
+		select {
+		case h := <-indexer.headCh:
+			indexer.head.Store(h.Header.Number.Uint64())
+			if done == nil {
+				stop = make(chan struct{})
+				done = make(chan struct{})
+				go indexer.run(h.Header.Number.Uint64(), stop, done)
+			} else {
+				pending = true
+			}
+
+		case <-done:
+			stop = nil
+			done = nil
+			indexer.indexed.Store(rawdb.ReadAddrIndexRange(indexer.db))
+			if pending {
+				pending = false
+				stop = make(chan struct{})
+				done = make(chan struct{})
+				go indexer.run(indexer.head.Load(), stop, done)
+			}
+
+		case ch := <-indexer.term:
+			if stop != nil {
+				close(stop)
+			}
+			if done != nil {
+				log.Info("Waiting background address indexer to exit")
+				<-done
+			}
+			close(ch)
+			return
+		}
+	}
+}
+
+// progress returns the address indexing progress.
+func (indexer *addrIndexer) progress() TxIndexProgress {
+	head := indexer.head.Load()
+	if head < indexer.cutoff {
+		return TxIndexProgress{}
+	}
+	var (
+		from  = indexer.from(head)
+		total = head - from + 1
+	)
+	indexed := indexer.indexed.Load()
+	if indexed == nil || indexed.Head < from || indexed.Tail > head || indexed.Head < indexed.Tail {
+		return TxIndexProgress{Remaining: total}
+	}
+	// Count the blocks still to be indexed at either end of the range. Stale
+	// blocks below the range to keep are not accounted for.
+	var remaining uint64
+	if indexed.Head < head {
+		remaining += head - indexed.Head
+	}
+	if indexed.Tail > from {
+		remaining += indexed.Tail - from
+	}
+	return TxIndexProgress{Indexed: total - remaining, Remaining: remaining}
+}
+
+// close shutdown the indexer. Safe to be called for multiple times.
+func (indexer *addrIndexer) close() {
+	ch := make(chan struct{})
+	select {
+	case indexer.term <- ch:
+		<-ch
+	case <-indexer.closed:
+	}
+}
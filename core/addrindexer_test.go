@@ -0,0 +1,208 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// waitAddrIndex waits until the address index covers the chain head, starting
+// at the given tail. Unindexing the blocks below the tail doesn't count in the
+// progress, so the range is polled instead.
+func waitAddrIndex(t *testing.T, chain *BlockChain, tail uint64) *rawdb.AddrIndexRange {
+	t.Helper()
+
+	head := chain.CurrentBlock()
+	for i := 0; i < 1000; i++ {
+		progress, err := chain.AddrIndexProgress()
+		if err != nil {
+			t.Fatalf("Failed to retrieve address index progress: %v", err)
+		}
+		indexed := rawdb.ReadAddrIndexRange(chain.db)
+		if progress.Done() && indexed != nil && indexed.Tail == tail && indexed.HeadHash == head.Hash() {
+			return indexed
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Address index not caught up with head #%d", head.Number)
+	return nil
+}
+
+// addrTxHashes retrieves the hashes of all indexed transactions of an account.
+func addrTxHashes(t *testing.T, chain *BlockChain, addr common.Address) []common.Hash {
+	t.Helper()
+
+	var (
+		hashes []common.Hash
+		next   = &rawdb.AddrTxEntry{}
+	)
+	for next != nil {
+		var (
+			txs []*AddrTransaction
+			err error
+		)
+		txs, next, err = chain.GetTransactionsByAddress(addr, next.Number, next.Index, 3)
+		if err != nil {
+			t.Fatalf("Failed to retrieve transactions of %x: %v", addr, err)
+		}
+		for _, tx := range txs {
+			hashes = append(hashes, tx.Tx.Hash())
+		}
+	}
+	return hashes
+}
+
+// TestAddrIndexer tests the maintenance of the address index across tail
+// pruning and reorgs.
+func TestAddrIndexer(t *testing.T) {
+	var (
+		key, _    = crypto.GenerateKey()
+		sender    = crypto.PubkeyToAddress(key.PublicKey)
+		recipient = common.HexToAddress("0xdeadbeef")
+		other     = common.HexToAddress("0xcafebabe")
+		created   = crypto.CreateAddress(sender, 2)
+		signer    = types.LatestSigner(params.TestChainConfig)
+
+		gspec = &Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   types.GenesisAlloc{sender: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		engine = ethash.NewFaker()
+	)
+	// Send a transfer to the recipient in each block, except for a contract
+	// creation in the third one. The side chain sends to another account from
+	// the eighth block on.
+	makeTx := func(nonce uint64, to *common.Address) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			To:       to,
+			Value:    big.NewInt(1000),
+			Gas:      100000,
+			GasPrice: big.NewInt(10 * params.InitialBaseFee),
+		})
+	}
+	db, blocks, _ := GenerateChainWithGenesis(gspec, engine, 16, func(i int, gen *BlockGen) {
+		if i == 2 {
+			gen.AddTx(makeTx(uint64(i), nil))
+		} else {
+			gen.AddTx(makeTx(uint64(i), &recipient))
+		}
+	})
+	side, _ := GenerateChain(gspec.Config, blocks[6], engine, db, 12, func(i int, gen *BlockGen) {
+		gen.AddTx(makeTx(uint64(7+i), &other))
+	})
+	cfg := DefaultConfig()
+	cfg.AddrIndex = true
+
+	chaindb := rawdb.NewMemoryDatabase()
+	chain, err := NewBlockChain(chaindb, gspec, engine, cfg)
+	if err != nil {
+		t.Fatalf("Failed to create chain: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("Failed to insert chain: %v", err)
+	}
+	if indexed := waitAddrIndex(t, chain, 0); indexed.Tail != 0 || indexed.Head != 16 {
+		t.Fatalf("Indexed range mismatch: have [%d, %d], want [0, 16]", indexed.Tail, indexed.Head)
+	}
+	if hashes := addrTxHashes(t, chain, sender); len(hashes) != 16 {
+		t.Fatalf("Sender transaction count mismatch: have %d, want 16", len(hashes))
+	}
+	if hashes := addrTxHashes(t, chain, recipient); len(hashes) != 15 {
+		t.Fatalf("Recipient transaction count mismatch: have %d, want 15", len(hashes))
+	}
+	if hashes := addrTxHashes(t, chain, created); len(hashes) != 1 || hashes[0] != blocks[2].Transactions()[0].Hash() {
+		t.Fatalf("Created contract transactions mismatch: %x", hashes)
+	}
+	tx, err := chain.GetTransactionBySenderAndNonce(sender, 5)
+	if err != nil || tx == nil || tx.Tx.Hash() != blocks[5].Transactions()[0].Hash() || tx.BlockNumber != 6 || tx.Roles != rawdb.AddrTxSender {
+		t.Fatalf("Sender nonce lookup mismatch: %+v, %v", tx, err)
+	}
+	// Reorg to the side chain, the transactions of the dropped blocks must be
+	// unindexed
+	if _, err := chain.InsertChain(side); err != nil {
+		t.Fatalf("Failed to insert side chain: %v", err)
+	}
+	if chain.CurrentBlock().Hash() != side[len(side)-1].Hash() {
+		t.Fatal("Side chain not canonical")
+	}
+	if indexed := waitAddrIndex(t, chain, 0); indexed.Head != 19 {
+		t.Fatalf("Indexed head mismatch: have %d, want 19", indexed.Head)
+	}
+	if hashes := addrTxHashes(t, chain, recipient); len(hashes) != 6 {
+		t.Fatalf("Recipient transaction count mismatch after reorg: have %d, want 6", len(hashes))
+	}
+	if hashes := addrTxHashes(t, chain, other); len(hashes) != 12 {
+		t.Fatalf("Side recipient transaction count mismatch: have %d, want 12", len(hashes))
+	}
+	if entries := rawdb.ReadAddrTxEntries(chaindb, recipient, 0, 0, 100); len(entries) != 6 {
+		t.Fatalf("Stale entries left after reorg: have %d, want 6", len(entries))
+	}
+	tx, err = chain.GetTransactionBySenderAndNonce(sender, 10)
+	if err != nil || tx == nil || tx.Tx.Hash() != side[3].Transactions()[0].Hash() {
+		t.Fatalf("Sender nonce lookup mismatch after reorg: %+v, %v", tx, err)
+	}
+	chain.Stop()
+
+	// Restart with a limit, the blocks below the tail must be unindexed
+	cfg.AddrIndexLimit = 10
+	chain, err = NewBlockChain(chaindb, gspec, engine, cfg)
+	if err != nil {
+		t.Fatalf("Failed to recreate chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if indexed := waitAddrIndex(t, chain, 10); indexed.Tail != 10 || indexed.Head != 19 {
+		t.Fatalf("Indexed range mismatch: have [%d, %d], want [10, 19]", indexed.Tail, indexed.Head)
+	}
+	if hashes := addrTxHashes(t, chain, sender); len(hashes) != 10 {
+		t.Fatalf("Sender transaction count mismatch after pruning: have %d, want 10", len(hashes))
+	}
+	if tx, _ := chain.GetTransactionBySenderAndNonce(sender, 5); tx != nil {
+		t.Fatalf("Pruned sender nonce still indexed: %+v", tx)
+	}
+	verifyAddrIndexSize(t, chaindb, 10)
+}
+
+// verifyAddrIndexSize checks the number of address index entries, each of the
+// indexed transactions having a sender, a recipient and a sender nonce entry.
+func verifyAddrIndexSize(t *testing.T, db ethdb.Database, txs int) {
+	t.Helper()
+
+	var count int
+	it := db.NewIterator([]byte("i"), nil)
+	defer it.Release()
+	for it.Next() {
+		if key := it.Key(); len(key) > 1 && (key[1] == 'a' || key[1] == 'n') {
+			count++
+		}
+	}
+	if count != 3*txs {
+		t.Fatalf("Address index entry count mismatch: have %d, want %d", count, 3*txs)
+	}
+}
@@ -215,6 +215,15 @@ type BlockChainConfig struct {
 	// If the value is -1, indexing is disabled.
 	TxLookupLimit int64
 
+	// AddrIndex enables the indexing of the transactions of each sender,
+	// recipient and created contract.
+	AddrIndex bool
+
+	// AddrIndexLimit specifies the maximum number of blocks from head for which
+	// the address index is maintained, if enabled. If the value is zero, the
+	// entire chain will be indexed.
+	AddrIndexLimit uint64
+
 	// StateSizeTracking indicates whether the state size tracking is enabled.
 	StateSizeTracking bool
 
@@ -333,6 +342,7 @@ type BlockChain struct {
 	codedb        *state.CodeDB                    // The database handler for maintaining contract codes.
 	jumpDestCache vm.JumpDestCache                 // Shared JUMPDEST analysis cache for block processing
 	txIndexer     *txIndexer                       // Transaction indexer, might be nil if not enabled
+	addrIndexer   *addrIndexer                     // Address indexer, might be nil if not enabled
 
 	hc               *HeaderChain
 	rmLogsFeed       event.Feed
@@ -568,6 +578,10 @@ func NewBlockChain(db ethdb.Database, genesis *Genesis, engine consensus.Engine,
 	if bc.cfg.TxLookupLimit >= 0 {
 		bc.txIndexer = newTxIndexer(uint64(bc.cfg.TxLookupLimit), bc)
 	}
+	// Start address indexer if it's enabled.
+	if bc.cfg.AddrIndex {
+		bc.addrIndexer = newAddrIndexer(bc.cfg.AddrIndexLimit, bc)
+	}
 
 	// Start state size tracker
 	if bc.cfg.StateSizeTracking {
@@ -1347,6 +1361,10 @@ func (bc *BlockChain) stopWithoutSaving() {
 	if bc.txIndexer != nil {
 		bc.txIndexer.close()
 	}
+	// Signal shutdown address indexer.
+	if bc.addrIndexer != nil {
+		bc.addrIndexer.close()
+	}
 	// Unsubscribe all subscriptions registered from blockchain.
 	bc.scope.Close()
 
@@ -499,6 +499,87 @@ func (bc *BlockChain) TxIndexProgress() (TxIndexProgress, error) {
 	return bc.txIndexer.txIndexProgress(), nil
 }
 
+// AddrIndexProgress returns the address indexing progress.
+func (bc *BlockChain) AddrIndexProgress() (TxIndexProgress, error) {
+	if bc.addrIndexer == nil {
+		return TxIndexProgress{}, ErrAddrIndexDisabled
+	}
+	return bc.addrIndexer.progress(), nil
+}
+
+// AddrTransaction is a canonical transaction found through the address index.
+type AddrTransaction struct {
+	Tx          *types.Transaction
+	BlockHash   common.Hash
+	BlockNumber uint64
+	Index       uint64
+	Roles       byte // Roles of the queried account, see rawdb.AddrTxSender and co.
+}
+
+// resolveAddrTx retrieves the canonical transaction of an address index entry,
+// or nil if the entry is stale because its block was reorged out.
+func (bc *BlockChain) resolveAddrTx(entry rawdb.AddrTxEntry) *AddrTransaction {
+	hash := bc.GetCanonicalHash(entry.Number)
+	if hash == (common.Hash{}) {
+		return nil
+	}
+	body := bc.GetBody(hash)
+	if body == nil || uint64(entry.Index) >= uint64(len(body.Transactions)) {
+		return nil
+	}
+	tx := body.Transactions[entry.Index]
+	if tx.Hash() != entry.Hash {
+		return nil
+	}
+	return &AddrTransaction{
+		Tx:          tx,
+		BlockHash:   hash,
+		BlockNumber: entry.Number,
+		Index:       uint64(entry.Index),
+		Roles:       entry.Roles,
+	}
+}
+
+// GetTransactionBySenderAndNonce retrieves the canonical transaction sent by
+// an account with the given nonce. A null is returned if the transaction is
+// not found, which may be due to the address indexer not being finished.
+func (bc *BlockChain) GetTransactionBySenderAndNonce(sender common.Address, nonce uint64) (*AddrTransaction, error) {
+	if bc.addrIndexer == nil {
+		return nil, ErrAddrIndexDisabled
+	}
+	entry := rawdb.ReadSenderNonceEntry(bc.db, sender, nonce)
+	if entry == nil {
+		return nil, nil
+	}
+	return bc.resolveAddrTx(*entry), nil
+}
+
+// GetTransactionsByAddress retrieves at most limit canonical transactions sent,
+// received or created by an account, in chain order, starting at the given block
+// number and transaction index. The position to continue from is also returned,
+// or nil if there are no more transactions.
+//
+// Only the transactions of the indexed range are visible, the caller must check
+// the indexer progress.
+func (bc *BlockChain) GetTransactionsByAddress(addr common.Address, number uint64, index uint32, limit int) ([]*AddrTransaction, *rawdb.AddrTxEntry, error) {
+	if bc.addrIndexer == nil {
+		return nil, nil, ErrAddrIndexDisabled
+	}
+	entries := rawdb.ReadAddrTxEntries(bc.db, addr, number, index, limit+1)
+
+	var next *rawdb.AddrTxEntry
+	if len(entries) > limit {
+		next, entries = &entries[limit], entries[:limit]
+	}
+	txs := make([]*AddrTransaction, 0, len(entries))
+	for _, entry := range entries {
+		if tx := bc.resolveAddrTx(entry); tx != nil {
+			txs = append(txs, tx)
+		}
+	}
+	return txs, next, nil
+}
+
 // StateIndexProgress returns the historical state indexing progress.
 func (bc *BlockChain) StateIndexProgress() (uint64, uint64, error) {
 	return bc.triedb.IndexProgress()
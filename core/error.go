@@ -32,6 +32,10 @@ var (
 	// ErrBlockOversized is returned if the size of the RLP-encoded block
 	// exceeds the cap established by EIP 7934
 	ErrBlockOversized = errors.New("block RLP-encoded size exceeds maximum")
+
+	// ErrAddrIndexDisabled is returned when querying the address index of a
+	// chain which doesn't maintain it.
+	ErrAddrIndexDisabled = errors.New("address index is not enabled")
 )
 
 // List of evm-call-message pre-checking errors. All state transition messages will
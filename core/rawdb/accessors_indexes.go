@@ -631,3 +631,190 @@ func DeleteBloomBitsDb(db ethdb.KeyValueStore, hashScheme bool, stopCallback fun
 	}
 	return deletePrefixRange(db, bloomBitsMetaPrefix, hashScheme, stopCallback)
 }
+
+// Roles an account may have in a transaction of the address index.
+const (
+	AddrTxSender    byte = 1 << iota // The account sent the transaction
+	AddrTxRecipient                  // The account is the recipient of the transaction
+	AddrTxCreated                    // The account was created by the transaction
+)
+
+// AddrIndexRange is the range of blocks whose transactions are present in the
+// address index.
+type AddrIndexRange struct {
+	Tail     uint64      // First indexed block
+	Head     uint64      // Last indexed block
+	HeadHash common.Hash // Hash of the last indexed block, to detect reorgs
+}
+
+// ReadAddrIndexRange retrieves the range of blocks covered by the address index,
+// or nil if nothing was indexed yet.
+func ReadAddrIndexRange(db ethdb.KeyValueReader) *AddrIndexRange {
+	data, _ := db.Get(addrIndexRangeKey)
+	if len(data) == 0 {
+		return nil
+	}
+	var r AddrIndexRange
+	if err := rlp.DecodeBytes(data, &r); err != nil {
+		log.Error("Invalid address index range RLP", "err", err)
+		return nil
+	}
+	return &r
+}
+
+// WriteAddrIndexRange stores the range of blocks covered by the address index.
+func WriteAddrIndexRange(db ethdb.KeyValueWriter, r AddrIndexRange) {
+	enc, err := rlp.EncodeToBytes(&r)
+	if err != nil {
+		log.Crit("Failed to encode address index range", "err", err)
+	}
+	if err := db.Put(addrIndexRangeKey, enc); err != nil {
+		log.Crit("Failed to store address index range", "err", err)
+	}
+}
+
+// DeleteAddrIndexRange deletes the range of blocks covered by the address index.
+func DeleteAddrIndexRange(db ethdb.KeyValueWriter) {
+	if err := db.Delete(addrIndexRangeKey); err != nil {
+		log.Crit("Failed to delete address index range", "err", err)
+	}
+}
+
+// AddrTxEntry is the position of a transaction in the address index.
+type AddrTxEntry struct {
+	Number uint64      // Number of the block containing the transaction
+	Index  uint32      // Index of the transaction within the block
+	Roles  byte        // Roles of the account in the transaction
+	Hash   common.Hash // Hash of the transaction, to detect stale entries
+}
+
+// addrIndexEntries collects the accounts involved in the transactions of a block,
+// along with the sender of each one.
+func addrIndexEntries(number uint64, txs types.Transactions, signer types.Signer) (map[common.Address]map[uint32]byte, []*common.Address) {
+	var (
+		entries = make(map[common.Address]map[uint32]byte)
+		senders = make([]*common.Address, len(txs))
+	)
+	add := func(addr common.Address, index uint32, role byte) {
+		if entries[addr] == nil {
+			entries[addr] = make(map[uint32]byte)
+		}
+		entries[addr][index] |= role
+	}
+	for i, tx := range txs {
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			log.Warn("Skipping address indexing of invalid transaction", "block", number, "index", i, "err", err)
+			continue
+		}
+		senders[i] = &from
+		add(from, uint32(i), AddrTxSender)
+		if to := tx.To(); to != nil {
+			add(*to, uint32(i), AddrTxRecipient)
+		} else {
+			add(crypto.CreateAddress(from, tx.Nonce()), uint32(i), AddrTxCreated)
+		}
+	}
+	return entries, senders
+}
+
+// WriteAddrIndexEntries stores the address index entries of the transactions of
+// a block: the transactions each account sent, received or was created by, and
+// the transaction of each sender and nonce.
+func WriteAddrIndexEntries(db ethdb.KeyValueWriter, number uint64, txs types.Transactions, signer types.Signer) {
+	entries, senders := addrIndexEntries(number, txs, signer)
+	for addr, roles := range entries {
+		for index, role := range roles {
+			value := append([]byte{role}, txs[index].Hash().Bytes()...)
+			if err := db.Put(addrTxKey(addr, number, index), value); err != nil {
+				log.Crit("Failed to store address index entry", "err", err)
+			}
+		}
+	}
+	for i, sender := range senders {
+		if sender == nil {
+			continue
+		}
+		value := make([]byte, 8+4+common.HashLength)
+		binary.BigEndian.PutUint64(value, number)
+		binary.BigEndian.PutUint32(value[8:], uint32(i))
+		copy(value[12:], txs[i].Hash().Bytes())
+		if err := db.Put(senderNonceKey(*sender, txs[i].Nonce()), value); err != nil {
+			log.Crit("Failed to store sender nonce entry", "err", err)
+		}
+	}
+}
+
+// DeleteAddrIndexEntries removes the address index entries of the transactions
+// of a block.
+func DeleteAddrIndexEntries(db ethdb.KeyValueWriter, number uint64, txs types.Transactions, signer types.Signer) {
+	entries, senders := addrIndexEntries(number, txs, signer)
+	for addr, roles := range entries {
+		for index := range roles {
+			if err := db.Delete(addrTxKey(addr, number, index)); err != nil {
+				log.Crit("Failed to delete address index entry", "err", err)
+			}
+		}
+	}
+	for i, sender := range senders {
+		if sender == nil {
+			continue
+		}
+		if err := db.Delete(senderNonceKey(*sender, txs[i].Nonce())); err != nil {
+			log.Crit("Failed to delete sender nonce entry", "err", err)
+		}
+	}
+}
+
+// ReadAddrTxEntries retrieves at most limit address index entries of an account,
+// starting at the given block number and transaction index, in chain order.
+func ReadAddrTxEntries(db ethdb.Iteratee, addr common.Address, number uint64, index uint32, limit int) []AddrTxEntry {
+	var (
+		prefix = append(bytes.Clone(addrTxPrefix), addr.Bytes()...)
+		start  = addrTxKey(addr, number, index)[len(prefix):]
+		it     = db.NewIterator(prefix, start)
+	)
+	defer it.Release()
+
+	var entries []AddrTxEntry
+	for len(entries) < limit && it.Next() {
+		key, value := it.Key(), it.Value()
+		if len(key) != len(prefix)+8+4 || len(value) != 1+common.HashLength {
+			continue
+		}
+		entries = append(entries, AddrTxEntry{
+			Number: binary.BigEndian.Uint64(key[len(prefix):]),
+			Index:  binary.BigEndian.Uint32(key[len(prefix)+8:]),
+			Roles:  value[0],
+			Hash:   common.BytesToHash(value[1:]),
+		})
+	}
+	return entries
+}
+
+// ReadSenderNonceEntry retrieves the position of the transaction sent by an
+// account with the given nonce, or nil if it's not indexed.
+func ReadSenderNonceEntry(db ethdb.KeyValueReader, sender common.Address, nonce uint64) *AddrTxEntry {
+	data, _ := db.Get(senderNonceKey(sender, nonce))
+	if len(data) != 8+4+common.HashLength {
+		return nil
+	}
+	return &AddrTxEntry{
+		Number: binary.BigEndian.Uint64(data),
+		Index:  binary.BigEndian.Uint32(data[8:]),
+		Roles:  AddrTxSender,
+		Hash:   common.BytesToHash(data[12:]),
+	}
+}
+
+// DeleteAddrIndex removes the entire address index from the database.
+func DeleteAddrIndex(db ethdb.KeyValueStore, hashScheme bool, stopCallback func(bool) bool) error {
+	if err := deletePrefixRange(db, addrTxPrefix, hashScheme, stopCallback); err != nil {
+		return err
+	}
+	if err := deletePrefixRange(db, senderNoncePrefix, hashScheme, stopCallback); err != nil {
+		return err
+	}
+	DeleteAddrIndexRange(db)
+	return nil
+}
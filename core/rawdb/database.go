@@ -443,6 +443,7 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		storageTries       stat
 		codes              stat
 		txLookups          stat
+		addrIndex          stat
 		accountSnaps       stat
 		storageSnaps       stat
 		preimages          stat
@@ -523,6 +524,10 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 				codes.add(size)
 			case bytes.HasPrefix(key, txLookupPrefix) && len(key) == (len(txLookupPrefix)+common.HashLength):
 				txLookups.add(size)
+			case bytes.HasPrefix(key, addrTxPrefix) && len(key) == (len(addrTxPrefix)+common.AddressLength+8+4):
+				addrIndex.add(size)
+			case bytes.HasPrefix(key, senderNoncePrefix) && len(key) == (len(senderNoncePrefix)+common.AddressLength+8):
+				addrIndex.add(size)
 			case bytes.HasPrefix(key, SnapshotAccountPrefix) && len(key) == (len(SnapshotAccountPrefix)+common.HashLength):
 				accountSnaps.add(size)
 			case bytes.HasPrefix(key, SnapshotStoragePrefix) && len(key) == (len(SnapshotStoragePrefix)+2*common.HashLength):
@@ -654,6 +659,7 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		{"Key-Value store", "Block hash->number", hashNumPairings.sizeString(), hashNumPairings.countString()},
 		{"Key-Value store", "Block accessList", blockAccessList.sizeString(), blockAccessList.countString()},
 		{"Key-Value store", "Transaction index", txLookups.sizeString(), txLookups.countString()},
+		{"Key-Value store", "Address index", addrIndex.sizeString(), addrIndex.countString()},
 		{"Key-Value store", "Log index filter-map rows", filterMapRows.sizeString(), filterMapRows.countString()},
 		{"Key-Value store", "Log index last-block-of-map", filterMapLastBlock.sizeString(), filterMapLastBlock.countString()},
 		{"Key-Value store", "Log index block-lv", filterMapBlockLV.sizeString(), filterMapBlockLV.countString()},
@@ -711,7 +717,7 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 var knownMetadataKeys = [][]byte{
 	databaseVersionKey, headHeaderKey, headBlockKey, headFastBlockKey, headFinalizedBlockKey,
 	lastPivotKey, fastTrieProgressKey, snapshotDisabledKey, SnapshotRootKey, snapshotJournalKey,
-	snapshotGeneratorKey, snapshotRecoveryKey, txIndexTailKey, fastTxLookupLimitKey,
+	snapshotGeneratorKey, snapshotRecoveryKey, txIndexTailKey, fastTxLookupLimitKey, addrIndexRangeKey,
 	uncleanShutdownKey, badBlockKey, transitionStatusKey, skeletonSyncStatusKey,
 	persistentStateIDKey, trieJournalKey, snapshotSyncStatusKey, snapSyncStatusFlagKey,
 	filterMapsRangeKey, headStateHistoryIndexKey, headTrienodeHistoryIndexKey, VerkleTransitionStatePrefix,
@@ -87,6 +87,10 @@ var (
 	// txIndexTailKey tracks the oldest block whose transactions have been indexed.
 	txIndexTailKey = []byte("TransactionIndexTail")
 
+	// addrIndexRangeKey tracks the range of blocks whose transactions have been
+	// added to the address index.
+	addrIndexRangeKey = []byte("AddressIndexRange")
+
 	// fastTxLookupLimitKey tracks the transaction lookup limit during fast sync.
 	// This flag is deprecated, it's kept to avoid reporting errors when inspect
 	// database.
@@ -162,6 +166,10 @@ var (
 	// old log index
 	bloomBitsMetaPrefix = []byte("iB")
 
+	// address index
+	addrTxPrefix      = []byte("ia") // addrTxPrefix + address + num (uint64 big endian) + index (uint32 big endian) -> roles + tx hash
+	senderNoncePrefix = []byte("in") // senderNoncePrefix + address + nonce (uint64 big endian) -> num (uint64 big endian) + index (uint32 big endian) + tx hash
+
 	preimageCounter     = metrics.NewRegisteredCounter("db/preimage/total", nil)
 	preimageHitsCounter = metrics.NewRegisteredCounter("db/preimage/hits", nil)
 	preimageMissCounter = metrics.NewRegisteredCounter("db/preimage/miss", nil)
@@ -225,6 +233,25 @@ func txLookupKey(hash common.Hash) []byte {
 	return append(txLookupPrefix, hash.Bytes()...)
 }
 
+// addrTxKey = addrTxPrefix + address + num (uint64 big endian) + index (uint32 big endian)
+func addrTxKey(addr common.Address, number uint64, index uint32) []byte {
+	buf := make([]byte, len(addrTxPrefix)+common.AddressLength+8+4)
+	n := copy(buf, addrTxPrefix)
+	n += copy(buf[n:], addr.Bytes())
+	binary.BigEndian.PutUint64(buf[n:], number)
+	binary.BigEndian.PutUint32(buf[n+8:], index)
+	return buf
+}
+
+// senderNonceKey = senderNoncePrefix + address + nonce (uint64 big endian)
+func senderNonceKey(sender common.Address, nonce uint64) []byte {
+	buf := make([]byte, len(senderNoncePrefix)+common.AddressLength+8)
+	n := copy(buf, senderNoncePrefix)
+	n += copy(buf[n:], sender.Bytes())
+	binary.BigEndian.PutUint64(buf[n:], nonce)
+	return buf
+}
+
 // accountSnapshotKey = SnapshotAccountPrefix + hash
 func accountSnapshotKey(hash common.Hash) []byte {
 	return append(SnapshotAccountPrefix, hash.Bytes()...)
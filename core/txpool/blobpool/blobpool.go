@@ -575,6 +575,9 @@ type BlobPool struct {
 	discoverFeed event.Feed // Event feed to send out new tx events on pool discovery (reorg excluded)
 	insertFeed   event.Feed // Event feed to send out new tx events on pool inclusion (reorg included)
 
+	lifecycleFeed event.Feed           // Event feed to send out transaction state changes
+	lifecycle     []txpool.TxLifecycle // State changes to send out when the running operation finishes
+
 	lock sync.RWMutex // Mutex protecting the pool during reorg handling
 }
 
@@ -940,6 +943,12 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.stored -= uint64(txs[i].storageSize)
 			p.lookup.untrack(txs[i])
 
+			if gapped {
+				p.trackLifecycle(txs[i].hash, txpool.TxDropped, txpool.DropNonceGap)
+			} else {
+				p.trackStale(txs[i].hash, inclusions)
+			}
+
 			// Included transactions blobs need to be moved to the limbo
 			if filled && inclusions != nil {
 				p.offload(addr, txs[i].nonce, txs[i].id, inclusions)
@@ -980,6 +989,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], txs[0].costCap)
 			p.stored -= uint64(txs[0].storageSize)
 			p.lookup.untrack(txs[0])
+			p.trackStale(txs[0].hash, inclusions)
 
 			// Included transactions blobs need to be moved to the limbo
 			if inclusions != nil {
@@ -1036,6 +1046,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], txs[i].costCap)
 			p.stored -= uint64(txs[i].storageSize)
 			p.lookup.untrack(txs[i])
+			p.trackLifecycle(txs[i].hash, txpool.TxDropped, txpool.DropInvalid)
 
 			if err := p.store.Delete(id); err != nil {
 				log.Error("Failed to delete blob transaction", "from", addr, "id", id, "err", err)
@@ -1058,6 +1069,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], txs[j].costCap)
 			p.stored -= uint64(txs[j].storageSize)
 			p.lookup.untrack(txs[j])
+			p.trackLifecycle(txs[j].hash, txpool.TxDropped, txpool.DropNonceGap)
 		}
 		txs = txs[:i]
 
@@ -1096,6 +1108,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], last.costCap)
 			p.stored -= uint64(last.storageSize)
 			p.lookup.untrack(last)
+			p.trackLifecycle(last.hash, txpool.TxDropped, txpool.DropInsufficientFunds)
 		}
 		if len(txs) == 0 {
 			delete(p.index, addr)
@@ -1136,6 +1149,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], last.costCap)
 			p.stored -= uint64(last.storageSize)
 			p.lookup.untrack(last)
+			p.trackLifecycle(last.hash, txpool.TxEvicted, txpool.DropAccountLimit)
 		}
 		p.index[addr] = txs
 
@@ -1190,7 +1204,7 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 	waitStart := time.Now()
 	p.lock.Lock()
 	resetwaitHist.Update(time.Since(waitStart).Nanoseconds())
-	defer p.lock.Unlock()
+	defer p.unlock()
 
 	defer func(start time.Time) {
 		resettimeHist.Update(time.Since(start).Nanoseconds())
@@ -1216,6 +1230,7 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 			for _, tx := range txs {
 				if err := p.reinject(addr, tx.Hash()); err == nil {
 					adds = append(adds, tx.WithoutBlobTxSidecar())
+					p.trackLifecycle(tx.Hash(), txpool.TxAdded, "")
 				}
 			}
 			// Recheck the account's pooled transactions to drop included and
@@ -1498,7 +1513,7 @@ func (p *BlobPool) reinject(addr common.Address, txhash common.Hash) error {
 // to be kept in sync with the main transaction pool's gas requirements.
 func (p *BlobPool) SetGasTip(tip *big.Int) {
 	p.lock.Lock()
-	defer p.lock.Unlock()
+	defer p.unlock()
 
 	// Store the new minimum gas tip
 	old := p.gasTip.Load()
@@ -1510,46 +1525,11 @@ func (p *BlobPool) SetGasTip(tip *big.Int) {
 		for addr, txs := range p.index {
 			for i, tx := range txs {
 				if tx.execTipCap.Cmp(newTip) < 0 {
-					// Drop the offending transaction
-					var (
-						ids    = []uint64{tx.id}
-						nonces = []uint64{tx.nonce}
-					)
-					p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], txs[i].costCap)
-					p.stored -= uint64(tx.storageSize)
-					p.lookup.untrack(tx)
-					txs[i] = nil
-
-					// Drop everything afterwards, no gaps allowed
-					for j, tx := range txs[i+1:] {
-						ids = append(ids, tx.id)
-						nonces = append(nonces, tx.nonce)
-
-						p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], tx.costCap)
-						p.stored -= uint64(tx.storageSize)
-						p.lookup.untrack(tx)
-						txs[i+1+j] = nil
-					}
-					// Clear out the dropped transactions from the index
-					if i > 0 {
-						p.index[addr] = txs[:i]
-						heap.Fix(p.evict, p.evict.index[addr])
-					} else {
-						delete(p.index, addr)
-						delete(p.spent, addr)
-
-						heap.Remove(p.evict, p.evict.index[addr])
-						p.reserver.Release(addr)
-					}
-					// Clear out the transactions from the data store
+					// Drop the offending transaction and everything afterwards
+					ids, nonces := p.dropFrom(addr, i, txpool.DropUnderpriced)
+
 					log.Warn("Dropping underpriced blob transaction", "from", addr, "rejected", tx.nonce, "tip", tx.execTipCap, "want", tip, "drop", nonces, "ids", ids)
 					dropUnderpricedMeter.Mark(int64(len(ids)))
-
-					for _, id := range ids {
-						if err := p.store.Delete(id); err != nil {
-							log.Error("Failed to delete dropped transaction", "id", id, "err", err)
-						}
-					}
 					break
 				}
 			}
@@ -1560,6 +1540,93 @@ func (p *BlobPool) SetGasTip(tip *big.Int) {
 	p.updateStorageMetrics()
 }
 
+// dropFrom removes the transaction at the given position of an account's queue
+// from the pool, along with everything afterwards, as no gaps are allowed. The
+// ids and nonces of the dropped transactions are returned.
+//
+// Concurrency: The caller must hold the pool lock before calling this function.
+func (p *BlobPool) dropFrom(addr common.Address, i int, reason txpool.TxDropReason) ([]uint64, []uint64) {
+	var (
+		txs    = p.index[addr]
+		ids    []uint64
+		nonces []uint64
+	)
+	for j, tx := range txs[i:] {
+		ids = append(ids, tx.id)
+		nonces = append(nonces, tx.nonce)
+
+		p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], tx.costCap)
+		p.stored -= uint64(tx.storageSize)
+		p.lookup.untrack(tx)
+		if j == 0 {
+			p.trackLifecycle(tx.hash, txpool.TxDropped, reason)
+		} else {
+			p.trackLifecycle(tx.hash, txpool.TxDropped, txpool.DropNonceGap)
+		}
+		txs[i+j] = nil
+	}
+	// Clear out the dropped transactions from the index
+	if i > 0 {
+		p.index[addr] = txs[:i]
+		heap.Fix(p.evict, p.evict.index[addr])
+	} else {
+		delete(p.index, addr)
+		delete(p.spent, addr)
+
+		heap.Remove(p.evict, p.evict.index[addr])
+		p.reserver.Release(addr)
+	}
+	// Clear out the transactions from the data store
+	for _, id := range ids {
+		if err := p.store.Delete(id); err != nil {
+			log.Error("Failed to delete dropped transaction", "id", id, "err", err)
+		}
+	}
+	return ids, nonces
+}
+
+// Remove drops a transaction from the pool, along with all later transactions of
+// the same account, as no gaps are allowed.
+func (p *BlobPool) Remove(hash common.Hash, reason txpool.TxDropReason) bool {
+	p.lock.Lock()
+	defer p.unlock()
+
+	// Transactions in the gapped buffer can be removed on their own
+	if from, ok := p.gappedSource[hash]; ok {
+		keep := p.gapped[from][:0]
+		for _, gtx := range p.gapped[from] {
+			if gtx.Tx.Hash() != hash {
+				keep = append(keep, gtx)
+			}
+		}
+		if len(keep) == 0 {
+			delete(p.gapped, from)
+		} else {
+			p.gapped[from] = keep
+		}
+		delete(p.gappedSource, hash)
+		gappedGauge.Update(int64(len(p.gappedSource)))
+
+		p.trackLifecycle(hash, txpool.TxDropped, reason)
+		return true
+	}
+	if !p.lookup.exists(hash) {
+		return false
+	}
+	for addr, txs := range p.index {
+		for i, tx := range txs {
+			if tx.hash == hash {
+				ids, nonces := p.dropFrom(addr, i, reason)
+				log.Debug("Dropped blob transaction", "from", addr, "hash", hash, "reason", reason, "drop", nonces, "ids", ids)
+
+				p.updateStorageMetrics()
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // ValidateTxBasics checks whether a transaction is valid according to the consensus
 // rules, but does not check state-dependent validation such as sufficient balance.
 // This check is meant as an early check which only needs to be performed once,
@@ -1571,6 +1638,7 @@ func (p *BlobPool) ValidateTxBasics(tx *types.Transaction) error {
 		MaxSize:      txMaxSize,
 		MinTip:       p.gasTip.Load().ToBig(),
 		MaxBlobCount: maxBlobsPerTx,
+		Policy:       p.config.Policy,
 	}
 	return txpool.ValidateTransaction(tx, p.head.Load(), p.signer, opts)
 }
@@ -1995,7 +2063,7 @@ func (p *BlobPool) AddPooledTx(ptx *BlobTxForPool) (err error) {
 	waitStart := time.Now()
 	p.lock.Lock()
 	addwaitHist.Update(time.Since(waitStart).Nanoseconds())
-	defer p.lock.Unlock()
+	defer p.unlock()
 
 	defer func(start time.Time) {
 		addtimeHist.Update(time.Since(start).Nanoseconds())
@@ -2029,6 +2097,7 @@ func (p *BlobPool) addLocked(ptx *BlobTxForPool, checkGapped bool) (err error) {
 				p.gapped[from] = append(p.gapped[from], ptx)
 				p.gappedSource[tx.Hash()] = from
 				gappedGauge.Update(int64(len(p.gappedSource)))
+				p.trackLifecycle(tx.Hash(), txpool.TxAdded, "")
 				log.Trace("added tx to gapped blob queue", "allowance", allowance, "hash", tx.Hash(), "from", from, "nonce", tx.Nonce(), "qlen", len(p.gapped[from]))
 				return nil
 			} else {
@@ -2098,6 +2167,7 @@ func (p *BlobPool) addLocked(ptx *BlobTxForPool, checkGapped bool) (err error) {
 		dropReplacedMeter.Mark(1)
 
 		prev := p.index[from][offset]
+		p.trackReplaced(prev.hash, meta.hash)
 		if err := p.store.Delete(prev.id); err != nil {
 			// Shitty situation, but try to recover gracefully instead of going boom
 			log.Error("Failed to delete replaced transaction", "id", prev.id, "err", err)
@@ -2177,6 +2247,14 @@ func (p *BlobPool) addLocked(ptx *BlobTxForPool, checkGapped bool) (err error) {
 
 	addValidMeter.Mark(1)
 
+	// Transactions moved over from the gapped buffer were already reported as
+	// added, they just became executable.
+	if checkGapped {
+		p.trackLifecycle(meta.hash, txpool.TxAdded, "")
+	} else {
+		p.trackLifecycle(meta.hash, txpool.TxPromoted, "")
+	}
+
 	// Transaction was added successfully, but we only announce if it is (close to being)
 	// includable and the previous one was already announced.
 	if p.isAnnouncable(meta) && (meta.nonce == next || (len(txs) > 1 && txs[offset-1].announced)) {
@@ -2213,6 +2291,7 @@ func (p *BlobPool) addLocked(ptx *BlobTxForPool, checkGapped bool) (err error) {
 
 			if ptx.Tx.Nonce() < stateNonce {
 				// Stale, drop it. Eventually we could add to limbo here if hash matches.
+				p.trackLifecycle(ptx.Tx.Hash(), txpool.TxDropped, txpool.DropNonceTooLow)
 				log.Trace("Gapped blob transaction became stale", "hash", ptx.Tx.Hash(), "from", from, "nonce", ptx.Tx.Nonce(), "state", stateNonce, "qlen", len(p.gapped[from]))
 				continue
 			}
@@ -2225,6 +2304,7 @@ func (p *BlobPool) addLocked(ptx *BlobTxForPool, checkGapped bool) (err error) {
 					gappedPromotedMeter.Mark(1)
 					log.Trace("Gapped blob transaction added to pool", "hash", ptx.Tx.Hash(), "from", from, "nonce", ptx.Tx.Nonce(), "qlen", len(p.gapped[from]))
 				} else {
+					p.trackLifecycle(ptx.Tx.Hash(), txpool.TxDropped, lifecycleDropReason(err))
 					log.Trace("Gapped blob transaction not accepted", "hash", ptx.Tx.Hash(), "from", from, "nonce", ptx.Tx.Nonce(), "err", err)
 				}
 			}
@@ -2268,6 +2348,7 @@ func (p *BlobPool) drop() {
 	}
 	p.stored -= uint64(drop.storageSize)
 	p.lookup.untrack(drop)
+	p.trackLifecycle(drop.hash, txpool.TxEvicted, txpool.DropPoolFull)
 
 	// Remove the transaction from the pool's eviction heap:
 	//   - If the entire account was dropped, pop off the address
@@ -2460,6 +2541,65 @@ func (p *BlobPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool
 	}
 }
 
+// SubscribeLifecycle registers a subscription for the state changes of the
+// transactions tracked by the pool.
+func (p *BlobPool) SubscribeLifecycle(ch chan<- txpool.LifecycleEvent) event.Subscription {
+	return p.lifecycleFeed.Subscribe(ch)
+}
+
+// trackLifecycle queues a state change of a transaction, to be sent out once the
+// running pool operation finishes. The caller must hold the pool lock.
+func (p *BlobPool) trackLifecycle(hash common.Hash, kind txpool.TxLifecycleKind, reason txpool.TxDropReason) {
+	p.lifecycle = append(p.lifecycle, txpool.TxLifecycle{Hash: hash, Kind: kind, Reason: reason})
+}
+
+// trackReplaced queues the replacement of a transaction by another one. The
+// caller must hold the pool lock.
+func (p *BlobPool) trackReplaced(hash common.Hash, replacement common.Hash) {
+	p.lifecycle = append(p.lifecycle, txpool.TxLifecycle{Hash: hash, Kind: txpool.TxReplaced, Replacement: replacement})
+}
+
+// trackStale queues the removal of a transaction whose nonce was used up. It is
+// reported as included if the transaction is part of the given inclusions, or
+// as dropped otherwise. The caller must hold the pool lock.
+func (p *BlobPool) trackStale(hash common.Hash, inclusions map[common.Hash]uint64) {
+	if number, ok := inclusions[hash]; ok {
+		p.lifecycle = append(p.lifecycle, txpool.TxLifecycle{Hash: hash, Kind: txpool.TxIncluded, Block: number})
+		return
+	}
+	p.trackLifecycle(hash, txpool.TxDropped, txpool.DropNonceTooLow)
+}
+
+// unlock releases the pool lock and sends out the transaction state changes
+// queued while it was held. The events are sent after unlocking, so that slow
+// subscribers don't block the pool.
+func (p *BlobPool) unlock() {
+	lifecycle := p.lifecycle
+	p.lifecycle = nil
+	p.lock.Unlock()
+
+	if len(lifecycle) > 0 {
+		p.lifecycleFeed.Send(txpool.LifecycleEvent{Txs: lifecycle})
+	}
+}
+
+// lifecycleDropReason maps a validation error to the reason code reported when
+// dropping a buffered transaction.
+func lifecycleDropReason(err error) txpool.TxDropReason {
+	switch {
+	case errors.Is(err, txpool.ErrUnderpriced), errors.Is(err, txpool.ErrTxGasPriceTooLow), errors.Is(err, txpool.ErrReplaceUnderpriced):
+		return txpool.DropUnderpriced
+	case errors.Is(err, core.ErrNonceTooLow):
+		return txpool.DropNonceTooLow
+	case errors.Is(err, core.ErrInsufficientFunds):
+		return txpool.DropInsufficientFunds
+	case errors.Is(err, txpool.ErrAccountLimitExceeded):
+		return txpool.DropAccountLimit
+	default:
+		return txpool.DropInvalid
+	}
+}
+
 // Nonce returns the next nonce of an account, with all transactions executable
 // by the pool already applied on top.
 func (p *BlobPool) Nonce(addr common.Address) uint64 {
@@ -2501,6 +2641,11 @@ func (p *BlobPool) evictGapped() {
 				// Evict old or stale transactions
 				// Should we add stale to limbo here if it would belong?
 				delete(p.gappedSource, gtx.Tx.Hash())
+				if gtx.Tx.Nonce() < nonce {
+					p.trackLifecycle(gtx.Tx.Hash(), txpool.TxDropped, txpool.DropNonceTooLow)
+				} else {
+					p.trackLifecycle(gtx.Tx.Hash(), txpool.TxEvicted, txpool.DropLifetime)
+				}
 				txs[i] = nil // Explicitly nil out evicted element
 			} else {
 				keep = append(keep, gtx)
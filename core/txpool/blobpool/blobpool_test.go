@@ -1491,6 +1491,85 @@ func TestBlobCountLimit(t *testing.T) {
 	pool.Close()
 }
 
+// Tests that the state changes of the transactions are reported on the lifecycle
+// feed, along with the replacements and the reasons of the drops.
+func TestLifecycleEvents(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.AddBalance(addr, uint256.NewInt(1_000_000_000), tracing.BalanceChangeUnspecified)
+	statedb.Commit(0, true, false)
+
+	cancunTime := uint64(0)
+	config := &params.ChainConfig{
+		ChainID:     big.NewInt(1),
+		LondonBlock: big.NewInt(0),
+		BerlinBlock: big.NewInt(0),
+		CancunTime:  &cancunTime,
+		BlobScheduleConfig: &params.BlobScheduleConfig{
+			Cancun: params.DefaultCancunBlobConfig,
+		},
+	}
+	chain := &testBlockChain{
+		config:  config,
+		basefee: uint256.NewInt(1050),
+		blobfee: uint256.NewInt(105),
+		statedb: statedb,
+		blocks:  make(map[uint64]*types.Block),
+	}
+	pool := New(Config{Datadir: t.TempDir()}, chain, nil)
+	if err := pool.Init(1, chain.CurrentBlock(), newReserver()); err != nil {
+		t.Fatalf("failed to create blob pool: %v", err)
+	}
+	defer pool.Close()
+
+	events := make(chan txpool.LifecycleEvent, 16)
+	sub := pool.SubscribeLifecycle(events)
+	defer sub.Unsubscribe()
+
+	var (
+		tx0  = makeTx(0, 1, 1000, 100, key)
+		tx0b = makeTx(0, 2, 2000, 200, key)
+		tx1  = makeTx(1, 1, 1000, 100, key)
+	)
+	for _, tx := range []*types.Transaction{tx0, tx0b, tx1} {
+		if err := pool.Add([]*types.Transaction{tx}, true)[0]; err != nil {
+			t.Fatalf("failed to add transaction: %v", err)
+		}
+	}
+	// Include the replacement in a new block
+	oldHead := chain.CurrentBlock()
+	newHead := chain.CurrentBlock()
+	newHead.Number = new(big.Int).Add(oldHead.Number, common.Big1)
+	newHead.ParentHash = oldHead.Hash()
+	chain.blocks[newHead.Number.Uint64()] = types.NewBlockWithHeader(newHead).WithBody(types.Body{Transactions: []*types.Transaction{tx0b}})
+
+	statedb.SetNonce(addr, 1, tracing.NonceChangeUnspecified)
+	pool.Reset(oldHead, newHead)
+
+	// Raise the minimum tip above the remaining transaction
+	pool.SetGasTip(big.NewInt(2))
+
+	want := []txpool.TxLifecycle{
+		{Hash: tx0.Hash(), Kind: txpool.TxAdded},
+		{Hash: tx0.Hash(), Kind: txpool.TxReplaced, Replacement: tx0b.Hash()},
+		{Hash: tx0b.Hash(), Kind: txpool.TxAdded},
+		{Hash: tx1.Hash(), Kind: txpool.TxAdded},
+		{Hash: tx0b.Hash(), Kind: txpool.TxIncluded, Block: newHead.Number.Uint64()},
+		{Hash: tx1.Hash(), Kind: txpool.TxDropped, Reason: txpool.DropUnderpriced},
+	}
+	var have []txpool.TxLifecycle
+	for len(events) > 0 {
+		have = append(have, (<-events).Txs...)
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Fatalf("lifecycle events mismatch:\nhave %+v\nwant %+v", have, want)
+	}
+	verifyPoolInternals(t, pool)
+}
+
 // Tests that adding transaction will correctly store it in the persistent store
 // and update all the indices.
 //
@@ -17,6 +17,7 @@
 package blobpool
 
 import (
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -27,6 +28,8 @@ type Config struct {
 	PriceBump uint64 // Minimum price bump percentage to replace an already existing nonce
 
 	FetchProbability uint64 // EIP-8070: full blob fetch probability for sparse blobpool
+
+	Policy txpool.AdmissionPolicy `toml:"-"` // Node-specific admission rules for new transactions (optional)
 }
 
 // DefaultConfig contains the default configurations for the transaction pool.
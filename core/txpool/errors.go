@@ -18,6 +18,7 @@ package txpool
 
 import (
 	"errors"
+	"fmt"
 )
 
 var (
@@ -77,4 +78,35 @@ var (
 
 	// ErrSidecarFormatError is returned when sidecar is malformed
 	ErrSidecarFormatError = errors.New("Wrong sidecar format")
+
+	// ErrPolicyRejected is returned if a transaction is rejected by the admission
+	// policy of the node. The concrete error is a *PolicyError.
+	ErrPolicyRejected = errors.New("rejected by admission policy")
+)
+
+// PolicyRule is the name of an admission policy rule.
+type PolicyRule string
+
+const (
+	PolicyDenied     PolicyRule = "deny"       // Sender or recipient is on the deny list
+	PolicyNotAllowed PolicyRule = "allow"      // Sender is not on the allow list
+	PolicyRateLimit  PolicyRule = "rate-limit" // Sender exceeded its rate limit
+	PolicyMinTip     PolicyRule = "min-tip"    // Gas tip is below the minimum of the tx type
+	PolicyCalldata   PolicyRule = "calldata"   // Calldata exceeds the size limit
+	PolicyCustom     PolicyRule = "custom"     // Rejected by a custom policy
 )
+
+// PolicyError is returned if a transaction is rejected by the admission policy
+// of the node. It matches ErrPolicyRejected.
+type PolicyError struct {
+	Rule   PolicyRule // Rule which rejected the transaction
+	Reason string     // Human readable details of the rejection
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("%v (%s): %s", ErrPolicyRejected, e.Rule, e.Reason)
+}
+
+func (e *PolicyError) Unwrap() error {
+	return ErrPolicyRejected
+}
@@ -146,6 +146,10 @@ type Config struct {
 	Journal   string           // Journal of local transactions to survive node restarts
 	Rejournal time.Duration    // Time interval to regenerate the local transaction journal
 
+	Snapshot         string        // Snapshot of the pool content to survive node restarts (empty = disabled)
+	SnapshotInterval time.Duration // Time interval to regenerate the pool snapshot
+	SnapshotLimit    uint64        // Maximum size of the pool snapshot in bytes (0 = unlimited)
+
 	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
 	PriceBump  uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
 
@@ -155,6 +159,8 @@ type Config struct {
 	GlobalQueue  uint64 // Maximum number of non-executable transaction slots for all accounts
 
 	Lifetime time.Duration // Maximum amount of time an account can remain stale in the non-executable pool
+
+	Policy txpool.AdmissionPolicy `toml:"-"` // Node-specific admission rules for new transactions (optional)
 }
 
 // DefaultConfig contains the default configurations for the transaction pool.
@@ -162,6 +168,9 @@ var DefaultConfig = Config{
 	Journal:   "transactions.rlp",
 	Rejournal: time.Hour,
 
+	SnapshotInterval: 10 * time.Minute,
+	SnapshotLimit:    64 * 1024 * 1024,
+
 	PriceLimit: 1,
 	PriceBump:  10,
 
@@ -238,6 +247,10 @@ type LegacyPool struct {
 	signer      types.Signer
 	mu          sync.RWMutex
 
+	lifecycleFeed event.Feed             // Event feed to send out transaction state changes
+	lifecycle     []txpool.TxLifecycle   // State changes to send out in the next reorg run
+	inclusions    map[common.Hash]uint64 // Transactions included by the blocks of the running reset
+
 	currentHead   atomic.Pointer[types.Header] // Current head of the blockchain
 	currentState  *state.StateDB               // Current state in the blockchain head
 	pendingNonces *noncer                      // Pending state tracking virtual nonces
@@ -378,10 +391,17 @@ func (pool *LegacyPool) loop() {
 		// Handle inactive account transaction eviction
 		case <-evict.C:
 			pool.mu.Lock()
-			for _, hash := range pool.queue.evictList() {
+			evicted := pool.queue.evictList()
+			for _, hash := range evicted {
+				pool.trackLifecycle(hash, txpool.TxEvicted, txpool.DropLifetime)
 				pool.removeTx(hash, true, true)
 			}
 			pool.mu.Unlock()
+
+			// Schedule a reorg run to send out the eviction events
+			if len(evicted) > 0 {
+				pool.requestPromoteExecutables(newAccountSet(pool.signer))
+			}
 		}
 	}
 }
@@ -413,26 +433,39 @@ func (pool *LegacyPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs
 	return pool.txFeed.Subscribe(ch)
 }
 
+// SubscribeLifecycle registers a subscription for the state changes of the
+// transactions tracked by the pool.
+func (pool *LegacyPool) SubscribeLifecycle(ch chan<- txpool.LifecycleEvent) event.Subscription {
+	return pool.lifecycleFeed.Subscribe(ch)
+}
+
 // SetGasTip updates the minimum gas tip required by the transaction pool for a
 // new transaction, and drops all transactions below this threshold.
 func (pool *LegacyPool) SetGasTip(tip *big.Int) {
 	pool.mu.Lock()
-	defer pool.mu.Unlock()
 
 	var (
 		newTip = uint256.MustFromBig(tip)
 		old    = pool.gasTip.Load()
+		drop   types.Transactions
 	)
 	pool.gasTip.Store(newTip)
 	// If the min miner fee increased, remove transactions below the new threshold
 	if newTip.Cmp(old) > 0 {
 		// pool.priced is sorted by GasFeeCap, so we have to iterate through pool.all instead
-		drop := pool.all.TxsBelowTip(tip)
+		drop = pool.all.TxsBelowTip(tip)
 		for _, tx := range drop {
+			pool.trackLifecycle(tx.Hash(), txpool.TxDropped, txpool.DropUnderpriced)
 			pool.removeTx(tx.Hash(), false, true)
 		}
 		pool.priced.Removed(len(drop))
 	}
+	pool.mu.Unlock()
+
+	// Schedule a reorg run to send out the drop events
+	if len(drop) > 0 {
+		pool.requestPromoteExecutables(newAccountSet(pool.signer))
+	}
 	log.Info("Legacy pool tip threshold updated", "tip", newTip)
 }
 
@@ -563,6 +596,7 @@ func (pool *LegacyPool) ValidateTxBasics(tx *types.Transaction) error {
 			1<<types.SetCodeTxType,
 		MaxSize: txMaxSize,
 		MinTip:  pool.gasTip.Load().ToBig(),
+		Policy:  pool.config.Policy,
 	}
 	return txpool.ValidateTransaction(tx, pool.currentHead.Load(), pool.signer, opts)
 }
@@ -758,6 +792,7 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 			underpricedTxMeter.Mark(1)
 
 			sender, _ := types.Sender(pool.signer, tx)
+			pool.trackLifecycle(tx.Hash(), txpool.TxEvicted, txpool.DropUnderpriced)
 			dropped := pool.removeTx(tx.Hash(), false, sender != from) // Don't unreserve the sender of the tx being added if last from the acc
 
 			pool.changesSinceReorg += dropped
@@ -777,10 +812,13 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 			pool.all.Remove(old.Hash())
 			pool.priced.Removed(1)
 			pendingReplaceMeter.Mark(1)
+			pool.trackReplaced(old.Hash(), hash)
 		}
 		pool.all.Add(tx)
 		pool.priced.Put(tx)
 		pool.queueTxEvent(tx)
+		pool.trackLifecycle(hash, txpool.TxAdded, "")
+		pool.trackLifecycle(hash, txpool.TxPromoted, "")
 		log.Trace("Pooled new executable transaction", "hash", hash, "from", from, "to", tx.To())
 
 		// Successful replacement. If needed, bump the heartbeat giving more time to queued txs.
@@ -792,6 +830,7 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 	if err != nil {
 		return false, err
 	}
+	pool.trackLifecycle(hash, txpool.TxAdded, "")
 
 	log.Trace("Pooled new future transaction", "hash", hash, "from", from, "to", tx.To())
 	return replaced, nil
@@ -830,6 +869,7 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, addAl
 		return false, err
 	}
 	if replaced != nil {
+		pool.trackReplaced(*replaced, hash)
 		pool.removeTx(*replaced, true, true)
 	}
 	// If the transaction isn't in lookup set but it's expected to be there,
@@ -840,6 +880,9 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, addAl
 	if addAll {
 		pool.all.Add(tx)
 		pool.priced.Put(tx)
+	} else {
+		// Transactions already in the lookup set are moved back from pending
+		pool.trackLifecycle(hash, txpool.TxDemoted, "")
 	}
 	return replaced != nil, nil
 }
@@ -862,13 +905,16 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 		pool.all.Remove(hash)
 		pool.priced.Removed(1)
 		pendingDiscardMeter.Mark(1)
+		pool.trackLifecycle(hash, txpool.TxDropped, txpool.DropUnderpriced)
 		return false
 	}
 	// Otherwise discard any previous transaction and mark this
+	pool.trackLifecycle(hash, txpool.TxPromoted, "")
 	if old != nil {
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
 		pendingReplaceMeter.Mark(1)
+		pool.trackReplaced(old.Hash(), hash)
 	} else {
 		// Nothing was replaced, bump the pending counter
 		pendingGauge.Inc(1)
@@ -1042,6 +1088,23 @@ func (pool *LegacyPool) Has(hash common.Hash) bool {
 	return pool.all.Get(hash) != nil
 }
 
+// Remove drops a transaction from the pool. Any pending transactions of the same
+// account with higher nonces are moved back into the queue.
+func (pool *LegacyPool) Remove(hash common.Hash, reason txpool.TxDropReason) bool {
+	pool.mu.Lock()
+	if pool.all.Get(hash) == nil {
+		pool.mu.Unlock()
+		return false
+	}
+	pool.trackLifecycle(hash, txpool.TxDropped, reason)
+	pool.removeTx(hash, true, true)
+	pool.mu.Unlock()
+
+	// Schedule a reorg run to send out the drop events
+	pool.requestPromoteExecutables(newAccountSet(pool.signer))
+	return true
+}
+
 // removeTx removes a single transaction from the queue, moving all subsequent
 // transactions back to the future queue.
 //
@@ -1103,6 +1166,34 @@ func (pool *LegacyPool) removeTx(hash common.Hash, outofbound bool, unreserve bo
 	return 0
 }
 
+// trackLifecycle queues a state change of a transaction, to be sent out at the
+// end of the next reorg run.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *LegacyPool) trackLifecycle(hash common.Hash, kind txpool.TxLifecycleKind, reason txpool.TxDropReason) {
+	pool.lifecycle = append(pool.lifecycle, txpool.TxLifecycle{Hash: hash, Kind: kind, Reason: reason})
+}
+
+// trackReplaced queues the replacement of a transaction by another one.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *LegacyPool) trackReplaced(hash common.Hash, replacement common.Hash) {
+	pool.lifecycle = append(pool.lifecycle, txpool.TxLifecycle{Hash: hash, Kind: txpool.TxReplaced, Replacement: replacement})
+}
+
+// trackStale queues the removal of a transaction whose nonce was used up. It is
+// reported as included if the transaction is part of the blocks processed by the
+// running reset, or as dropped otherwise.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *LegacyPool) trackStale(hash common.Hash) {
+	if number, ok := pool.inclusions[hash]; ok {
+		pool.lifecycle = append(pool.lifecycle, txpool.TxLifecycle{Hash: hash, Kind: txpool.TxIncluded, Block: number})
+		return
+	}
+	pool.trackLifecycle(hash, txpool.TxDropped, txpool.DropNonceTooLow)
+}
+
 // requestReset requests a pool reset to the new head block.
 // The returned channel is closed when the reset has occurred.
 func (pool *LegacyPool) requestReset(oldHead *types.Header, newHead *types.Header) chan struct{} {
@@ -1234,6 +1325,7 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 					return true
 				})
 				for _, hash := range hashes {
+					pool.trackLifecycle(hash, txpool.TxDropped, txpool.DropGasLimit)
 					pool.removeTx(hash, true, true)
 				}
 			}
@@ -1281,6 +1373,9 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 
 	dropBetweenReorgHistogram.Update(int64(pool.changesSinceReorg))
 	pool.changesSinceReorg = 0 // Reset change counter
+
+	lifecycle := pool.lifecycle
+	pool.lifecycle, pool.inclusions = nil, nil
 	pool.mu.Unlock()
 
 	// Notify subsystems for newly added transactions
@@ -1298,6 +1393,10 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 		}
 		pool.txFeed.Send(core.NewTxsEvent{Txs: txs})
 	}
+	// Notify subsystems of the transaction state changes
+	if len(lifecycle) > 0 {
+		pool.lifecycleFeed.Send(txpool.LifecycleEvent{Txs: lifecycle})
+	}
 }
 
 // reset retrieves the current state of the blockchain and ensures the content
@@ -1306,6 +1405,15 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 	// If we're reorging an old state, reinject all dropped transactions
 	var reinject types.Transactions
 
+	// Track the transactions included by the new blocks, so they can be told
+	// apart from the ones made stale by other transactions.
+	pool.inclusions = make(map[common.Hash]uint64)
+
+	if oldHead != nil && oldHead.Hash() == newHead.ParentHash {
+		if block := pool.chain.GetBlock(newHead.Hash(), newHead.Number.Uint64()); block != nil {
+			pool.trackInclusions(block)
+		}
+	}
 	if oldHead != nil && oldHead.Hash() != newHead.ParentHash {
 		// If the reorg is too deep, avoid doing it (will happen during fast sync)
 		oldNum := oldHead.Number.Uint64()
@@ -1353,6 +1461,7 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 				}
 				for add.NumberU64() > rem.NumberU64() {
 					included = append(included, add.Transactions()...)
+					pool.trackInclusions(add)
 					if add = pool.chain.GetBlock(add.ParentHash(), add.NumberU64()-1); add == nil {
 						log.Error("Unrooted new chain seen by tx pool", "block", newHead.Number, "hash", newHead.Hash())
 						return
@@ -1365,6 +1474,7 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 						return
 					}
 					included = append(included, add.Transactions()...)
+					pool.trackInclusions(add)
 					if add = pool.chain.GetBlock(add.ParentHash(), add.NumberU64()-1); add == nil {
 						log.Error("Unrooted new chain seen by tx pool", "block", newHead.Number, "hash", newHead.Hash())
 						return
@@ -1399,6 +1509,13 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 	pool.addTxsLocked(reinject, make([]error, len(reinject)))
 }
 
+// trackInclusions records the transactions of a block which became canonical.
+func (pool *LegacyPool) trackInclusions(block *types.Block) {
+	for _, tx := range block.Transactions() {
+		pool.inclusions[tx.Hash()] = block.NumberU64()
+	}
+}
+
 // promoteExecutables moves transactions that have become processable from the
 // future queue to the set of pending transactions. During this process, all
 // invalidated transactions (low nonce, low balance) are deleted.
@@ -1416,8 +1533,13 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 	}
 
 	// remove all removable transactions
-	for _, hash := range dropped {
-		pool.all.Remove(hash)
+	for _, ev := range dropped {
+		pool.all.Remove(ev.Hash)
+		if ev.Reason == txpool.DropNonceTooLow {
+			pool.trackStale(ev.Hash)
+		} else {
+			pool.lifecycle = append(pool.lifecycle, ev)
+		}
 	}
 	pool.priced.Removed(len(dropped))
 
@@ -1474,6 +1596,7 @@ func (pool *LegacyPool) truncatePending() {
 						// Drop the transaction from the global pools too
 						hash := tx.Hash()
 						pool.all.Remove(hash)
+						pool.trackLifecycle(hash, txpool.TxEvicted, txpool.DropPoolFull)
 
 						// Update the account nonce to the dropped transaction
 						pool.pendingNonces.setIfLower(offenders[i], tx.Nonce())
@@ -1499,6 +1622,7 @@ func (pool *LegacyPool) truncatePending() {
 					// Drop the transaction from the global pools too
 					hash := tx.Hash()
 					pool.all.Remove(hash)
+					pool.trackLifecycle(hash, txpool.TxEvicted, txpool.DropPoolFull)
 
 					// Update the account nonce to the dropped transaction
 					pool.pendingNonces.setIfLower(addr, tx.Nonce())
@@ -1520,6 +1644,7 @@ func (pool *LegacyPool) truncateQueue() {
 	// Remove all removable transactions from the lookup and global price list
 	for _, hash := range removed {
 		pool.all.Remove(hash)
+		pool.trackLifecycle(hash, txpool.TxEvicted, txpool.DropPoolFull)
 	}
 	pool.priced.Removed(len(removed))
 
@@ -1549,6 +1674,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 		for _, tx := range olds {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
+			pool.trackStale(hash)
 			log.Trace("Removed old pending transaction", "hash", hash)
 		}
 		// Drop all transactions that are too costly (low balance or out of gas), and queue any invalids back for later
@@ -1556,6 +1682,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 		for _, tx := range drops {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
+			pool.trackLifecycle(hash, txpool.TxDropped, dropReason(tx, gasLimit))
 			log.Trace("Removed unpayable pending transaction", "hash", hash)
 		}
 		pendingNofundsMeter.Mark(int64(len(drops)))
@@ -1803,6 +1930,15 @@ func (t *lookup) hasAuth(addr common.Address) bool {
 	return len(t.auths[addr]) > 0
 }
 
+// dropReason returns the reason of dropping a transaction which the sender can't
+// pay for, or which doesn't fit into a block.
+func dropReason(tx *types.Transaction, gasLimit uint64) txpool.TxDropReason {
+	if tx.Gas() > gasLimit {
+		return txpool.DropGasLimit
+	}
+	return txpool.DropInsufficientFunds
+}
+
 // numSlots calculates the number of slots needed for a single transaction.
 func numSlots(tx *types.Transaction) int {
 	return int((tx.Size() + txSlotSize - 1) / txSlotSize)
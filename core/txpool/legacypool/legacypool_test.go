@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"math/big"
 	"math/rand"
+	"reflect"
 	"slices"
 	"sync"
 	"sync/atomic"
@@ -2621,6 +2622,116 @@ func TestSetCodeTransactionsReorg(t *testing.T) {
 	}
 }
 
+// validateLifecycle checks that the expected transaction state changes were
+// fired on the pool's lifecycle feed, in order.
+func validateLifecycle(events chan txpool.LifecycleEvent, want []txpool.TxLifecycle) error {
+	var received []txpool.TxLifecycle
+
+	for len(received) < len(want) {
+		select {
+		case ev := <-events:
+			received = append(received, ev.Txs...)
+		case <-time.After(time.Second):
+			return fmt.Errorf("lifecycle event #%d not fired", len(received))
+		}
+	}
+	select {
+	case ev := <-events:
+		received = append(received, ev.Txs...)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if !reflect.DeepEqual(received, want) {
+		return fmt.Errorf("lifecycle events mismatch:\nhave %+v\nwant %+v", received, want)
+	}
+	return nil
+}
+
+// Tests that the state changes of the transactions are reported on the lifecycle
+// feed, along with the replacements and the reasons of the drops.
+func TestLifecycleEvents(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	events := make(chan txpool.LifecycleEvent, 32)
+	sub := pool.SubscribeLifecycle(events)
+	defer sub.Unsubscribe()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, big.NewInt(1000000000))
+
+	var (
+		tx0  = pricedTransaction(0, 100000, big.NewInt(1), key)
+		tx0b = pricedTransaction(0, 100000, big.NewInt(2), key)
+		tx1  = pricedTransaction(1, 100000, big.NewInt(1000), key)
+		tx2  = pricedTransaction(2, 100000, big.NewInt(1), key)
+	)
+	// Executable transactions are added to the queue and promoted right after,
+	// future ones stay in the queue.
+	if err := pool.addRemoteSync(tx0); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	if err := pool.addRemoteSync(tx2); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	if err := validateLifecycle(events, []txpool.TxLifecycle{
+		{Hash: tx0.Hash(), Kind: txpool.TxAdded},
+		{Hash: tx0.Hash(), Kind: txpool.TxPromoted},
+		{Hash: tx2.Hash(), Kind: txpool.TxAdded},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// Replace the pending transaction and fill the nonce gap
+	if err := pool.addRemoteSync(tx0b); err != nil {
+		t.Fatalf("failed to replace transaction: %v", err)
+	}
+	if err := pool.addRemoteSync(tx1); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	if err := validateLifecycle(events, []txpool.TxLifecycle{
+		{Hash: tx0.Hash(), Kind: txpool.TxReplaced, Replacement: tx0b.Hash()},
+		{Hash: tx0b.Hash(), Kind: txpool.TxAdded},
+		{Hash: tx0b.Hash(), Kind: txpool.TxPromoted},
+		{Hash: tx1.Hash(), Kind: txpool.TxAdded},
+		{Hash: tx1.Hash(), Kind: txpool.TxPromoted},
+		{Hash: tx2.Hash(), Kind: txpool.TxPromoted},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// Use up the first nonce and reduce the balance below the cost of the
+	// second transaction, the third one becomes gapped.
+	pool.mu.Lock()
+	pool.currentState.SetNonce(from, 1, tracing.NonceChangeUnspecified)
+	pool.currentState.SetBalance(from, uint256.NewInt(1000000), tracing.BalanceChangeUnspecified)
+	pool.mu.Unlock()
+
+	<-pool.requestReset(nil, nil)
+	if err := validateLifecycle(events, []txpool.TxLifecycle{
+		{Hash: tx0b.Hash(), Kind: txpool.TxDropped, Reason: txpool.DropNonceTooLow},
+		{Hash: tx1.Hash(), Kind: txpool.TxDropped, Reason: txpool.DropInsufficientFunds},
+		{Hash: tx2.Hash(), Kind: txpool.TxDemoted},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// Transactions part of the processed blocks are reported as included
+	pool.mu.Lock()
+	pool.inclusions = map[common.Hash]uint64{tx2.Hash(): 7}
+	pool.currentState.SetNonce(from, 3, tracing.NonceChangeUnspecified)
+	pool.promoteExecutables([]common.Address{from})
+	lifecycle := pool.lifecycle
+	pool.lifecycle, pool.inclusions = nil, nil
+	pool.mu.Unlock()
+
+	want := []txpool.TxLifecycle{{Hash: tx2.Hash(), Kind: txpool.TxIncluded, Block: 7}}
+	if !reflect.DeepEqual(lifecycle, want) {
+		t.Fatalf("lifecycle events mismatch:\nhave %+v\nwant %+v", lifecycle, want)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 // Benchmarks the speed of validating the contents of the pending queue of the
 // transaction pool.
 func BenchmarkPendingDemotion100(b *testing.B)   { benchmarkPendingDemotion(b, 100) }
@@ -156,14 +156,15 @@ func (q *queue) add(tx *types.Transaction) (*common.Hash, error) {
 // deemed too old (nonce too low) or too costly (insufficient funds or over gas limit).
 //
 // Returns three lists:
-// - all transactions that were removed from the queue and selected for promotion;
-// - all other transactions that were removed from the queue and dropped;
-// - the list of addresses removed.
-func (q *queue) promoteExecutables(accounts []common.Address, gasLimit uint64, currentState *state.StateDB, nonces *noncer) ([]*types.Transaction, []common.Hash, []common.Address) {
+//   - all transactions that were removed from the queue and selected for promotion;
+//   - all other transactions that were removed from the queue and dropped, along
+//     with the lifecycle event of their removal;
+//   - the list of addresses removed.
+func (q *queue) promoteExecutables(accounts []common.Address, gasLimit uint64, currentState *state.StateDB, nonces *noncer) ([]*types.Transaction, []txpool.TxLifecycle, []common.Address) {
 	// Track the promotable transactions to broadcast them at once
 	var (
 		promotable       []*types.Transaction
-		dropped          []common.Hash
+		dropped          []txpool.TxLifecycle
 		removedAddresses []common.Address
 	)
 	// Iterate over all accounts and promote any executable transactions
@@ -175,14 +176,14 @@ func (q *queue) promoteExecutables(accounts []common.Address, gasLimit uint64, c
 		// Drop all transactions that are deemed too old (low nonce)
 		forwards := list.Forward(currentState.GetNonce(addr))
 		for _, tx := range forwards {
-			dropped = append(dropped, tx.Hash())
+			dropped = append(dropped, txpool.TxLifecycle{Hash: tx.Hash(), Kind: txpool.TxDropped, Reason: txpool.DropNonceTooLow})
 		}
 		log.Trace("Removing old queued transactions", "count", len(forwards))
 
 		// Drop all transactions that are too costly (low balance or out of gas)
 		drops, _ := list.Filter(currentState.GetBalance(addr), gasLimit)
 		for _, tx := range drops {
-			dropped = append(dropped, tx.Hash())
+			dropped = append(dropped, txpool.TxLifecycle{Hash: tx.Hash(), Kind: txpool.TxDropped, Reason: dropReason(tx, gasLimit)})
 		}
 		log.Trace("Removing unpayable queued transactions", "count", len(drops))
 		queuedNofundsMeter.Mark(int64(len(drops)))
@@ -197,7 +198,7 @@ func (q *queue) promoteExecutables(accounts []common.Address, gasLimit uint64, c
 		var caps = list.Cap(int(q.config.AccountQueue))
 		for _, tx := range caps {
 			hash := tx.Hash()
-			dropped = append(dropped, hash)
+			dropped = append(dropped, txpool.TxLifecycle{Hash: hash, Kind: txpool.TxEvicted, Reason: txpool.DropAccountLimit})
 			log.Trace("Removing cap-exceeding queued transaction", "hash", hash)
 		}
 		queuedRateLimitMeter.Mark(int64(len(caps)))
@@ -0,0 +1,113 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TxLifecycleKind is the kind of state change a transaction went through in
+// the pool.
+type TxLifecycleKind string
+
+const (
+	// TxAdded is reported when a transaction is accepted into the pool.
+	TxAdded TxLifecycleKind = "added"
+
+	// TxPromoted is reported when a transaction becomes executable, moving from
+	// the queued (future) set to the pending one.
+	TxPromoted TxLifecycleKind = "promoted"
+
+	// TxDemoted is reported when a pending transaction becomes non-executable
+	// again, moving back into the queued set.
+	TxDemoted TxLifecycleKind = "demoted"
+
+	// TxReplaced is reported when a transaction is replaced by another one with
+	// the same sender and nonce, but with a higher price.
+	TxReplaced TxLifecycleKind = "replaced"
+
+	// TxEvicted is reported when a valid transaction is removed to free up space
+	// in the pool or because it lingered for too long.
+	TxEvicted TxLifecycleKind = "evicted"
+
+	// TxDropped is reported when a transaction is removed because it became
+	// invalid or unexecutable.
+	TxDropped TxLifecycleKind = "dropped"
+
+	// TxIncluded is reported when a transaction is removed because it was
+	// included in a block.
+	TxIncluded TxLifecycleKind = "included"
+)
+
+// TxDropReason is the reason code of an eviction or a drop.
+type TxDropReason string
+
+const (
+	// DropUnderpriced is used when a transaction pays less than the minimum tip
+	// accepted by the pool, or less than other transactions competing for room.
+	DropUnderpriced TxDropReason = "underpriced"
+
+	// DropNonceTooLow is used when the nonce of a transaction was used up by a
+	// different transaction included in the chain.
+	DropNonceTooLow TxDropReason = "nonce-too-low"
+
+	// DropNonceGap is used when a transaction can't be executed anymore because
+	// of a gap in the nonces preceding it.
+	DropNonceGap TxDropReason = "nonce-gap"
+
+	// DropInsufficientFunds is used when the sender can't pay for a transaction
+	// anymore.
+	DropInsufficientFunds TxDropReason = "insufficient-funds"
+
+	// DropGasLimit is used when a transaction requests more gas than allowed by
+	// the current block gas limit or the protocol.
+	DropGasLimit TxDropReason = "gas-limit"
+
+	// DropAccountLimit is used when the sender has more transactions in the pool
+	// than allowed for a single account.
+	DropAccountLimit TxDropReason = "account-limit"
+
+	// DropPoolFull is used when the pool is over its global capacity.
+	DropPoolFull TxDropReason = "pool-full"
+
+	// DropLifetime is used when a transaction stayed non-executable in the pool
+	// for longer than allowed.
+	DropLifetime TxDropReason = "lifetime"
+
+	// DropExpired is used when a private transaction wasn't included before its
+	// expiry block.
+	DropExpired TxDropReason = "expired"
+
+	// DropInvalid is used when a transaction is found to be invalid for any other
+	// reason.
+	DropInvalid TxDropReason = "invalid"
+)
+
+// TxLifecycle is a single state change of a transaction in the pool.
+type TxLifecycle struct {
+	Hash        common.Hash     // Hash of the transaction
+	Kind        TxLifecycleKind // Kind of the state change
+	Replacement common.Hash     // Hash of the replacing transaction (replaced only)
+	Reason      TxDropReason    // Reason of the removal (evicted and dropped only)
+	Block       uint64          // Number of the including block (included only)
+}
+
+// LifecycleEvent is posted by the pools when transactions change state, in the
+// order the changes happened.
+type LifecycleEvent struct {
+	Txs []TxLifecycle
+}
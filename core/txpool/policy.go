@@ -0,0 +1,244 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+	"golang.org/x/time/rate"
+)
+
+// maxPolicySenders is the number of sender buckets tracked by the rate limit of
+// the admission policy. When more senders are active, the least recently seen
+// ones are forgotten.
+const maxPolicySenders = 16384
+
+var policyRejectedMeter = metrics.NewRegisteredMeter("txpool/policy/rejected", nil)
+
+// policyTxTypes maps the transaction type names accepted in the configuration
+// of the admission policy to the transaction types.
+var policyTxTypes = map[string]byte{
+	"legacy":     types.LegacyTxType,
+	"accesslist": types.AccessListTxType,
+	"dynamicfee": types.DynamicFeeTxType,
+	"blob":       types.BlobTxType,
+	"setcode":    types.SetCodeTxType,
+}
+
+// AdmissionPolicy is a set of node-specific rules deciding which transactions are
+// accepted into the pool. It is run after a transaction passed the consensus
+// validation, so implementations can rely on it being well-formed and signed.
+//
+// Embedders can implement it to plug custom checks into the pool. Rejections
+// should be reported with a *PolicyError, other errors are wrapped into one.
+type AdmissionPolicy interface {
+	// Admit returns an error if the transaction sent by the given account must
+	// not be accepted into the pool.
+	Admit(tx *types.Transaction, from common.Address) error
+}
+
+// PolicyConfig are the configuration parameters of the built-in admission policy.
+type PolicyConfig struct {
+	// Deny is a list of addresses whose transactions are rejected, both when
+	// sending and when receiving them.
+	Deny []common.Address `toml:",omitempty"`
+
+	// Allow is a list of addresses allowed to send transactions. If empty, all
+	// senders which aren't denied are accepted.
+	Allow []common.Address `toml:",omitempty"`
+
+	// SenderRate is the number of transactions per second accepted from a single
+	// sender. Zero disables rate limiting.
+	SenderRate float64 `toml:",omitempty"`
+
+	// SenderBurst is the number of transactions a sender can submit at once. If
+	// zero, it is the rate rounded up.
+	SenderBurst int `toml:",omitempty"`
+
+	// MinTip is the minimum gas tip in wei required per transaction type, keyed
+	// by the type name: legacy, accesslist, dynamicfee, blob or setcode.
+	MinTip map[string]uint64 `toml:",omitempty"`
+
+	// MaxCalldata is the maximum size of the calldata of a transaction in bytes.
+	// Zero disables the limit.
+	MaxCalldata uint64 `toml:",omitempty"`
+}
+
+// PolicyLists are the address lists of the built-in admission policy, which can
+// be replaced at runtime.
+type PolicyLists struct {
+	Deny  []common.Address `json:"deny"`
+	Allow []common.Address `json:"allow"`
+}
+
+// Policy is the built-in admission policy of the pool, configured with a
+// PolicyConfig. Transactions accepted by the configured rules are also passed
+// to any custom policies.
+type Policy struct {
+	config PolicyConfig
+	minTip map[byte]*big.Int
+	custom []AdmissionPolicy
+
+	listLock sync.RWMutex
+	deny     map[common.Address]struct{}
+	allow    map[common.Address]struct{}
+
+	rateLock sync.Mutex
+	senders  lru.BasicLRU[common.Address, *rate.Limiter]
+}
+
+// NewPolicy creates the built-in admission policy. The custom policies are run
+// in order after the configured rules.
+func NewPolicy(config PolicyConfig, custom ...AdmissionPolicy) (*Policy, error) {
+	p := &Policy{
+		config:  config,
+		minTip:  make(map[byte]*big.Int),
+		custom:  custom,
+		senders: lru.NewBasicLRU[common.Address, *rate.Limiter](maxPolicySenders),
+	}
+	for name, tip := range config.MinTip {
+		kind, ok := policyTxTypes[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown transaction type %q in minimum tip", name)
+		}
+		p.minTip[kind] = new(big.Int).SetUint64(tip)
+	}
+	if config.SenderRate < 0 {
+		return nil, errors.New("negative sender rate")
+	}
+	p.SetLists(PolicyLists{Deny: config.Deny, Allow: config.Allow})
+	return p, nil
+}
+
+// Lists returns the current address lists of the policy.
+func (p *Policy) Lists() PolicyLists {
+	p.listLock.RLock()
+	defer p.listLock.RUnlock()
+
+	lists := PolicyLists{
+		Deny:  make([]common.Address, 0, len(p.deny)),
+		Allow: make([]common.Address, 0, len(p.allow)),
+	}
+	for addr := range p.deny {
+		lists.Deny = append(lists.Deny, addr)
+	}
+	for addr := range p.allow {
+		lists.Allow = append(lists.Allow, addr)
+	}
+	return lists
+}
+
+// SetLists replaces the address lists of the policy. Transactions already in the
+// pool are not affected.
+func (p *Policy) SetLists(lists PolicyLists) {
+	deny := make(map[common.Address]struct{}, len(lists.Deny))
+	for _, addr := range lists.Deny {
+		deny[addr] = struct{}{}
+	}
+	allow := make(map[common.Address]struct{}, len(lists.Allow))
+	for _, addr := range lists.Allow {
+		allow[addr] = struct{}{}
+	}
+	p.listLock.Lock()
+	p.deny, p.allow = deny, allow
+	p.listLock.Unlock()
+}
+
+// Admit implements AdmissionPolicy.
+func (p *Policy) Admit(tx *types.Transaction, from common.Address) error {
+	err := p.admit(tx, from)
+	if err != nil {
+		policyRejectedMeter.Mark(1)
+	}
+	return err
+}
+
+func (p *Policy) admit(tx *types.Transaction, from common.Address) error {
+	if err := p.checkLists(tx, from); err != nil {
+		return err
+	}
+	if limit := p.config.MaxCalldata; limit > 0 && uint64(len(tx.Data())) > limit {
+		return &PolicyError{Rule: PolicyCalldata, Reason: fmt.Sprintf("calldata size %d, limit %d", len(tx.Data()), limit)}
+	}
+	if tip, ok := p.minTip[tx.Type()]; ok && tx.GasTipCapIntCmp(tip) < 0 {
+		return &PolicyError{Rule: PolicyMinTip, Reason: fmt.Sprintf("gas tip cap %v, minimum needed %v", tx.GasTipCap(), tip)}
+	}
+	for _, policy := range p.custom {
+		if err := policy.Admit(tx, from); err != nil {
+			var perr *PolicyError
+			if !errors.As(err, &perr) {
+				err = &PolicyError{Rule: PolicyCustom, Reason: err.Error()}
+			}
+			return err
+		}
+	}
+	// Only count the transactions passing all other rules against the rate limit
+	return p.checkRate(from)
+}
+
+// checkLists rejects transactions from or to denied addresses, and transactions
+// from addresses not on a non-empty allow list.
+func (p *Policy) checkLists(tx *types.Transaction, from common.Address) error {
+	p.listLock.RLock()
+	defer p.listLock.RUnlock()
+
+	if _, ok := p.deny[from]; ok {
+		return &PolicyError{Rule: PolicyDenied, Reason: fmt.Sprintf("sender %v denied", from)}
+	}
+	if to := tx.To(); to != nil {
+		if _, ok := p.deny[*to]; ok {
+			return &PolicyError{Rule: PolicyDenied, Reason: fmt.Sprintf("recipient %v denied", *to)}
+		}
+	}
+	if len(p.allow) > 0 {
+		if _, ok := p.allow[from]; !ok {
+			return &PolicyError{Rule: PolicyNotAllowed, Reason: fmt.Sprintf("sender %v not allowed", from)}
+		}
+	}
+	return nil
+}
+
+// checkRate takes a token from the rate limit bucket of the sender.
+func (p *Policy) checkRate(from common.Address) error {
+	if p.config.SenderRate == 0 {
+		return nil
+	}
+	p.rateLock.Lock()
+	bucket, ok := p.senders.Get(from)
+	if !ok {
+		burst := p.config.SenderBurst
+		if burst <= 0 {
+			burst = max(int(p.config.SenderRate+0.999), 1)
+		}
+		bucket = rate.NewLimiter(rate.Limit(p.config.SenderRate), burst)
+		p.senders.Add(from, bucket)
+	}
+	p.rateLock.Unlock()
+
+	if !bucket.AllowN(time.Now(), 1) {
+		return &PolicyError{Rule: PolicyRateLimit, Reason: fmt.Sprintf("sender %v over %v txs/s", from, p.config.SenderRate)}
+	}
+	return nil
+}
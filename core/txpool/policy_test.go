@@ -0,0 +1,134 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// policyFunc is an AdmissionPolicy implemented by a function.
+type policyFunc func(tx *types.Transaction, from common.Address) error
+
+func (f policyFunc) Admit(tx *types.Transaction, from common.Address) error { return f(tx, from) }
+
+func TestAdmissionPolicy(t *testing.T) {
+	var (
+		key, _    = crypto.GenerateKey()
+		sender    = crypto.PubkeyToAddress(key.PublicKey)
+		other, _  = crypto.GenerateKey()
+		recipient = common.HexToAddress("0x0000000000000000000000000000000000000001")
+		custom    = common.HexToAddress("0x00000000000000000000000000000000000000ff")
+
+		head   = &types.Header{Number: big.NewInt(1), GasLimit: 5000000, Time: 1, Difficulty: big.NewInt(1)}
+		signer = types.LatestSigner(params.TestChainConfig)
+	)
+	makeTx := func(key *ecdsa.PrivateKey, nonce uint64, to common.Address, tip int64, data []byte) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			Nonce:     nonce,
+			To:        &to,
+			Gas:       100000,
+			GasTipCap: big.NewInt(tip),
+			GasFeeCap: big.NewInt(tip),
+			Data:      data,
+		})
+	}
+	policy, err := NewPolicy(PolicyConfig{
+		Deny:        []common.Address{common.HexToAddress("0xdead")},
+		SenderRate:  0.001,
+		SenderBurst: 2,
+		MinTip:      map[string]uint64{"dynamicfee": 10},
+		MaxCalldata: 4,
+	}, policyFunc(func(tx *types.Transaction, from common.Address) error {
+		if *tx.To() == custom {
+			return errors.New("custom rejection")
+		}
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := &ValidationOptions{
+		Config:  params.TestChainConfig,
+		Accept:  0xFF,
+		MaxSize: 32 * 1024,
+		MinTip:  big.NewInt(0),
+		Policy:  policy,
+	}
+	tests := []struct {
+		name string
+		tx   *types.Transaction
+		rule PolicyRule
+	}{
+		{"accepted", makeTx(key, 0, recipient, 10, nil), ""},
+		{"denied recipient", makeTx(key, 1, common.HexToAddress("0xdead"), 10, nil), PolicyDenied},
+		{"tip too low", makeTx(key, 1, recipient, 9, nil), PolicyMinTip},
+		{"calldata too large", makeTx(key, 1, recipient, 10, make([]byte, 5)), PolicyCalldata},
+		{"custom rejection", makeTx(key, 1, custom, 10, nil), PolicyCustom},
+		{"within burst", makeTx(key, 1, recipient, 10, []byte{1, 2, 3, 4}), ""},
+		{"rate limited", makeTx(key, 2, recipient, 10, nil), PolicyRateLimit},
+		{"other sender", makeTx(other, 0, recipient, 10, nil), ""},
+	}
+	for _, tt := range tests {
+		err := ValidateTransaction(tt.tx, head, signer, opts)
+		checkPolicyError(t, tt.name, err, tt.rule)
+	}
+	// Replace the lists, the other sender is denied and the sender is not allowed
+	policy.SetLists(PolicyLists{
+		Deny:  []common.Address{crypto.PubkeyToAddress(other.PublicKey)},
+		Allow: []common.Address{crypto.PubkeyToAddress(other.PublicKey), sender},
+	})
+	if lists := policy.Lists(); len(lists.Deny) != 1 || len(lists.Allow) != 2 {
+		t.Fatalf("wrong lists after reload: %+v", lists)
+	}
+	checkPolicyError(t, "denied sender", ValidateTransaction(makeTx(other, 1, recipient, 10, nil), head, signer, opts), PolicyDenied)
+
+	policy.SetLists(PolicyLists{Allow: []common.Address{crypto.PubkeyToAddress(other.PublicKey)}})
+	checkPolicyError(t, "not allowed", ValidateTransaction(makeTx(key, 2, recipient, 10, nil), head, signer, opts), PolicyNotAllowed)
+	checkPolicyError(t, "allowed", ValidateTransaction(makeTx(other, 1, recipient, 10, nil), head, signer, opts), "")
+
+	// Invalid configurations must be rejected
+	if _, err := NewPolicy(PolicyConfig{MinTip: map[string]uint64{"unknown": 1}}); err == nil {
+		t.Fatal("unknown transaction type accepted")
+	}
+}
+
+func checkPolicyError(t *testing.T, name string, err error, rule PolicyRule) {
+	t.Helper()
+	if rule == "" {
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+		return
+	}
+	var perr *PolicyError
+	if !errors.As(err, &perr) || !errors.Is(err, ErrPolicyRejected) {
+		t.Errorf("%s: wrong error: have %v, want policy error", name, err)
+		return
+	}
+	if perr.Rule != rule {
+		t.Errorf("%s: wrong rule: have %s, want %s", name, perr.Rule, rule)
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package snapshot persists the pending and queued transactions of the pool, so
+// that the mempool survives node restarts.
+//
+// Only the transactions of the legacy pool are snapshotted. The blob pool keeps
+// its transactions in its own on-disk store and rebuilds its metadata from it on
+// startup, so it does not need to be persisted separately.
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// snapshotVersion is the version of the snapshot file format. Snapshots written
+// with a different version are ignored on load.
+const snapshotVersion = 1
+
+// loadBatchSize is the number of transactions injected into the pool at once
+// when loading a snapshot.
+const loadBatchSize = 1024
+
+// header is the first item of a snapshot file, followed by the RLP encoded
+// transactions: the pending ones first, then the queued ones, each account's
+// in nonce order.
+type header struct {
+	Version uint64 // Version of the snapshot format
+	Time    uint64 // Unix timestamp of the snapshot
+}
+
+// Snapshotter periodically writes the content of the transaction pool to disk,
+// and reinjects it into the pool on startup.
+type Snapshotter struct {
+	path     string         // Filesystem path to store the snapshot at
+	interval time.Duration  // Time interval to regenerate the snapshot
+	limit    uint64         // Maximum size of the snapshot in bytes (0 = unlimited)
+	pool     *txpool.TxPool // The tx pool to snapshot
+
+	lock       sync.Mutex // Protects the snapshot file from concurrent writes
+	shutdownCh chan struct{}
+	wg         sync.WaitGroup
+}
+
+// New creates a new Snapshotter.
+func New(path string, interval time.Duration, limit uint64, pool *txpool.TxPool) *Snapshotter {
+	return &Snapshotter{
+		path:       path,
+		interval:   interval,
+		limit:      limit,
+		pool:       pool,
+		shutdownCh: make(chan struct{}),
+	}
+}
+
+// Start implements node.Lifecycle interface
+// Start loads the last snapshot into the pool and starts the periodic writer.
+func (s *Snapshotter) Start() error {
+	if err := s.load(); err != nil {
+		log.Warn("Failed to load transaction pool snapshot", "err", err)
+	}
+	s.wg.Add(1)
+	go s.loop()
+	return nil
+}
+
+// Stop implements node.Lifecycle interface
+// Stop terminates the periodic writer and takes a final snapshot.
+func (s *Snapshotter) Stop() error {
+	close(s.shutdownCh)
+	s.wg.Wait()
+
+	return s.write()
+}
+
+func (s *Snapshotter) loop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.shutdownCh:
+			return
+		case <-ticker.C:
+			if err := s.write(); err != nil {
+				log.Warn("Failed to write transaction pool snapshot", "err", err)
+			}
+		}
+	}
+}
+
+// load parses the snapshot file from disk, injecting its transactions into the
+// pool. The transactions go through the regular validation of the pool, so the
+// ones invalidated by the current head are dropped.
+func (s *Snapshotter) load() error {
+	input, err := os.Open(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	stream := rlp.NewStream(input, s.limit)
+
+	var head header
+	if err := stream.Decode(&head); err != nil {
+		return fmt.Errorf("invalid snapshot header: %v", err)
+	}
+	if head.Version != snapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d, want %d", head.Version, snapshotVersion)
+	}
+	var (
+		total, dropped int
+		failure        error
+		batch          []*types.Transaction
+	)
+	loadBatch := func() {
+		for _, err := range s.pool.Add(batch, false) {
+			if err != nil {
+				log.Trace("Failed to add snapshotted transaction", "err", err)
+				dropped++
+			}
+		}
+		batch = batch[:0]
+	}
+	for {
+		tx := new(types.Transaction)
+		if err := stream.Decode(tx); err != nil {
+			if err != io.EOF {
+				failure = err
+			}
+			break
+		}
+		total++
+		if batch = append(batch, tx); len(batch) >= loadBatchSize {
+			loadBatch()
+		}
+	}
+	if len(batch) > 0 {
+		loadBatch()
+	}
+	log.Info("Loaded transaction pool snapshot", "transactions", total, "dropped", dropped, "age", common.PrettyAge(time.Unix(int64(head.Time), 0)))
+	return failure
+}
+
+// write regenerates the snapshot file from the current content of the pool.
+// Private transactions and blob transactions are left out. Transactions which
+// don't fit into the size limit, if any, are skipped along with the rest of the sender's
+// transactions, since they couldn't be executed without them.
+func (s *Snapshotter) write() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var (
+		pending, queued = s.pool.Content()
+		private         = s.pool.PrivateTxs()
+	)
+	replacement, err := os.OpenFile(s.path+".new", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	head := header{
+		Version: snapshotVersion,
+		Time:    uint64(time.Now().Unix()),
+	}
+	blob, err := rlp.EncodeToBytes(&head)
+	if err != nil {
+		replacement.Close()
+		return err
+	}
+	if _, err := replacement.Write(blob); err != nil {
+		replacement.Close()
+		return err
+	}
+	var (
+		size     = uint64(len(blob))
+		written  int
+		skipped  int
+		accounts int
+	)
+	for _, content := range []map[common.Address][]*types.Transaction{pending, queued} {
+		for _, txs := range content {
+			accounts++
+			for i, tx := range txs {
+				if tx.Type() == types.BlobTxType {
+					continue
+				}
+				if _, ok := private[tx.Hash()]; ok {
+					continue
+				}
+				blob, err := rlp.EncodeToBytes(tx)
+				if err != nil {
+					replacement.Close()
+					return err
+				}
+				if s.limit != 0 && size+uint64(len(blob)) > s.limit {
+					skipped += len(txs) - i
+					break
+				}
+				if _, err := replacement.Write(blob); err != nil {
+					replacement.Close()
+					return err
+				}
+				size += uint64(len(blob))
+				written++
+			}
+		}
+	}
+	if err := replacement.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(s.path+".new", s.path); err != nil {
+		return err
+	}
+	logger := log.Info
+	if written == 0 {
+		logger = log.Debug
+	}
+	logger("Wrote transaction pool snapshot", "transactions", written, "accounts", accounts, "skipped", skipped, "size", common.StorageSize(size))
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snapshot
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	key, _     = crypto.GenerateKey()
+	privKey, _ = crypto.GenerateKey()
+	funds      = big.NewInt(1000000000000000)
+	gspec      = &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			crypto.PubkeyToAddress(key.PublicKey):     {Balance: funds},
+			crypto.PubkeyToAddress(privKey.PublicKey): {Balance: funds},
+		},
+		BaseFee: big.NewInt(params.InitialBaseFee),
+	}
+	signer = types.LatestSigner(gspec.Config)
+)
+
+func makeTx(key *ecdsa.PrivateKey, nonce uint64) *types.Transaction {
+	tx, _ := types.SignTx(types.NewTransaction(nonce, common.Address{0x00}, big.NewInt(1000), params.TxGas, big.NewInt(params.GWei), nil), signer, key)
+	return tx
+}
+
+func newTestPool(t *testing.T, chain *core.BlockChain) *txpool.TxPool {
+	pool, err := txpool.New(1, chain, []txpool.SubPool{legacypool.New(legacypool.DefaultConfig, chain)})
+	if err != nil {
+		t.Fatalf("Failed to create tx pool: %v", err)
+	}
+	return pool
+}
+
+// Tests that the pending and queued transactions survive a restart of the pool,
+// apart from the private ones, and that the snapshot respects its size limit,
+// if any.
+func TestSnapshotRoundtrip(t *testing.T) {
+	chain, _ := core.NewBlockChain(rawdb.NewMemoryDatabase(), gspec, ethash.NewFaker(), nil)
+	defer chain.Stop()
+
+	var (
+		path = filepath.Join(t.TempDir(), "txpool.rlp")
+		pool = newTestPool(t, chain)
+	)
+	for _, err := range pool.Add([]*types.Transaction{makeTx(key, 0), makeTx(key, 1), makeTx(key, 2), makeTx(key, 5)}, true) {
+		if err != nil {
+			t.Fatalf("Failed to add transaction: %v", err)
+		}
+	}
+	if err := pool.AddPrivate(makeTx(privKey, 0), 100, true); err != nil {
+		t.Fatalf("Failed to add private transaction: %v", err)
+	}
+	if err := New(path, time.Minute, 0, pool).write(); err != nil {
+		t.Fatalf("Failed to write snapshot: %v", err)
+	}
+	pool.Close()
+
+	// Restart the pool and check that the public transactions are restored
+	pool = newTestPool(t, chain)
+	if err := New(path, time.Minute, 0, pool).load(); err != nil {
+		t.Fatalf("Failed to load snapshot: %v", err)
+	}
+	pool.Sync()
+	if pending, queued := pool.Stats(); pending != 3 || queued != 1 {
+		t.Fatalf("restored transaction count mismatch: have %d/%d, want 3/1", pending, queued)
+	}
+	if pool.Has(makeTx(privKey, 0).Hash()) {
+		t.Fatal("private transaction restored from snapshot")
+	}
+	// Regenerate the snapshot with room for two transactions only
+	limit := uint64(len(mustEncode(t, &header{Version: snapshotVersion, Time: uint64(time.Now().Unix())})))
+	limit += 2 * uint64(len(mustEncode(t, makeTx(key, 0))))
+
+	if err := New(path, time.Minute, limit, pool).write(); err != nil {
+		t.Fatalf("Failed to write snapshot: %v", err)
+	}
+	pool.Close()
+
+	pool = newTestPool(t, chain)
+	defer pool.Close()
+
+	if err := New(path, time.Minute, limit, pool).load(); err != nil {
+		t.Fatalf("Failed to load snapshot: %v", err)
+	}
+	pool.Sync()
+	if pending, queued := pool.Stats(); pending != 2 || queued != 0 {
+		t.Fatalf("restored transaction count mismatch: have %d/%d, want 2/0", pending, queued)
+	}
+}
+
+// Tests that snapshots of an unknown version are rejected.
+func TestSnapshotVersion(t *testing.T) {
+	chain, _ := core.NewBlockChain(rawdb.NewMemoryDatabase(), gspec, ethash.NewFaker(), nil)
+	defer chain.Stop()
+
+	pool := newTestPool(t, chain)
+	defer pool.Close()
+
+	path := filepath.Join(t.TempDir(), "txpool.rlp")
+	blob := append(mustEncode(t, &header{Version: snapshotVersion + 1}), mustEncode(t, makeTx(key, 0))...)
+	if err := os.WriteFile(path, blob, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := New(path, time.Minute, legacypool.DefaultConfig.SnapshotLimit, pool).load(); err == nil {
+		t.Fatal("snapshot with unknown version loaded")
+	}
+	if pending, queued := pool.Stats(); pending != 0 || queued != 0 {
+		t.Fatalf("transactions loaded from unknown snapshot: %d/%d", pending, queued)
+	}
+}
+
+func mustEncode(t *testing.T, val any) []byte {
+	t.Helper()
+	blob, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return blob
+}
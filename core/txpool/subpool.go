@@ -90,6 +90,8 @@ type TxMetadata struct {
 	Type            uint8  // The type of the transaction
 	Size            uint64 // The length of the 'rlp encoding' of a transaction (including blobs)
 	SizeWithoutBlob uint64 // The length without blob data (for ETH/72 announcements)
+	Private         bool   // Whether the transaction must not be propagated to peers
+	Expiry          uint64 // Block number after which a private transaction is dropped
 }
 
 // SubPool represents a specialized transaction pool that lives on its own (e.g.
@@ -151,6 +153,11 @@ type SubPool interface {
 	// to a later point to batch multiple ones together.
 	Add(txs []*types.Transaction, sync bool) []error
 
+	// Remove drops a transaction from the pool, along with any transactions of the
+	// same account which can't be executed without it. It returns whether the
+	// transaction was found in the pool.
+	Remove(hash common.Hash, reason TxDropReason) bool
+
 	// Pending retrieves all currently processable transactions, grouped by origin
 	// account and sorted by nonce.
 	//
@@ -163,6 +170,10 @@ type SubPool interface {
 	// or also for reorged out ones.
 	SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool) event.Subscription
 
+	// SubscribeLifecycle subscribes to the state changes of the transactions
+	// tracked by the subpool.
+	SubscribeLifecycle(ch chan<- LifecycleEvent) event.Subscription
+
 	// Nonce returns the next nonce of an account, with all transactions executable
 	// by the pool already applied on top.
 	Nonce(addr common.Address) uint64
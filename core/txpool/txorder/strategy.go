@@ -0,0 +1,373 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txorder
+
+import (
+	"bytes"
+	"container/heap"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+)
+
+// TransactionSet is a set of pending transactions which can be retrieved one at
+// a time in the order of a strategy, honouring the nonce order of each account.
+type TransactionSet interface {
+	// Peek returns the next transaction and its effective miner tip, or nil if
+	// the set is empty.
+	Peek() (*txpool.LazyTransaction, *uint256.Int)
+
+	// Shift replaces the next transaction with the following one from the same
+	// account, if the strategy allows it.
+	Shift()
+
+	// Pop removes the next transaction without replacing it with the following
+	// one from the same account. It is used when a transaction can't be executed,
+	// so that all subsequent ones from the same account are discarded.
+	Pop()
+
+	// Empty returns whether the set has no more transactions.
+	Empty() bool
+
+	// Clear removes the entire content of the set.
+	Clear()
+}
+
+// Preceder is implemented by the transaction sets whose strategy doesn't order
+// the transactions by miner tip, to pick between the next transactions of two
+// sets created by the same strategy.
+type Preceder interface {
+	// Precedes reports whether the next transaction of the set goes before the
+	// next one of the other set.
+	Precedes(other TransactionSet) bool
+}
+
+// First returns the set whose next transaction goes first among two non-empty
+// sets created by the same strategy, e.g. the plain and blob transactions of a
+// block. The sets are compared with Preceder if implemented, by the miner tip of
+// their next transaction otherwise, preferring a on equal tips.
+func First(a, b TransactionSet) TransactionSet {
+	if p, ok := a.(Preceder); ok {
+		if p.Precedes(b) {
+			return a
+		}
+		return b
+	}
+	_, atip := a.Peek()
+	_, btip := b.Peek()
+	if atip.Lt(btip) {
+		return b
+	}
+	return a
+}
+
+// Strategy creates an ordered transaction set from the nonce-sorted pending
+// transactions of each account. Transactions which can't pay the base fee must
+// be left out.
+//
+// Note, the input map is reowned so the caller should not interact any more with
+// it after providing it to the strategy.
+type Strategy func(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) TransactionSet
+
+// Config are the parameters of the ordering strategies. Strategies ignore the
+// fields they don't use.
+type Config struct {
+	SenderCap int // Maximum number of transactions included per sender (0 = unlimited)
+}
+
+// StrategyConstructor creates a configured ordering strategy.
+type StrategyConstructor func(config Config) (Strategy, error)
+
+// Names of the built-in ordering strategies.
+const (
+	// PriceOrdering picks the transactions by decreasing miner tip, and by first
+	// seen time among equal tips. It is the default strategy.
+	PriceOrdering = "price"
+
+	// FCFSOrdering picks the transactions strictly in the order they were first
+	// seen by the node, regardless of their price.
+	FCFSOrdering = "fcfs"
+
+	// SenderOrdering picks the senders by decreasing miner tip of their next
+	// transaction, and includes the transactions of each sender in a row, up to
+	// the configured per-sender cap.
+	SenderOrdering = "sender"
+)
+
+var (
+	strategiesLock sync.RWMutex
+	strategies     = map[string]StrategyConstructor{
+		PriceOrdering: func(Config) (Strategy, error) {
+			return priceOrdering, nil
+		},
+		FCFSOrdering: func(Config) (Strategy, error) {
+			return fcfsOrdering, nil
+		},
+		SenderOrdering: func(config Config) (Strategy, error) {
+			if config.SenderCap < 0 {
+				return nil, errors.New("negative sender cap")
+			}
+			return func(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) TransactionSet {
+				return NewTransactionsBySender(signer, txs, baseFee, config.SenderCap)
+			}, nil
+		},
+	}
+)
+
+// priceOrdering is the Strategy of the price ordering.
+func priceOrdering(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) TransactionSet {
+	return NewTransactionsByPriceAndNonce(signer, txs, baseFee)
+}
+
+// fcfsOrdering is the Strategy of the first-come-first-served ordering.
+func fcfsOrdering(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) TransactionSet {
+	return NewTransactionsByTimeAndNonce(signer, txs, baseFee)
+}
+
+// Register registers an ordering strategy by name, replacing any previous one
+// with the same name.
+func Register(name string, ctor StrategyConstructor) {
+	strategiesLock.Lock()
+	defer strategiesLock.Unlock()
+
+	strategies[name] = ctor
+}
+
+// Strategies returns the names of the registered ordering strategies.
+func Strategies() []string {
+	strategiesLock.RLock()
+	defer strategiesLock.RUnlock()
+
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates the ordering strategy registered with the given name. An empty
+// name selects the price ordering.
+func New(name string, config Config) (Strategy, error) {
+	if name == "" {
+		name = PriceOrdering
+	}
+	strategiesLock.RLock()
+	ctor, ok := strategies[name]
+	strategiesLock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown transaction ordering %q", name)
+	}
+	return ctor(config)
+}
+
+// txByTime implements the heap interface, ordering the transactions by the time
+// they were first seen.
+type txByTime []*txWithMinerFee
+
+func (s txByTime) Len() int { return len(s) }
+func (s txByTime) Less(i, j int) bool {
+	if s[i].tx.Time.Equal(s[j].tx.Time) {
+		return bytes.Compare(s[i].tx.Hash[:], s[j].tx.Hash[:]) < 0
+	}
+	return s[i].tx.Time.Before(s[j].tx.Time)
+}
+func (s txByTime) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s *txByTime) Push(x interface{}) {
+	*s = append(*s, x.(*txWithMinerFee))
+}
+
+func (s *txByTime) Pop() interface{} {
+	old := *s
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*s = old[0 : n-1]
+	return x
+}
+
+// TransactionsByTimeAndNonce represents a set of transactions that can return
+// transactions in the order they were first seen, while supporting removing
+// entire batches of transactions for non-executable accounts.
+type TransactionsByTimeAndNonce struct {
+	txs     map[common.Address][]*txpool.LazyTransaction // Per account nonce-sorted list of transactions
+	heads   txByTime                                     // Next transaction for each unique account (time heap)
+	baseFee *uint256.Int                                 // Current base fee
+}
+
+// NewTransactionsByTimeAndNonce creates a transaction set that can retrieve
+// first-come-first-served sorted transactions in a nonce-honouring way.
+//
+// Note, the input map is reowned so the caller should not interact any more with
+// it after providing it to the constructor.
+func NewTransactionsByTimeAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) *TransactionsByTimeAndNonce {
+	var baseFeeUint *uint256.Int
+	if baseFee != nil {
+		baseFeeUint = uint256.MustFromBig(baseFee)
+	}
+	heads := make(txByTime, 0, len(txs))
+	for from, accTxs := range txs {
+		wrapped, err := newTxWithMinerFee(accTxs[0], from, baseFeeUint)
+		if err != nil {
+			delete(txs, from)
+			continue
+		}
+		heads = append(heads, wrapped)
+		txs[from] = accTxs[1:]
+	}
+	heap.Init(&heads)
+
+	return &TransactionsByTimeAndNonce{
+		txs:     txs,
+		heads:   heads,
+		baseFee: baseFeeUint,
+	}
+}
+
+// Peek returns the next transaction by first seen time.
+func (t *TransactionsByTimeAndNonce) Peek() (*txpool.LazyTransaction, *uint256.Int) {
+	if len(t.heads) == 0 {
+		return nil, nil
+	}
+	return t.heads[0].tx, t.heads[0].fees
+}
+
+// Shift replaces the current head with the next one from the same account.
+func (t *TransactionsByTimeAndNonce) Shift() {
+	acc := t.heads[0].from
+	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
+		if wrapped, err := newTxWithMinerFee(txs[0], acc, t.baseFee); err == nil {
+			t.heads[0], t.txs[acc] = wrapped, txs[1:]
+			heap.Fix(&t.heads, 0)
+			return
+		}
+	}
+	heap.Pop(&t.heads)
+}
+
+// Pop removes the current head, *not* replacing it with the next one from the
+// same account.
+func (t *TransactionsByTimeAndNonce) Pop() {
+	heap.Pop(&t.heads)
+}
+
+// Empty returns if the time heap is empty.
+func (t *TransactionsByTimeAndNonce) Empty() bool {
+	return len(t.heads) == 0
+}
+
+// Clear removes the entire content of the heap.
+func (t *TransactionsByTimeAndNonce) Clear() {
+	t.heads, t.txs = nil, nil
+}
+
+// Precedes reports whether the next transaction of the set was seen before the
+// next one of the other set.
+func (t *TransactionsByTimeAndNonce) Precedes(other TransactionSet) bool {
+	o, ok := other.(*TransactionsByTimeAndNonce)
+	if !ok || len(o.heads) == 0 {
+		return true
+	}
+	if len(t.heads) == 0 {
+		return false
+	}
+	return txByTime{t.heads[0], o.heads[0]}.Less(0, 1)
+}
+
+// TransactionsBySender represents a set of transactions that returns the
+// transactions of each sender in a row, picking the senders by the price of
+// their next transaction. At most cap transactions are returned per sender.
+type TransactionsBySender struct {
+	txs     map[common.Address][]*txpool.LazyTransaction // Per account nonce-sorted list of transactions
+	heads   txByPriceAndTime                             // Next transaction for each waiting account (price heap)
+	current *txWithMinerFee                              // Next transaction of the account being included
+	taken   int                                          // Number of transactions taken from the current account
+	cap     int                                          // Maximum number of transactions per account (0 = unlimited)
+	baseFee *uint256.Int                                 // Current base fee
+}
+
+// NewTransactionsBySender creates a transaction set that can retrieve sender
+// grouped transactions in a nonce-honouring way.
+//
+// Note, the input map is reowned so the caller should not interact any more with
+// it after providing it to the constructor.
+func NewTransactionsBySender(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, cap int) *TransactionsBySender {
+	set := NewTransactionsByPriceAndNonce(signer, txs, baseFee)
+	return &TransactionsBySender{
+		txs:     set.txs,
+		heads:   set.heads,
+		cap:     cap,
+		baseFee: set.baseFee,
+	}
+}
+
+// Peek returns the next transaction of the current sender, or the best priced
+// transaction of the next sender if the current one is done.
+func (t *TransactionsBySender) Peek() (*txpool.LazyTransaction, *uint256.Int) {
+	if t.current == nil {
+		if len(t.heads) == 0 {
+			return nil, nil
+		}
+		t.current, t.taken = heap.Pop(&t.heads).(*txWithMinerFee), 0
+	}
+	return t.current.tx, t.current.fees
+}
+
+// Shift replaces the current transaction with the next one from the same
+// account, unless the account reached its cap.
+func (t *TransactionsBySender) Shift() {
+	if t.current == nil {
+		return
+	}
+	acc := t.current.from
+	t.current, t.taken = nil, t.taken+1
+
+	if t.cap > 0 && t.taken >= t.cap {
+		delete(t.txs, acc)
+		return
+	}
+	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
+		if wrapped, err := newTxWithMinerFee(txs[0], acc, t.baseFee); err == nil {
+			t.current, t.txs[acc] = wrapped, txs[1:]
+		}
+	}
+}
+
+// Pop removes the current transaction, *not* replacing it with the next one
+// from the same account.
+func (t *TransactionsBySender) Pop() {
+	t.current = nil
+}
+
+// Empty returns if there are no more transactions.
+func (t *TransactionsBySender) Empty() bool {
+	return t.current == nil && len(t.heads) == 0
+}
+
+// Clear removes the entire content of the set.
+func (t *TransactionsBySender) Clear() {
+	t.current, t.heads, t.txs = nil, nil, nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txorder
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+)
+
+// strategyTestTxs creates three accounts with three transactions each. The first
+// account pays the highest price, the last one the lowest, but the transactions
+// were seen round robin from the last account to the first.
+func strategyTestTxs(t *testing.T) ([]common.Address, func() map[common.Address][]*txpool.LazyTransaction) {
+	signer := types.HomesteadSigner{}
+
+	var (
+		keys  = make([]*ecdsa.PrivateKey, 3)
+		addrs = make([]common.Address, 3)
+		txs   = make(map[common.Address][]*txpool.LazyTransaction)
+	)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		addrs[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+	}
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		for i, key := range keys {
+			tx, err := types.SignTx(types.NewTransaction(nonce, common.Address{}, big.NewInt(100), 100, big.NewInt(int64(10-i)), nil), signer, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tx.SetTime(time.Unix(0, int64(nonce*3+uint64(len(keys)-i))))
+			txs[addrs[i]] = append(txs[addrs[i]], &txpool.LazyTransaction{
+				Hash:      tx.Hash(),
+				Tx:        tx,
+				Time:      tx.Time(),
+				GasFeeCap: uint256.MustFromBig(tx.GasFeeCap()),
+				GasTipCap: uint256.MustFromBig(tx.GasTipCap()),
+				Gas:       tx.Gas(),
+			})
+		}
+	}
+	// Return a copy on every call, the strategies reown the map
+	return addrs, func() map[common.Address][]*txpool.LazyTransaction {
+		cpy := make(map[common.Address][]*txpool.LazyTransaction, len(txs))
+		for addr, list := range txs {
+			cpy[addr] = slices.Clone(list)
+		}
+		return cpy
+	}
+}
+
+// drain retrieves the senders and nonces of all transactions of a set, shifting
+// after each one.
+func drain(set TransactionSet) (senders []common.Address, nonces []uint64) {
+	for tx, _ := set.Peek(); tx != nil; tx, _ = set.Peek() {
+		from, _ := types.Sender(types.HomesteadSigner{}, tx.Tx)
+		senders = append(senders, from)
+		nonces = append(nonces, tx.Tx.Nonce())
+		set.Shift()
+	}
+	return senders, nonces
+}
+
+func TestOrderingStrategies(t *testing.T) {
+	t.Parallel()
+
+	addrs, txs := strategyTestTxs(t)
+	a, b, c := addrs[0], addrs[1], addrs[2]
+
+	tests := []struct {
+		name    string
+		config  Config
+		senders []common.Address
+		nonces  []uint64
+	}{
+		{
+			name:    PriceOrdering,
+			senders: []common.Address{a, a, a, b, b, b, c, c, c},
+			nonces:  []uint64{0, 1, 2, 0, 1, 2, 0, 1, 2},
+		},
+		{
+			name:    FCFSOrdering,
+			senders: []common.Address{c, b, a, c, b, a, c, b, a},
+			nonces:  []uint64{0, 0, 0, 1, 1, 1, 2, 2, 2},
+		},
+		{
+			name:    SenderOrdering,
+			config:  Config{SenderCap: 2},
+			senders: []common.Address{a, a, b, b, c, c},
+			nonces:  []uint64{0, 1, 0, 1, 0, 1},
+		},
+	}
+	for _, tt := range tests {
+		strategy, err := New(tt.name, tt.config)
+		if err != nil {
+			t.Fatalf("%s: failed to create strategy: %v", tt.name, err)
+		}
+		senders, nonces := drain(strategy(types.HomesteadSigner{}, txs(), nil))
+		if !slices.Equal(senders, tt.senders) || !slices.Equal(nonces, tt.nonces) {
+			t.Errorf("%s: wrong order: have %x %v, want %x %v", tt.name, senders, nonces, tt.senders, tt.nonces)
+		}
+	}
+	// Popping must drop the rest of the sender's transactions
+	strategy, _ := New(SenderOrdering, Config{})
+	set := strategy(types.HomesteadSigner{}, txs(), nil)
+	set.Peek()
+	set.Pop()
+	if senders, _ := drain(set); len(senders) != 6 || slices.Contains(senders, a) {
+		t.Errorf("wrong transactions after pop: %x", senders)
+	}
+	if _, err := New("unknown", Config{}); err == nil {
+		t.Error("unknown strategy created")
+	}
+}
+
+func TestFirst(t *testing.T) {
+	t.Parallel()
+
+	addrs, txs := strategyTestTxs(t)
+	a, c := addrs[0], addrs[2]
+
+	// The first account pays more, but the last one was seen first
+	tests := []struct {
+		name string
+		want common.Address
+	}{
+		{name: PriceOrdering, want: a},
+		{name: FCFSOrdering, want: c},
+	}
+	for _, tt := range tests {
+		strategy, _ := New(tt.name, Config{})
+
+		all := txs()
+		first := strategy(types.HomesteadSigner{}, map[common.Address][]*txpool.LazyTransaction{a: all[a]}, nil)
+		second := strategy(types.HomesteadSigner{}, map[common.Address][]*txpool.LazyTransaction{c: all[c]}, nil)
+
+		for _, sets := range [][2]TransactionSet{{first, second}, {second, first}} {
+			tx, _ := First(sets[0], sets[1]).Peek()
+			if from, _ := types.Sender(types.HomesteadSigner{}, tx.Tx); from != tt.want {
+				t.Errorf("%s: wrong set picked: have %x, want %x", tt.name, from, tt.want)
+			}
+		}
+	}
+}
+
+func TestRegisterStrategy(t *testing.T) {
+	t.Parallel()
+
+	Register("custom", func(Config) (Strategy, error) {
+		return func(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) TransactionSet {
+			return NewTransactionsByPriceAndNonce(signer, txs, baseFee)
+		}, nil
+	})
+	if !slices.Contains(Strategies(), "custom") {
+		t.Fatalf("registered strategy missing: %v", Strategies())
+	}
+	if _, err := New("custom", Config{}); err != nil {
+		t.Fatalf("failed to create registered strategy: %v", err)
+	}
+}
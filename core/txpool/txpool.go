@@ -19,6 +19,7 @@ package txpool
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"math/big"
 	"sync"
 
@@ -31,6 +32,10 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 )
 
+// privateTxRetention is the number of blocks private transactions stay flagged
+// after their expiry block.
+const privateTxRetention = 64
+
 // TxStatus is the current status of a transaction as seen by the pool.
 type TxStatus uint
 
@@ -72,6 +77,9 @@ type TxPool struct {
 	stateLock sync.RWMutex   // The lock for protecting state instance
 	state     *state.StateDB // Current state at the blockchain head
 
+	privateLock sync.RWMutex           // The lock for protecting the private transaction set
+	private     map[common.Hash]uint64 // Private transactions, mapped to their expiry block
+
 	subs event.SubscriptionScope // Subscription scope to unsubscribe all on shutdown
 	quit chan chan error         // Quit channel to tear down the head updater
 	term chan struct{}           // Termination channel to detect a closed pool
@@ -104,6 +112,7 @@ func New(gasTip uint64, chain BlockChain, subpools []SubPool) (*TxPool, error) {
 		subpools:  subpools,
 		chain:     chain,
 		state:     statedb,
+		private:   make(map[common.Hash]uint64),
 		quit:      make(chan chan error),
 		term:      make(chan struct{}),
 		sync:      make(chan chan error),
@@ -202,6 +211,7 @@ func (p *TxPool) loop(head *types.Header) {
 					for _, subpool := range p.subpools {
 						subpool.Reset(oldHead, newHead)
 					}
+					p.dropExpired(newHead.Number.Uint64())
 					select {
 					case resetDone <- newHead:
 					case <-p.term:
@@ -303,6 +313,9 @@ func (p *TxPool) GetRLP(hash common.Hash, version uint) []byte {
 func (p *TxPool) GetMetadata(hash common.Hash) *TxMetadata {
 	for _, subpool := range p.subpools {
 		if meta := subpool.GetMetadata(hash); meta != nil {
+			p.privateLock.RLock()
+			meta.Expiry, meta.Private = p.private[hash]
+			p.privateLock.RUnlock()
 			return meta
 		}
 	}
@@ -358,6 +371,79 @@ func (p *TxPool) Add(txs []*types.Transaction, sync bool) []error {
 	return errs
 }
 
+// AddPrivate adds a transaction to the pool, flagging it as private until the
+// given expiry block. Private transactions are offered to the local miner, but
+// are never propagated to the network. They are dropped from the pool if they
+// aren't included by the expiry block.
+func (p *TxPool) AddPrivate(tx *types.Transaction, expiry uint64, sync bool) error {
+	// Flag the transaction before adding it, so it's never announced. Reject it
+	// if it's known already, as it might have been propagated.
+	hash := tx.Hash()
+
+	p.privateLock.Lock()
+	if _, ok := p.private[hash]; ok || p.Has(hash) {
+		p.privateLock.Unlock()
+		return ErrAlreadyKnown
+	}
+	p.private[hash] = expiry
+	p.privateLock.Unlock()
+
+	if err := p.Add([]*types.Transaction{tx}, sync)[0]; err != nil {
+		p.privateLock.Lock()
+		delete(p.private, hash)
+		p.privateLock.Unlock()
+		return err
+	}
+	return nil
+}
+
+// PrivateTxs returns the hashes of the private transactions, mapped to their
+// expiry block. Transactions which left the pool are contained until some blocks
+// after their expiry block.
+func (p *TxPool) PrivateTxs() map[common.Hash]uint64 {
+	p.privateLock.RLock()
+	defer p.privateLock.RUnlock()
+
+	return maps.Clone(p.private)
+}
+
+// IsPrivate reports whether a transaction was submitted privately. Transactions
+// stay flagged after leaving the pool, until some blocks after their expiry, so
+// that events about them which are handled late don't leak them.
+func (p *TxPool) IsPrivate(hash common.Hash) bool {
+	p.privateLock.RLock()
+	defer p.privateLock.RUnlock()
+
+	_, ok := p.private[hash]
+	return ok
+}
+
+// dropExpired removes the private transactions expiring before the given block
+// from the pool, and forgets about those which expired long enough ago.
+func (p *TxPool) dropExpired(number uint64) {
+	var expired []common.Hash
+
+	p.privateLock.Lock()
+	for hash, expiry := range p.private {
+		if expiry < number {
+			expired = append(expired, hash)
+		}
+		if expiry+privateTxRetention < number {
+			delete(p.private, hash)
+		}
+	}
+	p.privateLock.Unlock()
+
+	for _, hash := range expired {
+		for _, subpool := range p.subpools {
+			if subpool.Remove(hash, DropExpired) {
+				log.Debug("Dropped expired private transaction", "hash", hash)
+				break
+			}
+		}
+	}
+}
+
 // Pending retrieves all currently processable transactions, grouped by origin
 // account and sorted by nonce.
 //
@@ -386,6 +472,16 @@ func (p *TxPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool)
 	return p.subs.Track(event.JoinSubscriptions(subs...))
 }
 
+// SubscribeLifecycle registers a subscription for the state changes of the
+// transactions tracked by any of the subpools.
+func (p *TxPool) SubscribeLifecycle(ch chan<- LifecycleEvent) event.Subscription {
+	subs := make([]event.Subscription, len(p.subpools))
+	for i, subpool := range p.subpools {
+		subs[i] = subpool.SubscribeLifecycle(ch)
+	}
+	return p.subs.Track(event.JoinSubscriptions(subs...))
+}
+
 // PoolNonce returns the next nonce of an account, with all transactions executable
 // by the pool already applied on top.
 func (p *TxPool) PoolNonce(addr common.Address) uint64 {
@@ -495,6 +591,9 @@ func (p *TxPool) Clear() {
 	for _, subpool := range p.subpools {
 		subpool.Clear()
 	}
+	p.privateLock.Lock()
+	clear(p.private)
+	p.privateLock.Unlock()
 }
 
 // FilterType returns whether a transaction with the given type is supported
@@ -47,6 +47,8 @@ type ValidationOptions struct {
 	MaxSize      uint64   // Maximum size of a transaction that the caller can meaningfully handle
 	MaxBlobCount int      // Maximum number of blobs allowed per transaction
 	MinTip       *big.Int // Minimum gas tip needed to allow a transaction into the caller pool
+
+	Policy AdmissionPolicy // Node-specific admission rules, run after the transaction was found valid
 }
 
 // ValidationFunction is an method type which the pools use to perform the tx-validations which do not
@@ -60,7 +62,29 @@ type ValidationFunction func(tx *types.Transaction, head *types.Header, signer t
 //
 // This check is public to allow different transaction pools to check the basic
 // rules without duplicating code and running the risk of missed updates.
+//
+// If the options contain an admission policy, it is run once the transaction is
+// known to be valid.
 func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types.Signer, opts *ValidationOptions) error {
+	if err := validateTransaction(tx, head, signer, opts); err != nil {
+		return err
+	}
+	if opts.Policy == nil {
+		return nil
+	}
+	from, _ := types.Sender(signer, tx) // already validated, cached
+	if err := opts.Policy.Admit(tx, from); err != nil {
+		var perr *PolicyError
+		if !errors.As(err, &perr) {
+			err = &PolicyError{Rule: PolicyCustom, Reason: err.Error()}
+		}
+		return err
+	}
+	return nil
+}
+
+// validateTransaction implements the consensus validation of ValidateTransaction.
+func validateTransaction(tx *types.Transaction, head *types.Header, signer types.Signer, opts *ValidationOptions) error {
 	// Ensure transactions not implemented by the calling pool are rejected
 	if opts.Accept&(1<<tx.Type()) == 0 {
 		return fmt.Errorf("%w: tx type %v not supported by this pool", core.ErrTxTypeNotSupported, tx.Type())
@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
 )
@@ -141,3 +142,16 @@ func (api *AdminAPI) ImportChain(file string) (bool, error) {
 	}
 	return true, nil
 }
+
+// TxPolicyLists returns the address deny and allow lists of the transaction pool
+// admission policy.
+func (api *AdminAPI) TxPolicyLists() txpool.PolicyLists {
+	return api.eth.txPolicy.Lists()
+}
+
+// SetTxPolicyLists replaces the address deny and allow lists of the transaction
+// pool admission policy. Transactions already in the pool are not affected.
+func (api *AdminAPI) SetTxPolicyLists(lists txpool.PolicyLists) bool {
+	api.eth.txPolicy.SetLists(lists)
+	return true
+}
@@ -90,7 +90,7 @@ func (b *EthAPIBackend) SetHead(number uint64) error {
 func (b *EthAPIBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
 	// Pending block is only known by the miner
 	if number == rpc.PendingBlockNumber {
-		block, _, _ := b.eth.miner.Pending()
+		block, _, _ := b.Pending(ctx)
 		if block == nil {
 			return nil, errors.New("pending block is not available")
 		}
@@ -147,7 +147,7 @@ func (b *EthAPIBackend) HeaderByHash(ctx context.Context, hash common.Hash) (*ty
 func (b *EthAPIBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error) {
 	// Pending block is only known by the miner
 	if number == rpc.PendingBlockNumber {
-		block, _, _ := b.eth.miner.Pending()
+		block, _, _ := b.Pending(ctx)
 		if block == nil {
 			return nil, errors.New("pending block is not available")
 		}
@@ -236,14 +236,19 @@ func (b *EthAPIBackend) BlockByNumberOrHash(ctx context.Context, blockNrOrHash r
 	return nil, errors.New("invalid arguments; neither block nor hash specified")
 }
 
-func (b *EthAPIBackend) Pending() (*types.Block, types.Receipts, *state.StateDB) {
-	return b.eth.miner.Pending()
+// Pending returns the pending block of the miner. Callers who are not trusted
+// with private pool transactions get a pending block built without them.
+func (b *EthAPIBackend) Pending(ctx context.Context) (*types.Block, types.Receipts, *state.StateDB) {
+	if ethapi.PrivateTxsAllowed(ctx) {
+		return b.eth.miner.Pending()
+	}
+	return b.eth.miner.PendingPublic()
 }
 
 func (b *EthAPIBackend) StateAndHeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*state.StateDB, *types.Header, error) {
 	// Pending state is only known by the miner
 	if number == rpc.PendingBlockNumber {
-		block, _, state := b.eth.miner.Pending()
+		block, _, state := b.Pending(ctx)
 		if block == nil || state == nil {
 			return nil, nil, errors.New("pending state is not available")
 		}
@@ -380,6 +385,13 @@ func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction)
 	return nil
 }
 
+// SendPrivateTx adds a transaction to the pool without propagating it to the
+// network. It is not tracked by the local transaction tracker, so it's not
+// resubmitted once it expires.
+func (b *EthAPIBackend) SendPrivateTx(ctx context.Context, signedTx *types.Transaction, expiry uint64) error {
+	return b.eth.txPool.AddPrivate(signedTx, expiry, false)
+}
+
 func (b *EthAPIBackend) GetPoolTransactions() (types.Transactions, error) {
 	pending, _ := b.eth.txPool.Pending(txpool.PendingFilter{})
 	var txs types.Transactions
@@ -418,6 +430,24 @@ func (b *EthAPIBackend) TxIndexDone() bool {
 	return b.eth.blockchain.TxIndexDone()
 }
 
+// GetTransactionBySenderAndNonce retrieves the canonical transaction sent by an
+// account with the given nonce through the address index.
+func (b *EthAPIBackend) GetTransactionBySenderAndNonce(sender common.Address, nonce uint64) (*core.AddrTransaction, error) {
+	return b.eth.blockchain.GetTransactionBySenderAndNonce(sender, nonce)
+}
+
+// GetTransactionsByAddress retrieves a page of the canonical transactions sent,
+// received or created by an account through the address index.
+func (b *EthAPIBackend) GetTransactionsByAddress(addr common.Address, number uint64, index uint32, limit int) ([]*core.AddrTransaction, *rawdb.AddrTxEntry, error) {
+	return b.eth.blockchain.GetTransactionsByAddress(addr, number, index, limit)
+}
+
+// AddrIndexDone returns true if the address indexer has finished indexing.
+func (b *EthAPIBackend) AddrIndexDone() bool {
+	progress, err := b.eth.blockchain.AddrIndexProgress()
+	return err != nil || progress.Done()
+}
+
 func (b *EthAPIBackend) GetPoolNonce(ctx context.Context, addr common.Address) (uint64, error) {
 	return b.eth.txPool.PoolNonce(addr), nil
 }
@@ -434,6 +464,10 @@ func (b *EthAPIBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transac
 	return b.eth.txPool.ContentFrom(addr)
 }
 
+func (b *EthAPIBackend) TxPoolPrivate() map[common.Hash]uint64 {
+	return b.eth.txPool.PrivateTxs()
+}
+
 func (b *EthAPIBackend) TxPool() *txpool.TxPool {
 	return b.eth.txPool
 }
@@ -442,6 +476,10 @@ func (b *EthAPIBackend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.S
 	return b.eth.txPool.SubscribeTransactions(ch, true)
 }
 
+func (b *EthAPIBackend) SubscribeTxLifecycleEvent(ch chan<- txpool.LifecycleEvent) event.Subscription {
+	return b.eth.txPool.SubscribeLifecycle(ch)
+}
+
 func (b *EthAPIBackend) SyncProgress(ctx context.Context) ethereum.SyncProgress {
 	prog := b.eth.Downloader().Progress()
 	if txProg, err := b.eth.blockchain.TxIndexProgress(); err == nil {
@@ -464,6 +502,10 @@ func (b *EthAPIBackend) FeeHistory(ctx context.Context, blockCount uint64, lastB
 	return b.gpo.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
 }
 
+func (b *EthAPIBackend) FeeEstimate(ctx context.Context, blobBlocks uint64) (*gasprice.FeeEstimate, error) {
+	return b.gpo.FeeEstimate(ctx, blobBlocks)
+}
+
 func (b *EthAPIBackend) BaseFee(ctx context.Context) *big.Int {
 	header := b.CurrentHeader()
 	next := new(big.Int).Add(header.Number, common.Big1)
@@ -36,7 +36,11 @@ import (
 	"github.com/ethereum/go-ethereum/core/txpool/locals"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/miner"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/holiman/uint256"
 )
 
@@ -180,3 +184,116 @@ func testSendTx(t *testing.T, withLocal bool) {
 		}
 	}
 }
+
+func TestSendPrivateTx(t *testing.T) {
+	b := initBackend(false)
+
+	// Submit a private transaction expiring at block 1, and a public one
+	private := makeTx(0, nil, nil, key)
+	if err := b.SendPrivateTx(context.Background(), private, 1); err != nil {
+		t.Fatalf("Failed to submit private tx: %v", err)
+	}
+	if err := b.SendPrivateTx(context.Background(), private, 1); !errors.Is(err, txpool.ErrAlreadyKnown) {
+		t.Fatalf("Unexpected error resubmitting private tx, want: %v, got: %v", txpool.ErrAlreadyKnown, err)
+	}
+	public := makeTx(1, nil, nil, key)
+	if err := b.SendTx(context.Background(), public); err != nil {
+		t.Fatalf("Failed to submit tx: %v", err)
+	}
+	if err := b.SendPrivateTx(context.Background(), public, 1); !errors.Is(err, txpool.ErrAlreadyKnown) {
+		t.Fatalf("Unexpected error making public tx private, want: %v, got: %v", txpool.ErrAlreadyKnown, err)
+	}
+	if meta := b.TxPool().GetMetadata(private.Hash()); meta == nil || !meta.Private || meta.Expiry != 1 {
+		t.Fatalf("Wrong private tx metadata: %+v", meta)
+	}
+	if meta := b.TxPool().GetMetadata(public.Hash()); meta == nil || meta.Private {
+		t.Fatalf("Wrong public tx metadata: %+v", meta)
+	}
+	if private := b.TxPoolPrivate(); len(private) != 1 {
+		t.Fatalf("Wrong number of private txs: have %d, want 1", len(private))
+	}
+	// Private transactions are offered to the miner
+	if err := b.TxPool().Sync(); err != nil {
+		t.Fatalf("Failed to sync pool: %v", err)
+	}
+	if pending, _ := b.TxPool().Pending(txpool.PendingFilter{}); len(pending[address]) != 2 {
+		t.Fatalf("Wrong number of pending txs: have %d, want 2", len(pending[address]))
+	}
+	// Move the chain past the expiry block, the private transaction must be dropped
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, beacon.New(ethash.NewFaker()), 2, nil)
+	if _, err := b.eth.blockchain.InsertChain(blocks); err != nil {
+		t.Fatalf("Failed to insert blocks: %v", err)
+	}
+	if err := b.TxPool().Sync(); err != nil {
+		t.Fatalf("Failed to sync pool: %v", err)
+	}
+	if b.TxPool().Has(private.Hash()) {
+		t.Fatalf("Expired private tx not dropped")
+	}
+	if !b.TxPool().Has(public.Hash()) {
+		t.Fatalf("Public tx dropped")
+	}
+	// The dropped transaction stays flagged, so that late events don't leak it
+	if !b.eth.txPool.IsPrivate(private.Hash()) || b.eth.txPool.IsPrivate(public.Hash()) {
+		t.Fatalf("Wrong private flags after expiry")
+	}
+}
+
+func TestPendingHidesPrivateTx(t *testing.T) {
+	b := initBackend(false)
+	b.eth.miner = miner.New(b.eth, ethconfig.Defaults.Miner, beacon.New(ethash.NewFaker()))
+
+	// Submit a public transaction and a private one on top of it
+	public := makeTx(0, nil, nil, key)
+	if err := b.SendTx(context.Background(), public); err != nil {
+		t.Fatalf("Failed to submit tx: %v", err)
+	}
+	private := makeTx(1, nil, nil, key)
+	if err := b.SendPrivateTx(context.Background(), private, 10); err != nil {
+		t.Fatalf("Failed to submit private tx: %v", err)
+	}
+	if err := b.TxPool().Sync(); err != nil {
+		t.Fatalf("Failed to sync pool: %v", err)
+	}
+	// The miner's pending block includes the private transaction
+	if block, _, _ := b.eth.miner.Pending(); block == nil || len(block.Transactions()) != 2 {
+		t.Fatalf("Pending block doesn't include all transactions")
+	}
+	// Untrusted callers must not see it through any of the pending endpoints
+	var (
+		ctx   = context.Background()
+		api   = ethapi.NewBlockChainAPI(b)
+		txapi = ethapi.NewTransactionAPI(b, new(ethapi.AddrLocker))
+	)
+	block, err := api.GetBlockByNumber(ctx, rpc.PendingBlockNumber, false)
+	if err != nil {
+		t.Fatalf("Failed to get pending block: %v", err)
+	}
+	if txs := block["transactions"].([]interface{}); len(txs) != 1 || txs[0] != public.Hash() {
+		t.Fatalf("Wrong pending block transactions: %v", txs)
+	}
+	receipts, err := api.GetBlockReceipts(ctx, rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber))
+	if err != nil {
+		t.Fatalf("Failed to get pending receipts: %v", err)
+	}
+	if len(receipts) != 1 {
+		t.Fatalf("Wrong number of pending receipts: have %d, want 1", len(receipts))
+	}
+	if count, _ := txapi.GetBlockTransactionCountByNumber(ctx, rpc.PendingBlockNumber); count == nil || *count != 1 {
+		t.Fatalf("Wrong pending transaction count: %v", count)
+	}
+	if tx, _ := txapi.GetTransactionByBlockNumberAndIndex(ctx, rpc.PendingBlockNumber, 0); tx == nil || tx.Hash != public.Hash() {
+		t.Fatalf("Wrong pending transaction at index 0: %v", tx)
+	}
+	if tx, _ := txapi.GetTransactionByBlockNumberAndIndex(ctx, rpc.PendingBlockNumber, 1); tx != nil {
+		t.Fatalf("Private transaction served from the pending block: %v", tx)
+	}
+	pending := rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
+	nonce, err := txapi.GetTransactionCount(ctx, address, &pending)
+	if err != nil {
+		t.Fatalf("Failed to get pending nonce: %v", err)
+	}
+	if *nonce != 1 {
+		t.Fatalf("Wrong pending nonce: have %d, want 1", *nonce)
+	}
+}
@@ -17,9 +17,24 @@
 package eth
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/miner"
+)
+
+const (
+	// defaultBundleLifetime is the number of blocks a bundle stays eligible for
+	// inclusion if no maximum block is given.
+	defaultBundleLifetime = 25
+
+	// maxBundleLifetime is the maximum number of blocks ahead of the chain head a
+	// bundle can target.
+	maxBundleLifetime = 1024
 )
 
 // MinerAPI provides an API to control the miner.
@@ -56,3 +71,44 @@ func (api *MinerAPI) SetGasLimit(gasLimit hexutil.Uint64) bool {
 	api.e.Miner().SetGasCeil(uint64(gasLimit))
 	return true
 }
+
+// BundleArgs represents the arguments of a transaction bundle.
+type BundleArgs struct {
+	Txs               []hexutil.Bytes `json:"txs"`
+	MinBlock          *hexutil.Uint64 `json:"minBlock"`
+	MaxBlock          *hexutil.Uint64 `json:"maxBlock"`
+	RevertingTxHashes []common.Hash   `json:"revertingTxHashes"`
+}
+
+// AddBundle submits an ordered list of signed transactions to be included in a
+// block in the given range, in full and in order or not at all. If no maximum
+// block is given, the bundle stays eligible for a limited number of blocks. It
+// returns the hash of the bundle.
+func (api *MinerAPI) AddBundle(args BundleArgs) (common.Hash, error) {
+	if len(args.Txs) == 0 {
+		return common.Hash{}, errors.New("empty bundle")
+	}
+	bundle := &miner.Bundle{
+		Txs:       make([]*types.Transaction, len(args.Txs)),
+		Reverting: args.RevertingTxHashes,
+	}
+	for i, input := range args.Txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(input); err != nil {
+			return common.Hash{}, fmt.Errorf("invalid transaction %d: %v", i, err)
+		}
+		bundle.Txs[i] = tx
+	}
+	head := api.e.blockchain.CurrentBlock().Number.Uint64()
+	if args.MinBlock != nil {
+		bundle.MinBlock = uint64(*args.MinBlock)
+	}
+	bundle.MaxBlock = max(bundle.MinBlock, head+1) + defaultBundleLifetime - 1
+	if args.MaxBlock != nil {
+		bundle.MaxBlock = uint64(*args.MaxBlock)
+	}
+	if bundle.MaxBlock > head+maxBundleLifetime {
+		return common.Hash{}, fmt.Errorf("maximum block %d too far ahead, limit %d", bundle.MaxBlock, head+maxBundleLifetime)
+	}
+	return api.e.Miner().AddBundle(bundle)
+}
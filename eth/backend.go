@@ -40,6 +40,8 @@ import (
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/core/txpool/locals"
+	"github.com/ethereum/go-ethereum/core/txpool/snapshot"
+	"github.com/ethereum/go-ethereum/core/txpool/txorder"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/downloader"
@@ -95,14 +97,16 @@ type Ethereum struct {
 	// core protocol objects
 	config         *ethconfig.Config
 	txPool         *txpool.TxPool
+	txPolicy       *txpool.Policy
 	blobTxPool     *blobpool.BlobPool
 	blobCache      *blobpool.Cache
 	localTxTracker *locals.TxTracker
 	blockchain     *core.BlockChain
 
-	handler *handler
-	discmix *enode.FairMix
-	dropper *dropper
+	handler    *handler
+	discmix    *enode.FairMix
+	dropper    *dropper
+	reputation *reputationUpdater
 
 	// DB interfaces
 	chainDb ethdb.Database // Block chain database
@@ -250,6 +254,8 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			StateScheme:             scheme,
 			HistoryPolicy:           histPolicy,
 			TxLookupLimit:           int64(min(config.TransactionHistory, math.MaxInt64)),
+			AddrIndex:               config.AddressIndex,
+			AddrIndexLimit:          config.AddressHistory,
 			VmConfig: vm.Config{
 				EnablePreimageRecording: config.EnablePreimageRecording,
 			},
@@ -321,6 +327,15 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	eth.closeFilterMaps = make(chan chan struct{})
 
 	// TxPool
+	var customPolicies []txpool.AdmissionPolicy
+	if config.TxCustomPolicy != nil {
+		customPolicies = append(customPolicies, config.TxCustomPolicy)
+	}
+	if eth.txPolicy, err = txpool.NewPolicy(config.TxPolicy, customPolicies...); err != nil {
+		return nil, fmt.Errorf("invalid txpool admission policy: %v", err)
+	}
+	config.TxPool.Policy, config.BlobPool.Policy = eth.txPolicy, eth.txPolicy
+
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
@@ -346,7 +361,6 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		eth.localTxTracker = locals.New(config.TxPool.Journal, rejournal, eth.blockchain.Config(), eth.txPool)
 		stack.RegisterLifecycle(eth.localTxTracker)
 	}
-
 	// Permit the downloader to use the trie cache allowance during fast sync
 	cacheLimit := options.TrieCleanLimit + options.TrieDirtyLimit + options.SnapshotLimit
 	if eth.handler, err = newHandler(&handlerConfig{
@@ -366,7 +380,11 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	}
 
 	eth.dropper = newDropper(eth.p2pServer.MaxDialedConns(), eth.p2pServer.MaxInboundConns())
+	eth.reputation = newReputationUpdater(eth.handler.txTracker.GetAllPeerStats, eth.handler.downloader.PeerThroughputs, eth.p2pServer.AdjustReputation)
 
+	if _, err := txorder.New(config.Miner.TxOrdering, txorder.Config{SenderCap: config.Miner.TxOrderingSenderCap}); err != nil {
+		return nil, fmt.Errorf("invalid miner transaction ordering: %v", err)
+	}
 	eth.miner = miner.New(eth, config.Miner, eth.engine)
 	eth.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
 	eth.miner.SetPrioAddresses(config.TxPool.Locals)
@@ -385,6 +403,26 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	stack.RegisterProtocols(eth.Protocols())
 	stack.RegisterLifecycle(eth)
 
+	// Register the pool snapshotter after the backend, so that it's stopped first
+	// and takes its final snapshot before the pool is closed.
+	if config.TxPool.Snapshot != "" {
+		interval := config.TxPool.SnapshotInterval
+		if interval < time.Second {
+			log.Warn("Sanitizing invalid txpool snapshot interval", "provided", interval, "updated", time.Second)
+			interval = time.Second
+		}
+		stack.RegisterLifecycle(snapshot.New(stack.ResolvePath(config.TxPool.Snapshot), interval, config.TxPool.SnapshotLimit, eth.txPool))
+	}
+
+	// Report the node ready only once it's following the chain
+	health := stack.Config().Health
+	if !health.AllowSyncing {
+		stack.RegisterReadinessCheck("sync", eth.checkSynced)
+	}
+	if health.MaxHeadAge > 0 {
+		stack.RegisterReadinessCheck("head", func() error { return eth.checkHeadAge(health.MaxHeadAge) })
+	}
+
 	// Successful startup; push a marker and check previous unclean shutdowns.
 	eth.shutdownTracker.MarkStartup()
 
@@ -455,6 +493,24 @@ func (s *Ethereum) SetSynced()                         { s.handler.enableSyncedF
 func (s *Ethereum) ArchiveMode() bool                  { return s.config.NoPruning }
 func (s *Ethereum) EngineMaxReorgDepth() uint64        { return s.config.EngineMaxReorgDepth }
 
+// checkSynced is the readiness check of the sync status.
+func (s *Ethereum) checkSynced() error {
+	if prog := s.Downloader().Progress(); prog.CurrentBlock < prog.HighestBlock {
+		return fmt.Errorf("syncing, at block #%d of #%d", prog.CurrentBlock, prog.HighestBlock)
+	}
+	return nil
+}
+
+// checkHeadAge is the readiness check of the chain head, which must not be older
+// than the given age.
+func (s *Ethereum) checkHeadAge(maxAge time.Duration) error {
+	head := s.blockchain.CurrentBlock()
+	if age := time.Since(time.Unix(int64(head.Time), 0)); age > maxAge {
+		return fmt.Errorf("head #%d is %v old", head.Number, common.PrettyDuration(age))
+	}
+	return nil
+}
+
 // Protocols returns all the currently configured
 // network protocols to start.
 func (s *Ethereum) Protocols() []p2p.Protocol {
@@ -481,6 +537,9 @@ func (s *Ethereum) Start() error {
 	// Start the connection manager with inclusion-based peer protection.
 	s.dropper.Start(s.p2pServer, func() bool { return !s.Synced() }, s.handler.txTracker.GetAllPeerStats)
 
+	// Start crediting peers for useful work in their reputation.
+	s.reputation.Start()
+
 	// Subscribe to chain events for the filterMaps head updater.
 	s.fmHeadSub = s.blockchain.SubscribeChainEvent(s.fmHeadEventCh)
 	s.fmBlockProcSub = s.blockchain.SubscribeBlockProcessingEvent(s.fmBlockProcCh)
@@ -605,6 +664,7 @@ func (s *Ethereum) Stop() error {
 	// Stop all the peer-related stuff first.
 	s.discmix.Close()
 	s.dropper.Stop()
+	s.reputation.Stop()
 	s.handler.txTracker.Stop()
 	s.handler.Stop()
 
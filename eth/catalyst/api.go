@@ -50,14 +50,18 @@ import (
 
 // Register adds the engine API and related APIs to the full node.
 func Register(stack *node.Node, backend *eth.Ethereum) error {
+	api := NewConsensusAPI(backend)
 	stack.RegisterAPIs([]rpc.API{
 		newTestingAPI(backend),
 		{
 			Namespace:     "engine",
-			Service:       NewConsensusAPI(backend),
+			Service:       api,
 			Authenticated: true,
 		},
 	})
+	if maxAge := stack.Config().Health.MaxForkchoiceAge; maxAge > 0 {
+		stack.RegisterReadinessCheck("forkchoice", func() error { return api.checkForkchoice(maxAge) })
+	}
 	return nil
 }
 
@@ -1185,6 +1189,22 @@ func (api *ConsensusAPI) heartbeat() {
 	}
 }
 
+// checkForkchoice is the readiness check of the consensus client, which must have
+// sent a forkchoice update within the given age.
+func (api *ConsensusAPI) checkForkchoice(maxAge time.Duration) error {
+	if api.config().TerminalTotalDifficulty == nil {
+		return nil
+	}
+	last := api.lastForkchoiceUpdate.Load()
+	if last == 0 {
+		return errors.New("no forkchoice update received")
+	}
+	if age := time.Since(time.Unix(last, 0)); age > maxAge {
+		return fmt.Errorf("last forkchoice update %v ago", common.PrettyDuration(age))
+	}
+	return nil
+}
+
 // config retrieves the chain's fork configuration.
 func (api *ConsensusAPI) config() *params.ChainConfig {
 	return api.eth.BlockChain().Config()
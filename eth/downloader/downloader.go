@@ -265,6 +265,12 @@ func New(stateDb ethdb.Database, mode ethconfig.SyncMode, chain BlockChain, drop
 	return dl
 }
 
+// PeerThroughputs returns the data retrieval throughput of each download peer
+// relative to the average of all peers, keyed by peer id.
+func (d *Downloader) PeerThroughputs() map[string]float64 {
+	return d.peers.rates.RelativeCapacities()
+}
+
 // Progress retrieves the synchronisation boundaries, specifically the origin
 // block where synchronisation started at (may have failed/suspended); the block
 // or header sync is currently at; and the latest known block which the sync targets.
@@ -29,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
 )
 
 const (
@@ -46,6 +47,8 @@ const (
 	// The top inclusionProtectionFrac of each category (by score) are
 	// shielded from random dropping. 0.1 = top 10%.
 	inclusionProtectionFrac = 0.1
+	// Peers with at least this reputation score are protected from dropping.
+	reputationProtectionScore = 50
 )
 
 var (
@@ -62,6 +65,9 @@ var (
 // Callback type to get per-peer inclusion statistics.
 type getPeerStatsFunc func() map[string]txtracker.PeerStats
 
+// Callback type to get the reputation score of a peer.
+type getReputationFunc func(enode.ID) float64
+
 // protectionCategory defines a peer scoring function and the fraction of peers
 // to protect per inbound/dialed category. Multiple categories are unioned.
 type protectionCategory struct {
@@ -96,12 +102,15 @@ var protectionCategories = []protectionCategory{
 //     current activity). The union of all protected sets is shielded from
 //     random dropping, and the drop target is chosen randomly from the
 //     remainder.
+//   - Peers with a high reputation score are protected as well, and peers with
+//     a lower score are more likely to be chosen as the drop target.
 type dropper struct {
 	maxDialPeers    int // maximum number of dialed peers
 	maxInboundPeers int // maximum number of inbound peers
 	peersFunc       getPeersFunc
 	syncingFunc     getSyncingFunc
-	peerStatsFunc   getPeerStatsFunc // optional: inclusion stats for protection
+	peerStatsFunc   getPeerStatsFunc  // optional: inclusion stats for protection
+	reputationFunc  getReputationFunc // optional: reputation scores for protection
 
 	// peerDropTimer introduces churn if we are close to limit capacity.
 	// We handle Dialed and Inbound connections separately
@@ -137,6 +146,7 @@ func (cm *dropper) Start(srv *p2p.Server, syncingFunc getSyncingFunc, peerStatsF
 	cm.peersFunc = srv.Peers
 	cm.syncingFunc = syncingFunc
 	cm.peerStatsFunc = peerStatsFunc
+	cm.reputationFunc = srv.Reputation
 	cm.wg.Add(1)
 	go cm.loop()
 }
@@ -184,7 +194,7 @@ func (cm *dropper) dropRandomPeer() bool {
 		dropSkipped.Mark(1)
 		return false
 	}
-	p := droppable[mrand.Intn(len(droppable))]
+	p := cm.dropTarget(droppable)
 	log.Debug("Dropping random peer", "inbound", p.Inbound(),
 		"id", p.ID(), "duration", common.PrettyDuration(p.Lifetime()), "peercountbefore", len(peers))
 	p.Disconnect(p2p.DiscUselessPeer)
@@ -196,16 +206,42 @@ func (cm *dropper) dropRandomPeer() bool {
 	return true
 }
 
+// dropTarget chooses a random peer to drop, weighting the peers by their
+// reputation so that peers with a lower score are more likely to be dropped.
+func (cm *dropper) dropTarget(droppable []*p2p.Peer) *p2p.Peer {
+	if cm.reputationFunc == nil {
+		return droppable[mrand.Intn(len(droppable))]
+	}
+	var (
+		weights = make([]float64, len(droppable))
+		total   float64
+	)
+	for i, p := range droppable {
+		weights[i] = p2p.MaxReputation - cm.reputationFunc(p.ID()) + 1
+		total += weights[i]
+	}
+	pick := mrand.Float64() * total
+	for i, w := range weights {
+		if pick < w {
+			return droppable[i]
+		}
+		pick -= w
+	}
+	return droppable[len(droppable)-1]
+}
+
 // protectedPeers computes the set of peers that should not be dropped based
-// on inclusion stats. Each protection category independently selects its
-// top-N peers per inbound/dialed pool; the union is returned.
+// on inclusion stats and reputation. Each protection category independently
+// selects its top-N peers per inbound/dialed pool; the union is returned,
+// together with the peers of high reputation.
 func (cm *dropper) protectedPeers(peers []*p2p.Peer) map[*p2p.Peer]bool {
+	result := cm.reputablePeers(peers)
 	if cm.peerStatsFunc == nil {
-		return nil
+		return result
 	}
 	stats := cm.peerStatsFunc()
 	if len(stats) == 0 {
-		return nil
+		return result
 	}
 	// Split peers by direction.
 	var inbound, dialed []*p2p.Peer
@@ -216,13 +252,36 @@ func (cm *dropper) protectedPeers(peers []*p2p.Peer) map[*p2p.Peer]bool {
 			dialed = append(dialed, p)
 		}
 	}
-	result := protectedPeersByPool(inbound, dialed, stats)
+	for p := range protectedPeersByPool(inbound, dialed, stats) {
+		if result == nil {
+			result = make(map[*p2p.Peer]bool)
+		}
+		result[p] = true
+	}
 	if len(result) > 0 {
 		log.Debug("Protecting high-value peers from drop", "protected", len(result))
 	}
 	return result
 }
 
+// reputablePeers returns the set of peers whose reputation protects them from
+// being dropped.
+func (cm *dropper) reputablePeers(peers []*p2p.Peer) map[*p2p.Peer]bool {
+	if cm.reputationFunc == nil {
+		return nil
+	}
+	var result map[*p2p.Peer]bool
+	for _, p := range peers {
+		if cm.reputationFunc(p.ID()) >= reputationProtectionScore {
+			if result == nil {
+				result = make(map[*p2p.Peer]bool)
+			}
+			result[p] = true
+		}
+	}
+	return result
+}
+
 // protectedPeersByPool selects the union of top-N peers per protection
 // category across the given already-split inbound and dialed pools.
 // Factored from protectedPeers so tests can exercise the per-pool
@@ -232,3 +232,33 @@ func TestProtectedByPoolPerPoolIndependence(t *testing.T) {
 		t.Fatalf("expected 4 protected peers (top-2 of each pool), got %d", len(protected))
 	}
 }
+
+// TestProtectedPeersReputation verifies that peers with a high reputation are
+// protected without inclusion stats, and that the drop target is never one of
+// them while peers with a lower score are available.
+func TestProtectedPeersReputation(t *testing.T) {
+	peers := makePeers(10)
+	scores := map[enode.ID]float64{
+		peers[0].ID(): reputationProtectionScore,
+		peers[1].ID(): p2p.MaxReputation,
+		peers[2].ID(): reputationProtectionScore - 1,
+	}
+	cm := &dropper{maxDialPeers: 20, maxInboundPeers: 30}
+	cm.reputationFunc = func(id enode.ID) float64 { return scores[id] }
+
+	protected := cm.protectedPeers(peers)
+	if len(protected) != 2 || !protected[peers[0]] || !protected[peers[1]] {
+		t.Fatalf("expected the two reputable peers to be protected, got %v", protected)
+	}
+	// A peer with the maximum score has the least weight as drop target.
+	droppable := []*p2p.Peer{peers[1], peers[3]}
+	var reputable int
+	for i := 0; i < 1000; i++ {
+		if cm.dropTarget(droppable) == peers[1] {
+			reputable++
+		}
+	}
+	if reputable > 50 {
+		t.Fatalf("reputable peer chosen as drop target %d times out of 1000", reputable)
+	}
+}
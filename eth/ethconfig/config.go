@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/history"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
@@ -57,6 +58,7 @@ var Defaults = Config{
 	TxLookupLimit:           2350000,
 	TransactionHistory:      2350000,
 	LogHistory:              2350000,
+	AddressHistory:          2350000,
 	StateHistory:            pathdb.Defaults.StateHistory,
 	TrienodeHistory:         pathdb.Defaults.TrienodeHistory,
 	NodeFullValueCheckpoint: pathdb.Defaults.FullValueCheckpoint,
@@ -114,6 +116,8 @@ type Config struct {
 	LogHistory           uint64 `toml:",omitempty"` // The maximum number of blocks from head where a log search index is maintained.
 	LogNoHistory         bool   `toml:",omitempty"` // No log search index is maintained.
 	LogExportCheckpoints string // export log index checkpoints to file
+	AddressIndex         bool   `toml:",omitempty"` // Whether to index the transactions of each sender, recipient and created contract.
+	AddressHistory       uint64 `toml:",omitempty"` // The maximum number of blocks from head whose address indices are reserved.
 	StateHistory         uint64 `toml:",omitempty"` // The maximum number of blocks from head whose state histories are reserved.
 	TrienodeHistory      int64  `toml:",omitempty"` // Number of blocks from the chain head for which trienode histories are retained
 
@@ -170,6 +174,11 @@ type Config struct {
 	TxPool   legacypool.Config
 	BlobPool blobpool.Config
 
+	// Admission policy of the transaction pool. The custom policy is run after
+	// the configured rules.
+	TxPolicy       txpool.PolicyConfig
+	TxCustomPolicy txpool.AdmissionPolicy `toml:"-"`
+
 	// Gas Price Oracle options
 	GPO gasprice.Config
 
@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/history"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
@@ -30,6 +31,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		LogHistory              uint64 `toml:",omitempty"`
 		LogNoHistory            bool   `toml:",omitempty"`
 		LogExportCheckpoints    string
+		AddressIndex            bool                   `toml:",omitempty"`
+		AddressHistory          uint64                 `toml:",omitempty"`
 		StateHistory            uint64                 `toml:",omitempty"`
 		TrienodeHistory         int64                  `toml:",omitempty"`
 		NodeFullValueCheckpoint uint32                 `toml:",omitempty"`
@@ -52,6 +55,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Miner                   miner.Config
 		TxPool                  legacypool.Config
 		BlobPool                blobpool.Config
+		TxPolicy                txpool.PolicyConfig
+		TxCustomPolicy          txpool.AdmissionPolicy `toml:"-"`
 		GPO                     gasprice.Config
 		EnablePreimageRecording bool
 		EnableWitnessStats      bool
@@ -87,6 +92,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.LogHistory = c.LogHistory
 	enc.LogNoHistory = c.LogNoHistory
 	enc.LogExportCheckpoints = c.LogExportCheckpoints
+	enc.AddressIndex = c.AddressIndex
+	enc.AddressHistory = c.AddressHistory
 	enc.StateHistory = c.StateHistory
 	enc.TrienodeHistory = c.TrienodeHistory
 	enc.NodeFullValueCheckpoint = c.NodeFullValueCheckpoint
@@ -109,6 +116,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Miner = c.Miner
 	enc.TxPool = c.TxPool
 	enc.BlobPool = c.BlobPool
+	enc.TxPolicy = c.TxPolicy
+	enc.TxCustomPolicy = c.TxCustomPolicy
 	enc.GPO = c.GPO
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
 	enc.EnableWitnessStats = c.EnableWitnessStats
@@ -148,6 +157,8 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		LogHistory              *uint64 `toml:",omitempty"`
 		LogNoHistory            *bool   `toml:",omitempty"`
 		LogExportCheckpoints    *string
+		AddressIndex            *bool                  `toml:",omitempty"`
+		AddressHistory          *uint64                `toml:",omitempty"`
 		StateHistory            *uint64                `toml:",omitempty"`
 		TrienodeHistory         *int64                 `toml:",omitempty"`
 		NodeFullValueCheckpoint *uint32                `toml:",omitempty"`
@@ -170,6 +181,8 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Miner                   *miner.Config
 		TxPool                  *legacypool.Config
 		BlobPool                *blobpool.Config
+		TxPolicy                *txpool.PolicyConfig
+		TxCustomPolicy          txpool.AdmissionPolicy `toml:"-"`
 		GPO                     *gasprice.Config
 		EnablePreimageRecording *bool
 		EnableWitnessStats      *bool
@@ -234,6 +247,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.LogExportCheckpoints != nil {
 		c.LogExportCheckpoints = *dec.LogExportCheckpoints
 	}
+	if dec.AddressIndex != nil {
+		c.AddressIndex = *dec.AddressIndex
+	}
+	if dec.AddressHistory != nil {
+		c.AddressHistory = *dec.AddressHistory
+	}
 	if dec.StateHistory != nil {
 		c.StateHistory = *dec.StateHistory
 	}
@@ -300,6 +319,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.BlobPool != nil {
 		c.BlobPool = *dec.BlobPool
 	}
+	if dec.TxPolicy != nil {
+		c.TxPolicy = *dec.TxPolicy
+	}
+	if dec.TxCustomPolicy != nil {
+		c.TxCustomPolicy = dec.TxCustomPolicy
+	}
 	if dec.GPO != nil {
 		c.GPO = *dec.GPO
 	}
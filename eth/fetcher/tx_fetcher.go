@@ -456,6 +456,13 @@ func (f *TxFetcher) handleAddErrors(txs []common.Hash, errs []error, metrics del
 			f.underpriced.Add(txs[i], f.realTime())
 			underpriced++
 
+		// Track the transaction hash if the local admission policy rejected it
+		// too. Peers can't know the rules of this node, so relaying such txs
+		// is not their fault, but there's no point in fetching them again.
+		case errors.Is(err, txpool.ErrPolicyRejected):
+			f.underpriced.Add(txs[i], f.realTime())
+			underpriced++
+
 		default:
 			otherreject++
 		}
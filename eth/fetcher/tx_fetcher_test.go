@@ -19,6 +19,7 @@ package fetcher
 import (
 	"crypto/sha256"
 	"errors"
+	"fmt"
 	"math/big"
 	"math/rand"
 	"slices"
@@ -1227,6 +1228,60 @@ func TestTransactionFetcherUnderpricedDedup(t *testing.T) {
 	})
 }
 
+// Tests that transactions rejected by the admission policy don't get rescheduled,
+// and that the peers delivering them aren't penalized.
+func TestTransactionFetcherPolicyRejectedDedup(t *testing.T) {
+	policyErr := &txpool.PolicyError{Rule: txpool.PolicyDenied, Reason: "sender denied"}
+
+	testTransactionFetcherParallel(t, txFetcherTest{
+		init: func() *TxFetcher {
+			f := newTestTxFetcher()
+			f.addTxs = func(txs []*types.Transaction) []error {
+				errs := make([]error, len(txs))
+				for i := range errs {
+					errs[i] = policyErr
+				}
+				return errs
+			}
+			return f
+		},
+		steps: []interface{}{
+			// Deliver a transaction through the fetcher, but reject it by policy
+			doTxNotify{peer: "A",
+				hashes: []common.Hash{testTxsHashes[0], testTxsHashes[1]},
+				types:  []byte{testTxs[0].Type(), testTxs[1].Type()},
+				sizes:  []uint32{uint32(testTxs[0].Size()), uint32(testTxs[1].Size())},
+			},
+			doWait{time: txArriveTimeout, step: true},
+			doTxEnqueue{peer: "A", txs: []*types.Transaction{testTxs[0], testTxs[1]}, direct: true},
+			isScheduled{nil, nil, nil},
+
+			// Try to announce the transaction again, ensure it's not scheduled back
+			doTxNotify{peer: "A",
+				hashes: []common.Hash{testTxsHashes[0], testTxsHashes[1], testTxsHashes[2]},
+				types:  []byte{testTxs[0].Type(), testTxs[1].Type(), testTxs[2].Type()},
+				sizes:  []uint32{uint32(testTxs[0].Size()), uint32(testTxs[1].Size()), uint32(testTxs[2].Size())},
+			}, // [2] is needed to force a step in the fetcher
+			isWaiting(map[string][]announce{
+				"A": {{testTxsHashes[2], testTxs[2].Type(), uint32(testTxs[2].Size())}},
+			}),
+			isScheduled{nil, nil, nil},
+		},
+	})
+	// Policy rejections must not count towards throttling the peer
+	f := newTestTxFetcher()
+	metrics := deliveryMetrics{
+		inMeter:          txReplyInMeter,
+		knownMeter:       txReplyKnownMeter,
+		underpricedMeter: txReplyUnderpricedMeter,
+		otherRejectMeter: txReplyOtherRejectMeter,
+	}
+	hashes := []common.Hash{testTxsHashes[0], testTxsHashes[1]}
+	if reject := f.handleAddErrors(hashes, []error{policyErr, fmt.Errorf("wrapped: %w", policyErr)}, metrics); reject != 0 {
+		t.Errorf("policy rejections counted as misbehaviour: %d", reject)
+	}
+}
+
 // Tests that underpriced transactions don't get rescheduled after being rejected,
 // but at the same time there's a hard cap on the number of transactions that are
 // tracked.
@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/history"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -45,6 +46,12 @@ var (
 	errExceedMaxTopics        = errors.New("exceed max topics")
 	errExceedLogQueryLimit    = errors.New("exceed max addresses or topics per search position")
 	errExceedMaxTxHashes      = errors.New("exceed max number of transaction hashes allowed per transactionReceipts subscription")
+	errInvalidCursor          = invalidParamsErr("invalid log cursor")
+	errCursorReorged          = errors.New("log cursor block reorged out of the chain")
+	errMissingPageLimit       = invalidParamsErr("missing page limit")
+	errPaginationUnsupported  = invalidParamsErr("pagination is only supported by eth_getLogsPage")
+	errReplayUnsupported      = invalidParamsErr("log replay is only supported by subscriptions")
+	errMissingReplayStart     = invalidParamsErr("log replay requires fromBlock or blockHash")
 )
 
 type invalidParamsError struct {
@@ -65,6 +72,21 @@ const (
 	maxSubTopics = 1000
 	// The maximum number of transaction hash criteria allowed in a single subscription
 	maxTxHashes = 200
+	// The number of blocks searched at once when replaying historical logs
+	logsReplayChunk = 2048
+	// The maximum number of live logs queued up while replaying historical logs
+	logsReplayQueue = 10000
+	// The depth of the reorgs below the head tracked by replaying log subscriptions
+	logsReplayReorgDepth = 128
+	// The maximum number of blocks replayed by a single log subscription
+	logsReplayLimit = 100_000
+	// The maximum number of logs allowed in a single page of eth_getLogsPage
+	maxLogsPageLimit = 10000
+	// The maximum number of blocks searched for a single page of eth_getLogsPage
+	logsPageBlocks = 1 << 16
+	// The number of blocks searched first for a page of eth_getLogsPage, doubled
+	// after each chunk without enough logs
+	logsPageChunk = 1024
 )
 
 // filter is a helper struct that holds meta information over the filter type
@@ -146,10 +168,14 @@ func (api *FilterAPI) timeoutLoop(timeout time.Duration) {
 //
 // It is part of the filter package because this filter can be used through the
 // `eth_getFilterChanges` polling method that is also used for log filters.
-func (api *FilterAPI) NewPendingTransactionFilter(fullTx *bool) rpc.ID {
+//
+// Private transactions are only reported if the filter is created by a trusted
+// caller.
+func (api *FilterAPI) NewPendingTransactionFilter(ctx context.Context, fullTx *bool) rpc.ID {
 	var (
 		pendingTxs   = make(chan []*types.Transaction)
 		pendingTxSub = api.events.SubscribePendingTxs(pendingTxs)
+		allowed      = ethapi.PrivateTxsAllowed(ctx)
 	)
 
 	api.filtersMu.Lock()
@@ -161,6 +187,7 @@ func (api *FilterAPI) NewPendingTransactionFilter(fullTx *bool) rpc.ID {
 		for {
 			select {
 			case pTx := <-pendingTxs:
+				pTx = api.filterPrivate(allowed, pTx)
 				api.filtersMu.Lock()
 				if f, found := api.filters[pendingTxSub.ID]; found {
 					f.txs = append(f.txs, pTx...)
@@ -180,7 +207,8 @@ func (api *FilterAPI) NewPendingTransactionFilter(fullTx *bool) rpc.ID {
 
 // NewPendingTransactions creates a subscription that is triggered each time a
 // transaction enters the transaction pool. If fullTx is true the full tx is
-// sent to the client, otherwise the hash is sent.
+// sent to the client, otherwise the hash is sent. Private transactions are only
+// reported to trusted callers.
 func (api *FilterAPI) NewPendingTransactions(ctx context.Context, fullTx *bool) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
@@ -191,6 +219,7 @@ func (api *FilterAPI) NewPendingTransactions(ctx context.Context, fullTx *bool)
 		rpcSub       = notifier.CreateSubscription()
 		txs          = make(chan []*types.Transaction, 128)
 		pendingTxSub = api.events.SubscribePendingTxs(txs)
+		allowed      = ethapi.PrivateTxsAllowed(ctx)
 	)
 
 	go func() {
@@ -204,7 +233,7 @@ func (api *FilterAPI) NewPendingTransactions(ctx context.Context, fullTx *bool)
 				// To keep the original behaviour, send a single tx hash in one notification.
 				// TODO(rjl493456442) Send a batch of tx hashes in one notification
 				latest := api.sys.backend.CurrentHeader()
-				for _, tx := range txs {
+				for _, tx := range api.filterPrivate(allowed, txs) {
 					if fullTx != nil && *fullTx {
 						rpcTx := ethapi.NewRPCPendingTransaction(tx, latest, chainConfig)
 						notifier.Notify(rpcSub.ID, rpcTx)
@@ -221,6 +250,25 @@ func (api *FilterAPI) NewPendingTransactions(ctx context.Context, fullTx *bool)
 	return rpcSub, nil
 }
 
+// filterPrivate removes the private transactions from a batch of new pool
+// transactions, unless the subscriber is trusted with them.
+func (api *FilterAPI) filterPrivate(allowed bool, txs []*types.Transaction) []*types.Transaction {
+	if allowed {
+		return txs
+	}
+	private := api.sys.backend.TxPoolPrivate()
+	if len(private) == 0 {
+		return txs
+	}
+	filtered := make([]*types.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		if _, ok := private[tx.Hash()]; !ok {
+			filtered = append(filtered, tx)
+		}
+	}
+	return filtered
+}
+
 // NewBlockFilter creates a filter that fetches blocks that are imported into the chain.
 // It is part of the filter package since polling goes with eth_getFilterChanges.
 func (api *FilterAPI) NewBlockFilter() rpc.ID {
@@ -285,11 +333,47 @@ func (api *FilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
 }
 
 // Logs creates a subscription that fires for all new log that match the given filter criteria.
+//
+// If the criteria request a replay, the matching logs from fromBlock up to the
+// current head are replayed from the chain history before switching over to the
+// live stream. Alternatively, a block hash may be given as the cursor of the last
+// block the client processed: the replay starts at the block after it, and if the
+// cursor was reorged out of the chain, the logs of the dropped blocks are first
+// sent again as removed.
 func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
+	if crit.Limit != 0 || crit.Cursor != nil {
+		return nil, errPaginationUnsupported
+	}
+	// Resolve the first block of the historical logs to replay, if requested
+	var (
+		begin   uint64
+		removed []*types.Log
+	)
+	if crit.Replay {
+		switch {
+		case crit.BlockHash != nil:
+			if crit.FromBlock != nil || crit.ToBlock != nil {
+				return nil, errBlockHashWithRange
+			}
+			var err error
+			if removed, begin, err = api.resolveLogsCursor(ctx, crit); err != nil {
+				return nil, err
+			}
+
+		case crit.FromBlock != nil && crit.FromBlock.Int64() == rpc.EarliestBlockNumber.Int64():
+			begin = api.sys.backend.HistoryPruningCutoff()
+
+		case crit.FromBlock != nil && crit.FromBlock.Sign() >= 0:
+			begin = crit.FromBlock.Uint64()
+
+		default:
+			return nil, errMissingReplayStart
+		}
+	}
 
 	var (
 		rpcSub      = notifier.CreateSubscription()
@@ -300,6 +384,32 @@ func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subsc
 	if err != nil {
 		return nil, err
 	}
+	if crit.Replay {
+		// The live stream is already subscribed, so the head retrieved now marks
+		// the end of the replay without leaving a gap
+		head := api.sys.backend.CurrentHeader().Number.Uint64()
+
+		end := head
+		if crit.ToBlock != nil && crit.ToBlock.Sign() >= 0 && crit.ToBlock.Uint64() < end {
+			end = crit.ToBlock.Uint64()
+		}
+		if end >= begin && end-begin >= logsReplayLimit {
+			logsSub.Unsubscribe()
+			return nil, invalidParamsErr("exceed maximum replay range %d", logsReplayLimit)
+		}
+		tracked := begin
+		if head > logsReplayReorgDepth && head-logsReplayReorgDepth > tracked {
+			tracked = head - logsReplayReorgDepth
+		}
+		r := &logsReplay{
+			begin:     begin,
+			end:       end,
+			tracked:   tracked,
+			delivered: make(map[common.Hash]struct{}),
+		}
+		go api.replayLogs(notifier, rpcSub, logsSub, matchedLogs, crit, removed, r)
+		return rpcSub, nil
+	}
 
 	go func() {
 		defer logsSub.Unsubscribe()
@@ -318,6 +428,190 @@ func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subsc
 	return rpcSub, nil
 }
 
+// resolveLogsCursor resolves the block hash cursor of a log subscription into the
+// first block to replay. If the cursor is not part of the canonical chain anymore,
+// the matching logs of the blocks between it and the canonical chain are returned
+// as removed, oldest block first, and the replay starts after the fork point.
+func (api *FilterAPI) resolveLogsCursor(ctx context.Context, crit FilterCriteria) ([]*types.Log, uint64, error) {
+	header, err := api.sys.backend.HeaderByHash(ctx, *crit.BlockHash)
+	if err != nil {
+		return nil, 0, err
+	}
+	if header == nil {
+		return nil, 0, errUnknownBlock
+	}
+	var removed [][]*types.Log
+	for {
+		canonical, err := api.sys.backend.HeaderByNumber(ctx, rpc.BlockNumber(header.Number.Int64()))
+		if err != nil {
+			return nil, 0, err
+		}
+		if canonical != nil && canonical.Hash() == header.Hash() {
+			break
+		}
+		if len(removed) >= logsReplayReorgDepth {
+			return nil, 0, errors.New("cursor block too far from the canonical chain")
+		}
+		logs, err := api.sys.NewBlockFilter(header.Hash(), crit.Addresses, crit.Topics).Logs(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i, log := range logs {
+			cpy := *log
+			cpy.Removed = true
+			logs[i] = &cpy
+		}
+		removed = append(removed, logs)
+
+		if header, err = api.sys.backend.HeaderByHash(ctx, header.ParentHash); err != nil {
+			return nil, 0, err
+		}
+		if header == nil {
+			return nil, 0, errUnknownBlock
+		}
+	}
+	begin := header.Number.Uint64() + 1
+	if begin < api.sys.backend.HistoryPruningCutoff() {
+		return nil, 0, &history.PrunedHistoryError{}
+	}
+	// Removals are reported in chain order, same as on reorgs
+	var logs []*types.Log
+	for i := len(removed) - 1; i >= 0; i-- {
+		logs = append(logs, removed[i]...)
+	}
+	return logs, begin, nil
+}
+
+// replayLogs delivers the historical logs of a subscription, followed by the live
+// ones. The live logs arriving during the replay are queued and delivered after
+// it, skipping the ones the replay already covered.
+func (api *FilterAPI) replayLogs(notifier *rpc.Notifier, rpcSub *rpc.Subscription, logsSub *Subscription, matchedLogs chan []*types.Log, crit FilterCriteria, removed []*types.Log, r *logsReplay) {
+	defer logsSub.Unsubscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		history = make(chan []*types.Log)
+		done    = make(chan error, 1)
+	)
+	go func() {
+		for from := r.begin; from <= r.end; from += logsReplayChunk {
+			to := min(from+logsReplayChunk-1, r.end)
+
+			logs, err := api.sys.NewRangeFilter(int64(from), int64(to), crit.Addresses, crit.Topics, 0).Logs(ctx)
+			if err != nil {
+				done <- err
+				return
+			}
+			select {
+			case history <- logs:
+			case <-ctx.Done():
+				done <- ctx.Err()
+				return
+			}
+		}
+		done <- nil
+	}()
+	notify := func(logs []*types.Log) {
+		for _, log := range logs {
+			notifier.Notify(rpcSub.ID, log)
+		}
+	}
+	notify(removed)
+
+	// Replay the history, queueing up the live logs meanwhile
+	var (
+		queue  [][]*types.Log
+		queued int
+	)
+	for replaying := true; replaying; {
+		select {
+		case logs := <-history:
+			r.replayed(logs)
+			notify(logs)
+
+		case err := <-done:
+			if err != nil {
+				log.Warn("Failed to replay historical logs", "id", rpcSub.ID, "err", err)
+				return
+			}
+			replaying = false
+
+		case logs := <-matchedLogs:
+			if queued += len(logs); queued > logsReplayQueue {
+				log.Warn("Too many live logs during replay, dropping subscription", "id", rpcSub.ID)
+				return
+			}
+			queue = append(queue, logs)
+
+		case <-rpcSub.Err(): // client send an unsubscribe request
+			return
+		}
+	}
+	// Switch over to the live stream
+	for _, logs := range queue {
+		notify(r.live(logs))
+	}
+	for {
+		select {
+		case logs := <-matchedLogs:
+			notify(r.live(logs))
+		case <-rpcSub.Err(): // client send an unsubscribe request
+			return
+		}
+	}
+}
+
+// logsReplay tracks the blocks whose logs were delivered to a subscription
+// replaying historical logs, to avoid duplicating them on the live stream.
+//
+// Live logs can only overlap the replayed range for blocks imported after the
+// subscription, so only the blocks down to a reorg depth below the head are
+// tracked. Older ones would require a deeper reorg, and are always delivered.
+type logsReplay struct {
+	begin, end uint64                   // Block range of the historical logs
+	tracked    uint64                   // First block of the range tracked for overlaps
+	delivered  map[common.Hash]struct{} // Tracked blocks whose logs are delivered
+}
+
+// replayed records the historical logs delivered to the client.
+func (r *logsReplay) replayed(logs []*types.Log) {
+	for _, log := range logs {
+		if log.BlockNumber >= r.tracked {
+			r.delivered[log.BlockHash] = struct{}{}
+		}
+	}
+}
+
+// live filters a batch of live logs overlapping the replayed range: new logs of
+// blocks already delivered are dropped, as are removals of blocks never delivered.
+func (r *logsReplay) live(logs []*types.Log) []*types.Log {
+	var (
+		result  []*types.Log
+		updates = make(map[common.Hash]bool)
+	)
+	for _, log := range logs {
+		if log.BlockNumber < r.tracked || log.BlockNumber > r.end {
+			result = append(result, log)
+			continue
+		}
+		// A batch contains all logs of a block, decide on the state before it
+		if _, ok := r.delivered[log.BlockHash]; ok == log.Removed {
+			result = append(result, log)
+			updates[log.BlockHash] = !log.Removed
+		}
+	}
+	for hash, delivered := range updates {
+		if delivered {
+			r.delivered[hash] = struct{}{}
+		} else {
+			delete(r.delivered, hash)
+		}
+	}
+	return result
+}
+
 // TransactionReceiptsQuery defines criteria for transaction receipts subscription.
 // Same as ethereum.TransactionReceiptsQuery but with UnmarshalJSON() method.
 type TransactionReceiptsQuery ethereum.TransactionReceiptsQuery
@@ -411,6 +705,12 @@ type FilterCriteria ethereum.FilterQuery
 //
 // In case "fromBlock" > "toBlock" an error is returned.
 func (api *FilterAPI) NewFilter(crit FilterCriteria) (rpc.ID, error) {
+	if crit.Limit != 0 || crit.Cursor != nil {
+		return "", errPaginationUnsupported
+	}
+	if crit.Replay {
+		return "", errReplayUnsupported
+	}
 	logs := make(chan []*types.Log)
 	logsSub, err := api.events.SubscribeLogs(ethereum.FilterQuery(crit), logs)
 	if err != nil {
@@ -443,8 +743,69 @@ func (api *FilterAPI) NewFilter(crit FilterCriteria) (rpc.ID, error) {
 	return logsSub.ID, nil
 }
 
+// LogsPage is a single page of the results of an eth_getLogsPage query.
+type LogsPage struct {
+	Logs   []*types.Log  `json:"logs"`
+	Cursor hexutil.Bytes `json:"cursor,omitempty"` // Continuation token of the next page, omitted on the last one
+}
+
 // GetLogs returns logs matching the given argument that are stored within the state.
 func (api *FilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([]*types.Log, error) {
+	if crit.Limit != 0 || crit.Cursor != nil {
+		return nil, errPaginationUnsupported
+	}
+	if crit.Replay {
+		return nil, errReplayUnsupported
+	}
+	filter, err := api.newQueryFilter(crit)
+	if err != nil {
+		return nil, err
+	}
+	// Run the filter and return all the logs
+	logs, err := filter.Logs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return returnLogs(logs), err
+}
+
+// GetLogsPage returns a single page of at most crit.Limit logs matching the given
+// argument, along with a cursor to pass in the criteria to retrieve the next one.
+func (api *FilterAPI) GetLogsPage(ctx context.Context, crit FilterCriteria) (*LogsPage, error) {
+	if crit.Limit == 0 {
+		return nil, errMissingPageLimit
+	}
+	if crit.Limit > maxLogsPageLimit {
+		return nil, invalidParamsErr("exceed maximum page limit %d", maxLogsPageLimit)
+	}
+	if crit.Replay {
+		return nil, errReplayUnsupported
+	}
+	var cursor *logCursor
+	if crit.Cursor != nil {
+		var err error
+		if cursor, err = decodeLogCursor(crit.Cursor); err != nil {
+			return nil, err
+		}
+	}
+	filter, err := api.newQueryFilter(crit)
+	if err != nil {
+		return nil, err
+	}
+	logs, next, err := filter.Page(ctx, int(crit.Limit), cursor)
+	if err != nil {
+		return nil, err
+	}
+	page := &LogsPage{Logs: returnLogs(logs)}
+	if next != nil {
+		page.Cursor = next.encode()
+	}
+	return page, nil
+}
+
+// newQueryFilter validates the criteria of a one-off log query, and creates the
+// filter running it.
+func (api *FilterAPI) newQueryFilter(crit FilterCriteria) (*Filter, error) {
 	if len(crit.Topics) > maxTopics {
 		return nil, errExceedMaxTopics
 	}
@@ -487,13 +848,7 @@ func (api *FilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([]*type
 		// Construct the range filter
 		filter = api.sys.NewRangeFilter(begin, end, crit.Addresses, crit.Topics, api.rangeLimit)
 	}
-
-	// Run the filter and return all the logs
-	logs, err := filter.Logs(ctx)
-	if err != nil {
-		return nil, err
-	}
-	return returnLogs(logs), err
+	return filter, nil
 }
 
 // UninstallFilter removes the filter with the given filter id.
@@ -627,6 +982,9 @@ func (args *FilterCriteria) UnmarshalJSON(data []byte) error {
 		ToBlock   *rpc.BlockNumber `json:"toBlock"`
 		Addresses interface{}      `json:"address"`
 		Topics    []interface{}    `json:"topics"`
+		Limit     *hexutil.Uint64  `json:"limit"`
+		Cursor    *hexutil.Bytes   `json:"cursor"`
+		Replay    bool             `json:"replay"`
 	}
 
 	var raw input
@@ -650,6 +1008,13 @@ func (args *FilterCriteria) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if raw.Limit != nil {
+		args.Limit = uint64(*raw.Limit)
+	}
+	if raw.Cursor != nil {
+		args.Cursor = *raw.Cursor
+	}
+	args.Replay = raw.Replay
 	args.Addresses = []common.Address{}
 
 	if raw.Addresses != nil {
@@ -18,6 +18,7 @@ package filters
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"math"
 	"math/big"
@@ -96,9 +97,23 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 		return f.blockLogs(ctx, header)
 	}
 
+	begin, end, err := f.resolveRange(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if f.rangeLimit != 0 && (end-begin) > f.rangeLimit {
+		return nil, invalidParamsErr("exceed maximum block range %d", f.rangeLimit)
+	}
+	return f.rangeLogs(ctx, begin, end)
+}
+
+// resolveRange resolves the special block numbers of the filter range. The latest
+// block is resolved to MaxUint64, which is translated by rangeLogs to the actual
+// head in each iteration.
+func (f *Filter) resolveRange(ctx context.Context) (uint64, uint64, error) {
 	// Disallow pending logs.
 	if f.begin == rpc.PendingBlockNumber.Int64() || f.end == rpc.PendingBlockNumber.Int64() {
-		return nil, errPendingLogsUnsupported
+		return 0, 0, errPendingLogsUnsupported
 	}
 
 	resolveSpecial := func(number int64) (uint64, error) {
@@ -139,16 +154,138 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	// range query need to resolve the special begin/end block number
 	begin, err := resolveSpecial(f.begin)
 	if err != nil {
-		return nil, err
+		return 0, 0, err
 	}
 	end, err := resolveSpecial(f.end)
 	if err != nil {
-		return nil, err
+		return 0, 0, err
 	}
-	if f.rangeLimit != 0 && (end-begin) > f.rangeLimit {
-		return nil, invalidParamsErr("exceed maximum block range %d", f.rangeLimit)
+	return begin, end, nil
+}
+
+// logCursor is the position of a log in the chain, at which a paginated log query
+// continues. It is handed out to clients as an opaque token.
+type logCursor struct {
+	number uint64      // Number of the block containing the log
+	hash   common.Hash // Hash of the block, to detect reorgs between pages
+	index  uint        // Index of the log within the block
+}
+
+// logCursorLength is the length of an encoded log cursor.
+const logCursorLength = 8 + common.HashLength + 4
+
+// encode serializes the cursor into an opaque token.
+func (c *logCursor) encode() []byte {
+	enc := make([]byte, logCursorLength)
+	binary.BigEndian.PutUint64(enc, c.number)
+	copy(enc[8:], c.hash[:])
+	binary.BigEndian.PutUint32(enc[8+common.HashLength:], uint32(c.index))
+	return enc
+}
+
+// decodeLogCursor parses a cursor token handed out by a previous page.
+func decodeLogCursor(enc []byte) (*logCursor, error) {
+	if len(enc) != logCursorLength {
+		return nil, errInvalidCursor
 	}
-	return f.rangeLogs(ctx, begin, end)
+	return &logCursor{
+		number: binary.BigEndian.Uint64(enc),
+		hash:   common.BytesToHash(enc[8 : 8+common.HashLength]),
+		index:  uint(binary.BigEndian.Uint32(enc[8+common.HashLength:])),
+	}, nil
+}
+
+// Page returns at most limit logs matching the filter criteria, starting at the
+// given cursor, or at the beginning of the range if nil. The returned cursor is
+// the position of the next matching log if the page is full, or of the first block
+// not searched yet if the search budget ran out, and nil once the range is done.
+//
+// Each page searches at most logsPageBlocks blocks (or the range limit of the
+// filter if lower), in growing chunks, stopping as soon as the page is full.
+func (f *Filter) Page(ctx context.Context, limit int, cursor *logCursor) ([]*types.Log, *logCursor, error) {
+	// Drop the logs preceding the cursor within its block
+	skip := func(logs []*types.Log) []*types.Log {
+		if cursor == nil {
+			return logs
+		}
+		return slices.DeleteFunc(logs, func(log *types.Log) bool {
+			return log.BlockNumber == cursor.number && log.Index < cursor.index
+		})
+	}
+	if f.block != nil {
+		if cursor != nil && cursor.hash != *f.block {
+			return nil, nil, errInvalidCursor
+		}
+		logs, err := f.Logs(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		logs = skip(logs)
+		if len(logs) > limit {
+			return logs[:limit], cursorAt(logs[limit]), nil
+		}
+		return logs, nil, nil
+	}
+	begin, end, err := f.resolveRange(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Pin the head, pages must not chase it
+	if end == math.MaxUint64 {
+		end = f.sys.backend.CurrentHeader().Number.Uint64()
+		begin = min(begin, end)
+	}
+	if begin > end {
+		return nil, nil, nil
+	}
+	if cursor != nil {
+		if cursor.number < begin || cursor.number > end {
+			return nil, nil, errInvalidCursor
+		}
+		header, err := f.sys.backend.HeaderByNumber(ctx, rpc.BlockNumber(cursor.number))
+		if err != nil {
+			return nil, nil, err
+		}
+		if header == nil || header.Hash() != cursor.hash {
+			return nil, nil, errCursorReorged
+		}
+		begin = cursor.number
+	}
+	budget := uint64(logsPageBlocks)
+	if f.rangeLimit != 0 && f.rangeLimit+1 < budget {
+		budget = f.rangeLimit + 1
+	}
+	var (
+		logs  []*types.Log
+		last  = min(end, begin+budget-1)
+		chunk = uint64(logsPageChunk)
+	)
+	for from := begin; from <= last; from, chunk = from+chunk, chunk*2 {
+		found, err := f.rangeLogs(ctx, from, min(from+chunk-1, last))
+		if err != nil {
+			return nil, nil, err
+		}
+		if logs = append(logs, skip(found)...); len(logs) > limit {
+			return logs[:limit], cursorAt(logs[limit]), nil
+		}
+	}
+	if last == end {
+		return logs, nil, nil
+	}
+	// Search budget exhausted, continue at the next block
+	header, err := f.sys.backend.HeaderByNumber(ctx, rpc.BlockNumber(last+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	if header == nil {
+		return nil, nil, errUnknownBlock
+	}
+	return logs, &logCursor{number: last + 1, hash: header.Hash()}, nil
+}
+
+// cursorAt returns the cursor pointing to the given log.
+func cursorAt(log *types.Log) *logCursor {
+	return &logCursor{number: log.BlockNumber, hash: log.BlockHash, index: log.Index}
 }
 
 const (
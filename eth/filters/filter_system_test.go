@@ -49,6 +49,7 @@ type testBackend struct {
 	chainFeed       event.Feed
 	pendingBlock    *types.Block
 	pendingReceipts types.Receipts
+	private         map[common.Hash]uint64
 }
 
 func (b *testBackend) ChainConfig() *params.ChainConfig {
@@ -156,6 +157,10 @@ func (b *testBackend) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscript
 	return b.logsFeed.Subscribe(ch)
 }
 
+func (b *testBackend) TxPoolPrivate() map[common.Hash]uint64 {
+	return b.private
+}
+
 func (b *testBackend) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription {
 	return b.chainFeed.Subscribe(ch)
 }
@@ -281,7 +286,7 @@ func TestPendingTxFilter(t *testing.T) {
 		hashes []common.Hash
 	)
 
-	fid0 := api.NewPendingTransactionFilter(nil)
+	fid0 := api.NewPendingTransactionFilter(context.Background(), nil)
 
 	time.Sleep(1 * time.Second)
 	backend.txFeed.Send(core.NewTxsEvent{Txs: transactions})
@@ -317,6 +322,41 @@ func TestPendingTxFilter(t *testing.T) {
 	}
 }
 
+// TestPendingTxFilterPrivate tests that pending tx filters of untrusted callers
+// don't retrieve private transactions.
+func TestPendingTxFilterPrivate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(db, Config{})
+		api          = NewFilterAPI(sys)
+
+		public  = types.NewTransaction(0, common.HexToAddress("0xb794f5ea0ba39494ce83a213fffba74279579268"), new(big.Int), 0, new(big.Int), nil)
+		private = types.NewTransaction(1, common.HexToAddress("0xb794f5ea0ba39494ce83a213fffba74279579268"), new(big.Int), 0, new(big.Int), nil)
+	)
+	backend.private = map[common.Hash]uint64{private.Hash(): 10}
+
+	fid0 := api.NewPendingTransactionFilter(context.Background(), nil)
+
+	time.Sleep(1 * time.Second)
+	backend.txFeed.Send(core.NewTxsEvent{Txs: []*types.Transaction{private, public}})
+
+	var hashes []common.Hash
+	timeout := time.Now().Add(1 * time.Second)
+	for len(hashes) == 0 && time.Now().Before(timeout) {
+		results, err := api.GetFilterChanges(fid0)
+		if err != nil {
+			t.Fatalf("Unable to retrieve transactions: %v", err)
+		}
+		hashes = append(hashes, results.([]common.Hash)...)
+		time.Sleep(100 * time.Millisecond)
+	}
+	if len(hashes) != 1 || hashes[0] != public.Hash() {
+		t.Fatalf("wrong transactions: have %x, want [%x]", hashes, public.Hash())
+	}
+}
+
 // TestPendingTxFilterFullTx tests whether pending tx filters retrieve all pending transactions that are posted to the event mux.
 func TestPendingTxFilterFullTx(t *testing.T) {
 	t.Parallel()
@@ -338,7 +378,7 @@ func TestPendingTxFilterFullTx(t *testing.T) {
 	)
 
 	fullTx := true
-	fid0 := api.NewPendingTransactionFilter(&fullTx)
+	fid0 := api.NewPendingTransactionFilter(context.Background(), &fullTx)
 
 	time.Sleep(1 * time.Second)
 	backend.txFeed.Send(core.NewTxsEvent{Txs: transactions})
@@ -752,7 +792,7 @@ func TestPendingTxFilterDeadlock(t *testing.T) {
 	// timeout either in 100ms or 200ms
 	subs := make([]*Subscription, 20)
 	for i := range subs {
-		fid := api.NewPendingTransactionFilter(nil)
+		fid := api.NewPendingTransactionFilter(context.Background(), nil)
 		api.filtersMu.Lock()
 		f, ok := api.filters[fid]
 		api.filtersMu.Unlock()
@@ -922,3 +962,161 @@ func TestTransactionReceiptsSubscription(t *testing.T) {
 		})
 	}
 }
+
+// TestLogsSubscriptionReplay tests that log subscriptions requesting a replay
+// deliver the historical logs before switching over to the live ones, without
+// duplicating the blocks already replayed.
+func TestLogsSubscriptionReplay(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(db, Config{})
+		api          = NewFilterAPI(sys)
+		addr         = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		sideAddr     = common.HexToAddress("0x2222222222222222222222222222222222222222")
+
+		gspec = &core.Genesis{
+			Config:  params.TestChainConfig,
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+	)
+	defer db.Close()
+
+	// Every block of the chain emits a single log, the side chain forks off with
+	// a different log at block 7
+	generate := func(n int, side bool) ([]*types.Block, []types.Receipts) {
+		_, blocks, receipts := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), n, func(i int, gen *core.BlockGen) {
+			receipt := makeReceipt(addr)
+			if side && i == n-1 {
+				receipt = makeReceipt(sideAddr)
+			}
+			gen.AddUncheckedReceipt(receipt)
+			gen.AddUncheckedTx(types.NewTransaction(999, common.HexToAddress("0x999"), big.NewInt(999), 999, gen.BaseFee(), nil))
+		})
+		return blocks, receipts
+	}
+	chain, receipts := generate(10, false)
+	side, sideReceipts := generate(7, true)
+
+	gspec.MustCommit(db, triedb.NewDatabase(db, triedb.HashDefaults))
+	for i, block := range chain {
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		rawdb.WriteHeadBlockHash(db, block.Hash())
+		rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), receipts[i])
+	}
+	rawdb.WriteBlock(db, side[6])
+	rawdb.WriteReceipts(db, side[6].Hash(), side[6].NumberU64(), sideReceipts[6])
+
+	backend.startFilterMaps(0, false, filtermaps.DefaultParams)
+	defer backend.stopFilterMaps()
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("eth", api); err != nil {
+		t.Fatal(err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	type expectedLog struct {
+		block   common.Hash
+		address common.Address
+		removed bool
+	}
+	expect := func(name string, logs chan types.Log, want []expectedLog) {
+		for i, w := range want {
+			select {
+			case log := <-logs:
+				if log.BlockHash != w.block || log.Address != w.address || log.Removed != w.removed {
+					t.Fatalf("%s: log %d mismatch: have block %x address %x removed %v, want block %x address %x removed %v",
+						name, i, log.BlockHash, log.Address, log.Removed, w.block, w.address, w.removed)
+				}
+			case <-time.After(3 * time.Second):
+				t.Fatalf("%s: log %d not delivered", name, i)
+			}
+		}
+	}
+	canonical := func(from int) []expectedLog {
+		var logs []expectedLog
+		for _, block := range chain[from-1:] {
+			logs = append(logs, expectedLog{block: block.Hash(), address: addr})
+		}
+		return logs
+	}
+	// Subscribe from a block number and from block hash cursors, both canonical
+	// and reorged out
+	var (
+		fromLogs   = make(chan types.Log, 16)
+		cursorLogs = make(chan types.Log, 16)
+		sideLogs   = make(chan types.Log, 16)
+		plainLogs  = make(chan types.Log, 16)
+	)
+	fromSub, err := client.EthSubscribe(context.Background(), fromLogs, "logs", map[string]any{"fromBlock": "0x5", "replay": true})
+	if err != nil {
+		t.Fatalf("failed to subscribe from block: %v", err)
+	}
+	defer fromSub.Unsubscribe()
+
+	cursorSub, err := client.EthSubscribe(context.Background(), cursorLogs, "logs", map[string]any{"blockHash": chain[7].Hash(), "replay": true})
+	if err != nil {
+		t.Fatalf("failed to subscribe from cursor: %v", err)
+	}
+	defer cursorSub.Unsubscribe()
+
+	sideSub, err := client.EthSubscribe(context.Background(), sideLogs, "logs", map[string]any{"blockHash": side[6].Hash(), "replay": true})
+	if err != nil {
+		t.Fatalf("failed to subscribe from side cursor: %v", err)
+	}
+	defer sideSub.Unsubscribe()
+
+	// A fromBlock alone doesn't request a replay
+	plainSub, err := client.EthSubscribe(context.Background(), plainLogs, "logs", map[string]any{"fromBlock": "0x0"})
+	if err != nil {
+		t.Fatalf("failed to subscribe without replay: %v", err)
+	}
+	defer plainSub.Unsubscribe()
+
+	expect("from block", fromLogs, canonical(5))
+	expect("cursor", cursorLogs, canonical(9))
+	expect("side cursor", sideLogs, append([]expectedLog{{block: side[6].Hash(), address: sideAddr, removed: true}}, canonical(7)...))
+
+	// Post an already replayed block, a new one and the removal of the head
+	head := chain[9]
+	var (
+		replayed = &types.Log{Address: addr, Topics: []common.Hash{}, Data: []byte{}, BlockNumber: head.NumberU64(), BlockHash: head.Hash()}
+		fresh    = &types.Log{Address: addr, Topics: []common.Hash{}, Data: []byte{}, BlockNumber: head.NumberU64() + 1, BlockHash: common.Hash{0x01}}
+		removed  = &types.Log{Address: addr, Topics: []common.Hash{}, Data: []byte{}, BlockNumber: head.NumberU64(), BlockHash: head.Hash(), Removed: true}
+	)
+
+	backend.logsFeed.Send([]*types.Log{replayed})
+	backend.logsFeed.Send([]*types.Log{fresh})
+
+	live := []expectedLog{{block: fresh.BlockHash, address: addr}}
+	expect("from block live", fromLogs, live)
+	expect("cursor live", cursorLogs, live)
+	expect("side cursor live", sideLogs, live)
+	expect("no replay live", plainLogs, append([]expectedLog{{block: head.Hash(), address: addr}}, live...))
+
+	backend.rmLogsFeed.Send(core.RemovedLogsEvent{Logs: []*types.Log{removed}})
+
+	live = []expectedLog{{block: head.Hash(), address: addr, removed: true}}
+	expect("from block removal", fromLogs, live)
+	expect("cursor removal", cursorLogs, live)
+	expect("side cursor removal", sideLogs, live)
+	expect("no replay removal", plainLogs, live)
+
+	// A cursor unknown to the chain must be rejected
+	if _, err := client.EthSubscribe(context.Background(), make(chan types.Log), "logs", map[string]any{"blockHash": common.Hash{0xff}, "replay": true}); err == nil {
+		t.Fatal("subscription from unknown cursor accepted")
+	}
+	// A replay without a start must be rejected
+	if _, err := client.EthSubscribe(context.Background(), make(chan types.Log), "logs", map[string]any{"replay": true}); err == nil {
+		t.Fatal("replay without start accepted")
+	}
+	// One-off queries don't support replays
+	if _, err := api.GetLogs(context.Background(), FilterCriteria{FromBlock: big.NewInt(0), Replay: true}); !errors.Is(err, errReplayUnsupported) {
+		t.Fatalf("wrong error for replaying query: %v", err)
+	}
+}
@@ -651,3 +651,104 @@ func TestRangeLimit(t *testing.T) {
 		t.Fatalf("expected rpc error, got %v", err)
 	}
 }
+
+func TestLogsPagination(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(db, Config{})
+		addr         = common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+		gspec = &core.Genesis{
+			Config:  params.TestChainConfig,
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+	)
+	defer db.Close()
+
+	// Block i contains i%4 logs, to have pages ending mid-block
+	_, chain, receipts := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 20, func(i int, gen *core.BlockGen) {
+		for j := 0; j < i%4; j++ {
+			gen.AddUncheckedReceipt(makeReceipt(addr))
+			gen.AddUncheckedTx(types.NewTransaction(uint64(j), common.HexToAddress("0x999"), big.NewInt(999), 999, gen.BaseFee(), nil))
+		}
+	})
+	gspec.MustCommit(db, triedb.NewDatabase(db, triedb.HashDefaults))
+	for i, block := range chain {
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		rawdb.WriteHeadBlockHash(db, block.Hash())
+		rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), receipts[i])
+	}
+	backend.startFilterMaps(0, false, filtermaps.DefaultParams)
+	defer backend.stopFilterMaps()
+
+	all, err := sys.NewRangeFilter(0, rpc.LatestBlockNumber.Int64(), nil, nil, 0).Logs(context.Background())
+	if err != nil {
+		t.Fatalf("failed to retrieve logs: %v", err)
+	}
+	// Page through the logs, both with pages ending on full pages and on the
+	// search budget bounded by the range limit
+	paginate := func(api *FilterAPI, limit uint64) ([]*types.Log, int) {
+		var (
+			logs   []*types.Log
+			cursor []byte
+			pages  int
+		)
+		for {
+			page, err := api.GetLogsPage(context.Background(), FilterCriteria{FromBlock: big.NewInt(0), Limit: limit, Cursor: cursor})
+			if err != nil {
+				t.Fatalf("page %d: failed to retrieve logs: %v", pages, err)
+			}
+			if uint64(len(page.Logs)) > limit {
+				t.Fatalf("page %d: too many logs: have %d, limit %d", pages, len(page.Logs), limit)
+			}
+			logs, pages = append(logs, page.Logs...), pages+1
+			if page.Cursor == nil {
+				return logs, pages
+			}
+			cursor = page.Cursor
+		}
+	}
+	for _, tt := range []struct {
+		rangeLimit uint64
+		limit      uint64
+		pages      int
+	}{
+		{rangeLimit: 0, limit: 2, pages: 15},
+		{rangeLimit: 0, limit: 29, pages: 2},
+		{rangeLimit: 4, limit: 100, pages: 5},
+	} {
+		api := NewFilterAPI(NewFilterSystem(backend, Config{RangeLimit: tt.rangeLimit}))
+
+		logs, pages := paginate(api, tt.limit)
+		if pages != tt.pages {
+			t.Errorf("range limit %d, limit %d: page count mismatch: have %d, want %d", tt.rangeLimit, tt.limit, pages, tt.pages)
+		}
+		if len(logs) != len(all) {
+			t.Fatalf("range limit %d, limit %d: log count mismatch: have %d, want %d", tt.rangeLimit, tt.limit, len(logs), len(all))
+		}
+		for i := range logs {
+			if logs[i].BlockHash != all[i].BlockHash || logs[i].Index != all[i].Index {
+				t.Errorf("range limit %d, limit %d: log %d mismatch: have %x/%d, want %x/%d", tt.rangeLimit, tt.limit, i, logs[i].BlockHash, logs[i].Index, all[i].BlockHash, all[i].Index)
+			}
+		}
+	}
+	// Cursors of blocks reorged out or malformed must be rejected
+	api := NewFilterAPI(sys)
+
+	cursor := (&logCursor{number: 5, hash: common.Hash{0x01}}).encode()
+	if _, err := api.GetLogsPage(context.Background(), FilterCriteria{Limit: 2, Cursor: cursor, FromBlock: big.NewInt(0)}); !errors.Is(err, errCursorReorged) {
+		t.Errorf("reorged cursor: have %v, want %v", err, errCursorReorged)
+	}
+	if _, err := api.GetLogsPage(context.Background(), FilterCriteria{Limit: 2, Cursor: []byte{0x01}, FromBlock: big.NewInt(0)}); !errors.Is(err, errInvalidCursor) {
+		t.Errorf("malformed cursor: have %v, want %v", err, errInvalidCursor)
+	}
+	if _, err := api.GetLogsPage(context.Background(), FilterCriteria{Cursor: cursor}); !errors.Is(err, errMissingPageLimit) {
+		t.Errorf("cursor without limit: have %v, want %v", err, errMissingPageLimit)
+	}
+	if _, err := api.GetLogs(context.Background(), FilterCriteria{Limit: 2}); !errors.Is(err, errPaginationUnsupported) {
+		t.Errorf("paginated eth_getLogs: have %v, want %v", err, errPaginationUnsupported)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package gasprice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// estimateBlocks is the number of recent blocks sampled by the fee estimation.
+	estimateBlocks = 20
+
+	// fullBlockRatio is the gas used ratio above which a block is considered full,
+	// so that transactions paying less than its lowest tip would have missed it.
+	fullBlockRatio = 0.95
+
+	// MaxBlobForecast is the maximum number of blocks the blob fee is forecast for.
+	MaxBlobForecast = 64
+
+	// forecastSlotTime is the block time assumed when forecasting blob fees.
+	forecastSlotTime = 12
+)
+
+// estimatePercentiles are the reward percentiles sampled by the fee estimation:
+// the lowest tip of each block, then the slow, standard and fast tiers.
+var estimatePercentiles = []float64{0, 10, 50, 90}
+
+// FeeSuggestion is the suggested fee parameters of a dynamic fee transaction
+// for one speed tier.
+type FeeSuggestion struct {
+	MaxFeePerGas         *big.Int // Fee cap covering the base fee increases until the expected inclusion
+	MaxPriorityFeePerGas *big.Int // Tip paid to the block producer
+	Delay                uint64   // Expected number of blocks until inclusion
+}
+
+// FeeEstimate contains the suggested transaction fees for several inclusion
+// speeds, along with a forecast of the blob fees.
+type FeeEstimate struct {
+	BaseFee  *big.Int // Base fee of the next block
+	Slow     FeeSuggestion
+	Standard FeeSuggestion
+	Fast     FeeSuggestion
+
+	BlobBaseFee      []*big.Int // Expected blob base fee of each of the forecast blocks
+	MaxFeePerBlobGas *big.Int   // Blob fee cap keeping a transaction includable in all forecast blocks
+}
+
+// FeeEstimate suggests fees for the slow, standard and fast inclusion of a
+// transaction, based on the tips paid in recent blocks and in the pending block
+// assembled from the pool. The expected inclusion delay of each tier is the
+// average interval between the sampled blocks the tip would have made it into.
+//
+// If the chain supports blobs, the blob base fee is also forecast for the given
+// number of blocks, assuming the recent average blob usage, along with the blob
+// fee cap needed to stay includable if all those blocks were full of blobs.
+func (oracle *Oracle) FeeEstimate(ctx context.Context, blobBlocks uint64) (*FeeEstimate, error) {
+	if blobBlocks > MaxBlobForecast {
+		return nil, fmt.Errorf("blob fee forecast of %d blocks exceeds limit %d", blobBlocks, MaxBlobForecast)
+	}
+	head, err := oracle.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	oldest, rewards, baseFees, gasUsedRatio, _, blobGasUsedRatio, err := oracle.FeeHistory(ctx, estimateBlocks, rpc.PendingBlockNumber, estimatePercentiles)
+	if err != nil {
+		return nil, err
+	}
+	if len(rewards) == 0 {
+		return nil, errors.New("no blocks available for fee estimation")
+	}
+	// The base fees run up to the block after the newest sampled one, which is
+	// the one after the next block if the pending block was sampled
+	next := len(baseFees) - 1
+	if oldest.Uint64()+uint64(len(rewards)) > head.Number.Uint64()+1 {
+		next--
+	}
+	estimate := &FeeEstimate{
+		BaseFee: baseFees[next],
+	}
+	for i, tier := range []*FeeSuggestion{&estimate.Slow, &estimate.Standard, &estimate.Fast} {
+		tier.MaxPriorityFeePerGas = oracle.tierTip(rewards, i+1)
+		tier.Delay = inclusionDelay(tier.MaxPriorityFeePerGas, rewards, gasUsedRatio)
+		tier.MaxFeePerGas = new(big.Int).Add(maxBaseFee(estimate.BaseFee, tier.Delay), tier.MaxPriorityFeePerGas)
+	}
+	// Forecast the blob fees from the current head if blobs are supported
+	if head.ExcessBlobGas != nil && blobBlocks > 0 {
+		var usage float64
+		for _, ratio := range blobGasUsedRatio {
+			usage += ratio
+		}
+		if len(blobGasUsedRatio) > 0 {
+			usage /= float64(len(blobGasUsedRatio))
+		}
+		estimate.BlobBaseFee = oracle.forecastBlobFees(head, estimate.BaseFee, blobBlocks, usage)
+		full := oracle.forecastBlobFees(head, estimate.BaseFee, blobBlocks, 1)
+		estimate.MaxFeePerBlobGas = full[len(full)-1]
+	}
+	return estimate, nil
+}
+
+// tierTip returns the tip of a speed tier, the median over the sampled blocks of
+// the tier's reward percentile. Empty blocks are ignored.
+func (oracle *Oracle) tierTip(rewards [][]*big.Int, tier int) *big.Int {
+	var tips []*big.Int
+	for _, reward := range rewards {
+		if reward == nil || reward[len(reward)-1].Sign() == 0 {
+			continue
+		}
+		tips = append(tips, reward[tier])
+	}
+	tip := new(big.Int)
+	if len(tips) > 0 {
+		slices.SortFunc(tips, func(a, b *big.Int) int { return a.Cmp(b) })
+		tip.Set(tips[len(tips)/2])
+	}
+	if tip.Cmp(oracle.ignorePrice) < 0 {
+		tip.Set(oracle.ignorePrice)
+	}
+	if tip.Cmp(oracle.maxPrice) > 0 {
+		tip.Set(oracle.maxPrice)
+	}
+	return tip
+}
+
+// inclusionDelay returns the expected number of blocks until a transaction paying
+// the given tip is included. A block would have included it if it had spare
+// room, or if the tip is at least the lowest one the block included.
+func inclusionDelay(tip *big.Int, rewards [][]*big.Int, gasUsedRatio []float64) uint64 {
+	var included uint64
+	for i, reward := range rewards {
+		if gasUsedRatio[i] < fullBlockRatio || reward == nil || tip.Cmp(reward[0]) >= 0 {
+			included++
+		}
+	}
+	if included == 0 {
+		return uint64(len(rewards)) + 1
+	}
+	return (uint64(len(rewards)) + included - 1) / included
+}
+
+// maxBaseFee returns the highest base fee possible after the given number of
+// blocks, each one raising it by at most 12.5%.
+func maxBaseFee(baseFee *big.Int, blocks uint64) *big.Int {
+	fee := new(big.Int).Set(baseFee)
+	for i := uint64(1); i < blocks; i++ {
+		fee.Mul(fee, big.NewInt(9))
+		fee.Div(fee, big.NewInt(8))
+	}
+	return fee
+}
+
+// forecastBlobFees returns the blob base fee of each of the next blocks after the
+// given head, if every block uses the given ratio of the maximum blob gas.
+func (oracle *Oracle) forecastBlobFees(head *types.Header, baseFee *big.Int, blocks uint64, usage float64) []*big.Int {
+	var (
+		config = oracle.backend.ChainConfig()
+		fees   = make([]*big.Int, 0, blocks)
+		parent = head
+	)
+	for i := uint64(0); i < blocks; i++ {
+		var (
+			time   = parent.Time + forecastSlotTime
+			excess = eip4844.CalcExcessBlobGas(config, parent, time)
+			used   = uint64(usage*float64(eip4844.MaxBlobsPerBlock(config, time))) * params.BlobTxBlobGasPerBlob
+		)
+		header := &types.Header{
+			Number:        new(big.Int).Add(parent.Number, big.NewInt(1)),
+			Time:          time,
+			BaseFee:       baseFee,
+			ExcessBlobGas: &excess,
+			BlobGasUsed:   &used,
+		}
+		fees = append(fees, eip4844.CalcBlobFee(config, header))
+		parent = header
+	}
+	return fees
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package gasprice
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestFeeEstimate(t *testing.T) {
+	for _, pending := range []bool{false, true} {
+		backend := newTestBackend(t, big.NewInt(16), big.NewInt(28), pending)
+		oracle := NewOracle(backend, Config{MaxHeaderHistory: 1000, MaxBlockHistory: 1000}, nil)
+
+		estimate, err := oracle.FeeEstimate(context.Background(), 4)
+		if err != nil {
+			t.Fatalf("pending %v: failed to estimate fees: %v", pending, err)
+		}
+		// The tips of the test chain rise by one gwei per block, with a single tip
+		// per block all tiers are the median of the 20 sampled blocks
+		head := backend.chain.CurrentBlock().Number.Uint64()
+		if pending {
+			head++
+		}
+		tip := new(big.Int).Mul(new(big.Int).SetUint64(head-10), big.NewInt(params.GWei))
+		for name, tier := range map[string]FeeSuggestion{"slow": estimate.Slow, "standard": estimate.Standard, "fast": estimate.Fast} {
+			if tier.MaxPriorityFeePerGas.Cmp(tip) != 0 {
+				t.Errorf("pending %v, %s: tip mismatch: have %v, want %v", pending, name, tier.MaxPriorityFeePerGas, tip)
+			}
+			// The blocks are not full, so any tip is included right away
+			if tier.Delay != 1 {
+				t.Errorf("pending %v, %s: delay mismatch: have %d, want 1", pending, name, tier.Delay)
+			}
+			if want := new(big.Int).Add(estimate.BaseFee, tip); tier.MaxFeePerGas.Cmp(want) != 0 {
+				t.Errorf("pending %v, %s: max fee mismatch: have %v, want %v", pending, name, tier.MaxFeePerGas, want)
+			}
+		}
+		if len(estimate.BlobBaseFee) != 4 {
+			t.Fatalf("pending %v: blob forecast length mismatch: have %d, want 4", pending, len(estimate.BlobBaseFee))
+		}
+		for i, fee := range estimate.BlobBaseFee {
+			if fee.Cmp(estimate.MaxFeePerBlobGas) > 0 {
+				t.Errorf("pending %v: blob fee %d above cap: %v > %v", pending, i, fee, estimate.MaxFeePerBlobGas)
+			}
+		}
+		backend.teardown()
+	}
+	// Forecasts beyond the limit must be rejected
+	backend := newTestBackend(t, big.NewInt(16), big.NewInt(28), false)
+	defer backend.teardown()
+
+	oracle := NewOracle(backend, Config{MaxHeaderHistory: 1000, MaxBlockHistory: 1000}, nil)
+	if _, err := oracle.FeeEstimate(context.Background(), MaxBlobForecast+1); err == nil {
+		t.Fatal("blob forecast beyond the limit accepted")
+	}
+}
+
+func TestMaxBaseFee(t *testing.T) {
+	if fee := maxBaseFee(big.NewInt(800), 1); fee.Int64() != 800 {
+		t.Errorf("next block base fee mismatch: have %v, want 800", fee)
+	}
+	if fee := maxBaseFee(big.NewInt(800), 3); fee.Int64() != 1012 {
+		t.Errorf("third block base fee mismatch: have %v, want 1012", fee)
+	}
+}
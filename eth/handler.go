@@ -84,6 +84,10 @@ type txPool interface {
 	// given transaction hash.
 	GetMetadata(hash common.Hash) *txpool.TxMetadata
 
+	// IsPrivate reports whether the transaction with the given hash was submitted
+	// privately, even if it already left the pool.
+	IsPrivate(hash common.Hash) bool
+
 	// Add should add the given transactions to the pool.
 	Add(txs []*types.Transaction, sync bool) []error
 
@@ -516,8 +520,9 @@ func (h *handler) Stop() {
 // already have the given transaction.
 func (h *handler) BroadcastTransactions(txs types.Transactions) {
 	var (
-		blobTxs  int // Number of blob transactions to announce only
-		largeTxs int // Number of large transactions to announce only
+		blobTxs    int // Number of blob transactions to announce only
+		largeTxs   int // Number of large transactions to announce only
+		privateTxs int // Number of private transactions to keep local
 
 		directCount int // Number of transactions sent directly to peers (duplicates included)
 		annCount    int // Number of transactions announced across all peers (duplicates included)
@@ -531,6 +536,10 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 	)
 
 	for _, tx := range txs {
+		if h.isPrivate(tx.Hash()) {
+			privateTxs++
+			continue
+		}
 		var directSet map[*ethPeer]struct{}
 		switch {
 		case tx.Type() == types.BlobTxType:
@@ -566,8 +575,14 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 		annCount += len(hashes)
 		peer.AsyncSendPooledTransactionHashes(hashes)
 	}
-	log.Trace("Distributed transactions", "plaintxs", len(txs)-blobTxs-largeTxs, "blobtxs", blobTxs, "largetxs", largeTxs,
-		"bcastpeers", len(txset), "bcastcount", directCount, "annpeers", len(annos), "anncount", annCount)
+	log.Trace("Distributed transactions", "plaintxs", len(txs)-blobTxs-largeTxs-privateTxs, "blobtxs", blobTxs, "largetxs", largeTxs,
+		"privatetxs", privateTxs, "bcastpeers", len(txset), "bcastcount", directCount, "annpeers", len(annos), "anncount", annCount)
+}
+
+// isPrivate reports whether a transaction was submitted privately, and must not
+// be propagated to peers.
+func (h *handler) isPrivate(hash common.Hash) bool {
+	return h.txpool.IsPrivate(hash)
 }
 
 // txBroadcastLoop announces new transactions to connected peers.
@@ -130,6 +130,12 @@ func (p *testTxPool) GetMetadata(hash common.Hash) *txpool.TxMetadata {
 	return nil
 }
 
+// IsPrivate reports whether a transaction was submitted privately, which is
+// never the case in the test pool.
+func (p *testTxPool) IsPrivate(hash common.Hash) bool {
+	return false
+}
+
 // Add appends a batch of transactions to the pool, and notifies any
 // listeners if the addition channel is non nil
 func (p *testTxPool) Add(txs []*types.Transaction, sync bool) []error {
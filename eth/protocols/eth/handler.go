@@ -252,13 +252,20 @@ var eth72 = map[uint64]msgHandler{
 
 // handleMessage is invoked whenever an inbound message is received from a remote
 // peer. The remote connection is torn down upon returning any error.
-func handleMessage(backend Backend, peer *Peer) error {
+func handleMessage(backend Backend, peer *Peer) (err error) {
 	// Read the next message from the remote peer, and ensure it's fully consumed
 	msg, err := peer.rw.ReadMsg()
 	if err != nil {
 		return err
 	}
 	defer msg.Discard()
+
+	// Any failure to handle a received message is a protocol violation
+	defer func() {
+		if err != nil {
+			peer.ReportViolation()
+		}
+	}()
 	if msg.Size > maxMessageSize {
 		return fmt.Errorf("%w: %v > %v", errMsgTooLarge, msg.Size, maxMessageSize)
 	}
@@ -624,11 +624,15 @@ func answerGetPooledTransactions(backend Backend, query GetPooledTransactionsReq
 		if bytes >= softResponseLimit {
 			break
 		}
-		// Retrieve the requested transaction, skipping if unknown to us
+		// Retrieve the requested transaction, skipping if unknown to us or
+		// if it was submitted privately
 		encoded := backend.TxPool().GetRLP(hash, version)
 		if len(encoded) == 0 {
 			continue
 		}
+		if meta := backend.TxPool().GetMetadata(hash); meta != nil && meta.Private {
+			continue
+		}
 		hashes = append(hashes, hash)
 		txs = append(txs, encoded)
 		bytes += len(encoded)
@@ -154,13 +154,20 @@ var snap2 = map[uint64]msgHandler{
 // HandleMessage is invoked whenever an inbound message is received from a
 // remote peer on the `snap` protocol. The remote connection is torn down upon
 // returning any error.
-func HandleMessage(backend Backend, peer *Peer) error {
+func HandleMessage(backend Backend, peer *Peer) (err error) {
 	// Read the next message from the remote peer, and ensure it's fully consumed
 	msg, err := peer.rw.ReadMsg()
 	if err != nil {
 		return err
 	}
 	defer msg.Discard()
+
+	// Any failure to handle a received message is a protocol violation
+	defer func() {
+		if err != nil {
+			peer.ReportViolation()
+		}
+	}()
 	if msg.Size > maxMessageSize {
 		return fmt.Errorf("%w: %v > %v", errMsgTooLarge, msg.Size, maxMessageSize)
 	}
@@ -0,0 +1,137 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/eth/txtracker"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+const (
+	// Interval between reputation updates.
+	reputationInterval = time.Minute
+	// Reputation credit per finalized transaction delivered by a peer, and the
+	// maximum inclusion credit of a peer per update.
+	inclusionCredit    = 0.5
+	maxInclusionCredit = 5
+	// Reputation credit of a peer per update for serving data at twice the average
+	// throughput, and the penalty for serving nothing.
+	throughputCredit = 0.05
+)
+
+// Callback type to get the relative data retrieval throughput of peers.
+type getThroughputsFunc func() map[string]float64
+
+// Callback type to adjust the reputation score of a peer.
+type adjustReputationFunc func(id enode.ID, delta float64)
+
+// reputationUpdater periodically credits peers for useful work, feeding their
+// reputation in the p2p server: peers earn credit for delivering transactions
+// which get finalized, and for serving data faster than the average peer, while
+// slow peers lose some.
+type reputationUpdater struct {
+	peerStatsFunc   getPeerStatsFunc
+	throughputsFunc getThroughputsFunc
+	adjustFunc      adjustReputationFunc
+
+	finalized map[string]uint64 // Finalization credits already accounted for
+
+	wg         sync.WaitGroup
+	shutdownCh chan struct{}
+}
+
+func newReputationUpdater(peerStatsFunc getPeerStatsFunc, throughputsFunc getThroughputsFunc, adjustFunc adjustReputationFunc) *reputationUpdater {
+	return &reputationUpdater{
+		peerStatsFunc:   peerStatsFunc,
+		throughputsFunc: throughputsFunc,
+		adjustFunc:      adjustFunc,
+		finalized:       make(map[string]uint64),
+		shutdownCh:      make(chan struct{}),
+	}
+}
+
+// Start the reputation updater.
+func (ru *reputationUpdater) Start() {
+	ru.wg.Add(1)
+	go ru.loop()
+}
+
+// Stop the reputation updater.
+func (ru *reputationUpdater) Stop() {
+	close(ru.shutdownCh)
+	ru.wg.Wait()
+}
+
+// loop is the main loop of the reputation updater.
+func (ru *reputationUpdater) loop() {
+	defer ru.wg.Done()
+
+	ticker := time.NewTicker(reputationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ru.update()
+		case <-ru.shutdownCh:
+			return
+		}
+	}
+}
+
+// update credits the peers for the work done since the last update.
+func (ru *reputationUpdater) update() {
+	credits := ru.inclusionCredits(ru.peerStatsFunc())
+	for id, rel := range ru.throughputsFunc() {
+		credits[id] += throughputCredit * max(-1, min(1, rel-1))
+	}
+	for id, credit := range credits {
+		if credit == 0 {
+			continue
+		}
+		if nodeID, err := enode.ParseID(id); err == nil {
+			ru.adjustFunc(nodeID, credit)
+		}
+	}
+}
+
+// inclusionCredits computes the credits of peers for their transactions which
+// got finalized since the last update.
+func (ru *reputationUpdater) inclusionCredits(stats map[string]txtracker.PeerStats) map[string]float64 {
+	credits := make(map[string]float64)
+	for id, s := range stats {
+		// The tracker resets the stats of reconnected peers
+		prev := ru.finalized[id]
+		if s.Finalized < prev {
+			prev = 0
+		}
+		if s.Finalized > prev {
+			credits[id] = min(maxInclusionCredit, inclusionCredit*float64(s.Finalized-prev))
+		}
+		ru.finalized[id] = s.Finalized
+	}
+	// Forget about the peers which are no longer tracked
+	for id := range ru.finalized {
+		if _, ok := stats[id]; !ok {
+			delete(ru.finalized, id)
+		}
+	}
+	return credits
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/eth/txtracker"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+func TestReputationUpdater(t *testing.T) {
+	var (
+		fast = enode.ID{1}
+		slow = enode.ID{2}
+
+		stats       map[string]txtracker.PeerStats
+		throughputs map[string]float64
+		adjusted    map[enode.ID]float64
+	)
+	ru := newReputationUpdater(
+		func() map[string]txtracker.PeerStats { return stats },
+		func() map[string]float64 { return throughputs },
+		func(id enode.ID, delta float64) { adjusted[id] += delta },
+	)
+	check := func(want map[enode.ID]float64) {
+		t.Helper()
+
+		adjusted = make(map[enode.ID]float64)
+		ru.update()
+		if len(adjusted) != len(want) {
+			t.Fatalf("adjusted peers mismatch: have %v, want %v", adjusted, want)
+		}
+		for id, delta := range want {
+			if math.Abs(adjusted[id]-delta) > 1e-9 {
+				t.Fatalf("credit mismatch of %v: have %v, want %v", id, adjusted[id], delta)
+			}
+		}
+	}
+	// Peers are credited for finalized transactions and relative throughput
+	stats = map[string]txtracker.PeerStats{fast.String(): {Finalized: 4}, slow.String(): {Finalized: 100}}
+	throughputs = map[string]float64{fast.String(): 3, slow.String(): 0.5}
+	check(map[enode.ID]float64{
+		fast: 4*inclusionCredit + throughputCredit,
+		slow: maxInclusionCredit - throughputCredit/2,
+	})
+	// Only newly finalized transactions earn credit
+	stats = map[string]txtracker.PeerStats{fast.String(): {Finalized: 5}, slow.String(): {Finalized: 100}}
+	throughputs = map[string]float64{fast.String(): 1}
+	check(map[enode.ID]float64{fast: inclusionCredit})
+
+	// A reconnected peer starts counting from zero
+	stats = map[string]txtracker.PeerStats{slow.String(): {Finalized: 2}}
+	throughputs = nil
+	check(map[enode.ID]float64{slow: 2 * inclusionCredit})
+}
@@ -28,7 +28,9 @@ func (h *handler) syncTransactions(p *eth.Peer) {
 	pending, _ := h.txpool.Pending(txpool.PendingFilter{BlobTxs: false})
 	for _, batch := range pending {
 		for _, tx := range batch {
-			hashes = append(hashes, tx.Hash)
+			if !h.isPrivate(tx.Hash) {
+				hashes = append(hashes, tx.Hash)
+			}
 		}
 	}
 	if len(hashes) == 0 {
@@ -566,6 +566,14 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 	if block.NumberU64() == 0 {
 		return nil, errors.New("genesis is not traceable")
 	}
+	// Serve the traces from the live tracer's store if possible
+	if traces := api.storedTraces(block, config); traces != nil {
+		results := make([]*txTraceResult, len(traces))
+		for i, tx := range block.Transactions() {
+			results[i] = &txTraceResult{TxHash: tx.Hash(), Result: traces[i]}
+		}
+		return results, nil
+	}
 	// Prepare base state
 	parent, err := api.blockByNumberAndHash(ctx, rpc.BlockNumber(block.NumberU64()-1), block.ParentHash())
 	if err != nil {
@@ -852,6 +860,9 @@ func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *
 	if err != nil {
 		return nil, err
 	}
+	if traces := api.storedTraces(block, config); index < uint64(len(traces)) {
+		return traces[index], nil
+	}
 	tx, vmctx, statedb, release, err := api.backend.StateAtTransaction(ctx, block, int(index))
 	if err != nil {
 		return nil, err
@@ -871,6 +882,61 @@ func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *
 	return api.traceTx(ctx, tx, msg, txctx, vmctx, statedb, config, nil)
 }
 
+// storedTraces returns the traces of all transactions in a block derived from
+// the call traces persisted by a live tracer. It returns nil unless the callTracer
+// was requested with its default settings, or a tracer whose results can be
+// derived from it like the flatCallTracer, since only those results are stored.
+func (api *API) storedTraces(block *types.Block, config *TraceConfig) []json.RawMessage {
+	if config == nil || config.Tracer == nil {
+		return nil
+	}
+	conv, ok := DefaultDirectory.CallConverter(*config.Tracer)
+	if !ok {
+		if *config.Tracer != "callTracer" {
+			return nil
+		}
+		if len(config.TracerConfig) > 0 {
+			var callConfig struct {
+				OnlyTopCall bool `json:"onlyTopCall"`
+				WithLog     bool `json:"withLog"`
+			}
+			if err := json.Unmarshal(config.TracerConfig, &callConfig); err != nil || callConfig.OnlyTopCall || callConfig.WithLog {
+				return nil
+			}
+		}
+	}
+	reader := LiveDirectory.CallTraceReader()
+	if reader == nil {
+		return nil
+	}
+	traces := reader.CallTraces(block.NumberU64(), block.Hash())
+	if len(traces) != len(block.Transactions()) {
+		return nil
+	}
+	if conv == nil {
+		return traces
+	}
+	var (
+		rules   = api.backend.ChainConfig().Rules(block.Number(), block.Difficulty().Sign() == 0, block.Time())
+		results = make([]json.RawMessage, len(traces))
+	)
+	for i, tx := range block.Transactions() {
+		txctx := &Context{
+			BlockHash:   block.Hash(),
+			BlockNumber: block.Number(),
+			TxIndex:     i,
+			TxHash:      tx.Hash(),
+		}
+		res, err := conv(traces[i], txctx, config.TracerConfig, rules)
+		if err != nil {
+			log.Debug("Failed to convert stored call trace", "tracer", *config.Tracer, "number", block.NumberU64(), "index", i, "err", err)
+			return nil
+		}
+		results[i] = res
+	}
+	return results
+}
+
 // TraceCall lets you trace a given eth_call. It collects the structured logs
 // created during the execution of EVM if the given transaction was added on
 // top of the provided block and returns them as a JSON object.
@@ -1033,6 +1099,10 @@ func APIs(backend Backend) []rpc.API {
 			Namespace: "debug",
 			Service:   NewAPI(backend),
 		},
+		{
+			Namespace: "trace",
+			Service:   NewTraceAPI(backend),
+		},
 	}
 }
 
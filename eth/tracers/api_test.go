@@ -1902,3 +1902,95 @@ func TestStandardTraceBadBlockToFile(t *testing.T) {
 		t.Fatal("want error for non-existent bad block, have none")
 	}
 }
+
+type testCallTraceReader map[common.Hash][]json.RawMessage
+
+func (r testCallTraceReader) CallTraces(number uint64, hash common.Hash) []json.RawMessage {
+	return r[hash]
+}
+
+func TestTraceFromCallTraceStore(t *testing.T) {
+	// Initialize test accounts
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	var txHash common.Hash
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &accounts[1].addr,
+			Value:    big.NewInt(1000),
+			Gas:      params.TxGas,
+			GasPrice: b.BaseFee(),
+		}), types.HomesteadSigner{}, accounts[0].key)
+		b.AddTx(tx)
+		txHash = tx.Hash()
+	})
+	defer backend.teardown()
+	api := NewAPI(backend)
+
+	block := backend.chain.GetBlockByNumber(1)
+	stored := json.RawMessage(`{"stored":true}`)
+	LiveDirectory.SetCallTraceReader(testCallTraceReader{block.Hash(): {stored}})
+	defer LiveDirectory.SetCallTraceReader(nil)
+
+	// The default callTracer is served from the store.
+	callTracer := "callTracer"
+	result, err := api.TraceTransaction(context.Background(), txHash, &TraceConfig{Tracer: &callTracer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result, stored) {
+		t.Fatalf("transaction trace not served from store: %s", result)
+	}
+	results, err := api.TraceBlockByNumber(context.Background(), 1, &TraceConfig{Tracer: &callTracer, TracerConfig: json.RawMessage(`{"withLog":false}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || !reflect.DeepEqual(results[0].Result, stored) || results[0].TxHash != txHash {
+		t.Fatalf("block trace not served from store")
+	}
+
+	// Other configurations are traced by re-executing the block.
+	result, err = api.TraceTransaction(context.Background(), txHash, &TraceConfig{Tracer: &callTracer, TracerConfig: json.RawMessage(`{"withLog":true}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(result, stored) {
+		t.Fatal("trace with logs served from store")
+	}
+	result, err = api.TraceTransaction(context.Background(), txHash, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(result, stored) {
+		t.Fatal("struct logger trace served from store")
+	}
+
+	// Tracers with a call trace converter are derived from the store.
+	DefaultDirectory.RegisterCallConverter("testConvertedTracer", func(callTrace json.RawMessage, ctx *Context, cfg json.RawMessage, rules params.Rules) (json.RawMessage, error) {
+		return json.RawMessage(fmt.Sprintf(`{"converted":%s,"tx":%q}`, callTrace, ctx.TxHash)), nil
+	})
+	defer delete(DefaultDirectory.conv, "testConvertedTracer")
+
+	converted := json.RawMessage(fmt.Sprintf(`{"converted":%s,"tx":%q}`, stored, txHash))
+	convertedTracer := "testConvertedTracer"
+	result, err = api.TraceTransaction(context.Background(), txHash, &TraceConfig{Tracer: &convertedTracer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result, converted) {
+		t.Fatalf("transaction trace not derived from store: %s", result)
+	}
+	results, err = api.TraceBlockByNumber(context.Background(), 1, &TraceConfig{Tracer: &convertedTracer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || !reflect.DeepEqual(results[0].Result, converted) {
+		t.Fatalf("block trace not derived from store")
+	}
+}
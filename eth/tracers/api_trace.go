@@ -0,0 +1,493 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// maxTraceFilterBlocks is the maximum number of blocks trace_filter will
+	// re-execute in a single request.
+	maxTraceFilterBlocks = 1000
+
+	// Trace types accepted by trace_replayBlockTransactions.
+	traceTypeTrace     = "trace"
+	traceTypeStateDiff = "stateDiff"
+	traceTypeVmTrace   = "vmTrace"
+)
+
+var (
+	flatCallTracerName   = "flatCallTracer"
+	flatCallTracerConfig = json.RawMessage(`{"convertParityErrors":true}`)
+)
+
+// TraceAPI is the collection of tracing APIs compatible with the trace_*
+// namespace of OpenEthereum (Parity). All traces are produced by re-executing
+// blocks with the flatCallTracer, state diffs with the prestateTracer in diff
+// mode. Block reward traces are not included.
+type TraceAPI struct {
+	api *API
+}
+
+// NewTraceAPI creates a new trace_* API instance.
+func NewTraceAPI(backend Backend) *TraceAPI {
+	return &TraceAPI{api: NewAPI(backend)}
+}
+
+// TraceFilterArgs are the arguments of trace_filter.
+type TraceFilterArgs struct {
+	FromBlock   *rpc.BlockNumber `json:"fromBlock"`
+	ToBlock     *rpc.BlockNumber `json:"toBlock"`
+	FromAddress []common.Address `json:"fromAddress"`
+	ToAddress   []common.Address `json:"toAddress"`
+	After       *uint64          `json:"after"`
+	Count       *uint64          `json:"count"`
+}
+
+// traceReplayResult is the result of replaying a single transaction with
+// trace_replayBlockTransactions.
+type traceReplayResult struct {
+	Output          hexutil.Bytes                        `json:"output"`
+	StateDiff       map[common.Address]*stateDiffAccount `json:"stateDiff"`
+	Trace           []json.RawMessage                    `json:"trace"`
+	TransactionHash common.Hash                          `json:"transactionHash"`
+	VmTrace         *struct{}                            `json:"vmTrace"`
+}
+
+// Block returns the call traces of all transactions in the given block.
+func (api *TraceAPI) Block(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]json.RawMessage, error) {
+	block, err := api.blockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return api.blockTraces(ctx, block)
+}
+
+// Transaction returns the call traces of the given transaction.
+func (api *TraceAPI) Transaction(ctx context.Context, hash common.Hash) ([]json.RawMessage, error) {
+	res, err := api.api.TraceTransaction(ctx, hash, &TraceConfig{Tracer: &flatCallTracerName, TracerConfig: flatCallTracerConfig})
+	if err != nil {
+		return nil, err
+	}
+	return decodeFlatTraces(res)
+}
+
+// ReplayBlockTransactions re-executes all transactions of the given block and
+// returns the requested trace types for each of them. Supported trace types are
+// "trace" and "stateDiff".
+func (api *TraceAPI) ReplayBlockTransactions(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, traceTypes []string) ([]*traceReplayResult, error) {
+	var wantTrace, wantStateDiff bool
+	for _, typ := range traceTypes {
+		switch typ {
+		case traceTypeTrace:
+			wantTrace = true
+		case traceTypeStateDiff:
+			wantStateDiff = true
+		case traceTypeVmTrace:
+			return nil, errors.New("vmTrace is not supported")
+		default:
+			return nil, fmt.Errorf("invalid trace type %q", typ)
+		}
+	}
+	block, err := api.blockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	// The call traces are always needed for the output of the transaction, the
+	// state diff is only collected if requested.
+	config := &TraceConfig{Tracer: &flatCallTracerName, TracerConfig: flatCallTracerConfig}
+	if wantStateDiff {
+		muxTracer := "muxTracer"
+		config = &TraceConfig{
+			Tracer:       &muxTracer,
+			TracerConfig: json.RawMessage(`{"flatCallTracer":{"convertParityErrors":true},"prestateTracer":{"diffMode":true}}`),
+		}
+	}
+	results, err := api.api.traceBlock(ctx, block, config)
+	if err != nil {
+		return nil, err
+	}
+	replays := make([]*traceReplayResult, len(results))
+	for i, res := range results {
+		if res.Error != "" {
+			return nil, fmt.Errorf("tracing transaction %#x failed: %s", res.TxHash, res.Error)
+		}
+		var (
+			callResult  = res.Result
+			stateResult json.RawMessage
+		)
+		if wantStateDiff {
+			var mux struct {
+				Calls json.RawMessage `json:"flatCallTracer"`
+				State json.RawMessage `json:"prestateTracer"`
+			}
+			if err := unmarshalResult(res.Result, &mux); err != nil {
+				return nil, err
+			}
+			callResult, stateResult = mux.Calls, mux.State
+		}
+		traces, err := decodeFlatTraces(callResult)
+		if err != nil {
+			return nil, err
+		}
+		replay := &traceReplayResult{
+			Output:          traceOutput(traces),
+			Trace:           []json.RawMessage{},
+			TransactionHash: res.TxHash,
+		}
+		if wantTrace {
+			for _, trace := range traces {
+				stripped, err := stripTraceLocation(trace)
+				if err != nil {
+					return nil, err
+				}
+				replay.Trace = append(replay.Trace, stripped)
+			}
+		}
+		if wantStateDiff {
+			if replay.StateDiff, err = decodeStateDiff(stateResult); err != nil {
+				return nil, err
+			}
+		}
+		replays[i] = replay
+	}
+	return replays, nil
+}
+
+// Filter returns the call traces in the given block range matching the sender
+// and recipient filters. A trace matches if its sender is in FromAddress and
+// its recipient is in ToAddress, where an empty list matches any address.
+func (api *TraceAPI) Filter(ctx context.Context, args TraceFilterArgs) ([]json.RawMessage, error) {
+	from, err := api.resolveBlockNumber(ctx, args.FromBlock)
+	if err != nil {
+		return nil, err
+	}
+	to, err := api.resolveBlockNumber(ctx, args.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, fmt.Errorf("invalid block range: fromBlock %d is after toBlock %d", from, to)
+	}
+	if to-from >= maxTraceFilterBlocks {
+		return nil, fmt.Errorf("block range too large: %d blocks, maximum is %d", to-from+1, maxTraceFilterBlocks)
+	}
+	var (
+		matches = []json.RawMessage{}
+		skip    uint64
+	)
+	if args.After != nil {
+		skip = *args.After
+	}
+	full := func() bool {
+		return args.Count != nil && uint64(len(matches)) >= *args.Count
+	}
+	for number := max(from, 1); number <= to && !full(); number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		block, err := api.api.blockByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return nil, err
+		}
+		traces, err := api.blockTraces(ctx, block)
+		if err != nil {
+			return nil, err
+		}
+		for _, trace := range traces {
+			ok, err := matchTrace(trace, args.FromAddress, args.ToAddress)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			if full() {
+				return matches, nil
+			}
+			matches = append(matches, trace)
+		}
+	}
+	return matches, nil
+}
+
+// blockTraces returns the flattened call traces of all transactions in a block.
+func (api *TraceAPI) blockTraces(ctx context.Context, block *types.Block) ([]json.RawMessage, error) {
+	results, err := api.api.traceBlock(ctx, block, &TraceConfig{Tracer: &flatCallTracerName, TracerConfig: flatCallTracerConfig})
+	if err != nil {
+		return nil, err
+	}
+	traces := []json.RawMessage{}
+	for _, res := range results {
+		if res.Error != "" {
+			return nil, fmt.Errorf("tracing transaction %#x failed: %s", res.TxHash, res.Error)
+		}
+		txTraces, err := decodeFlatTraces(res.Result)
+		if err != nil {
+			return nil, err
+		}
+		traces = append(traces, txTraces...)
+	}
+	return traces, nil
+}
+
+func (api *TraceAPI) blockByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.Block, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		return api.api.blockByHash(ctx, hash)
+	}
+	number, _ := blockNrOrHash.Number()
+	return api.api.blockByNumber(ctx, number)
+}
+
+// resolveBlockNumber converts a block number of a filter into an absolute one,
+// defaulting to the latest block.
+func (api *TraceAPI) resolveBlockNumber(ctx context.Context, number *rpc.BlockNumber) (uint64, error) {
+	if number != nil && *number >= 0 {
+		return uint64(*number), nil
+	}
+	n := rpc.LatestBlockNumber
+	if number != nil {
+		n = *number
+	}
+	header, err := api.api.backend.HeaderByNumber(ctx, n)
+	if err != nil {
+		return 0, err
+	}
+	if header == nil {
+		return 0, fmt.Errorf("block %s not found", n)
+	}
+	return header.Number.Uint64(), nil
+}
+
+// unmarshalResult decodes the result of a native tracer.
+func unmarshalResult(res interface{}, v interface{}) error {
+	raw, ok := res.(json.RawMessage)
+	if !ok {
+		return fmt.Errorf("unexpected tracer result type %T", res)
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// decodeFlatTraces splits the result of the flatCallTracer into its frames.
+func decodeFlatTraces(res interface{}) ([]json.RawMessage, error) {
+	var traces []json.RawMessage
+	if err := unmarshalResult(res, &traces); err != nil {
+		return nil, err
+	}
+	return traces, nil
+}
+
+// traceOutput returns the return data of a transaction, which is the output of
+// its outermost call frame, or the deployed code for contract creations.
+func traceOutput(traces []json.RawMessage) hexutil.Bytes {
+	if len(traces) == 0 {
+		return hexutil.Bytes{}
+	}
+	var frame struct {
+		Result *struct {
+			Code   hexutil.Bytes `json:"code"`
+			Output hexutil.Bytes `json:"output"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(traces[0], &frame); err != nil || frame.Result == nil {
+		return hexutil.Bytes{}
+	}
+	if frame.Result.Code != nil {
+		return frame.Result.Code
+	}
+	if frame.Result.Output != nil {
+		return frame.Result.Output
+	}
+	return hexutil.Bytes{}
+}
+
+// stripTraceLocation removes the block and transaction fields of a frame, which
+// trace_replayBlockTransactions doesn't report.
+func stripTraceLocation(trace json.RawMessage) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(trace, &fields); err != nil {
+		return nil, err
+	}
+	for _, key := range []string{"blockHash", "blockNumber", "transactionHash", "transactionPosition"} {
+		delete(fields, key)
+	}
+	return json.Marshal(fields)
+}
+
+// matchTrace reports whether the sender and recipient of a trace are contained
+// in the given address lists. For contract creations the recipient is the new
+// contract, for self-destructs the sender is the destructed contract and the
+// recipient the beneficiary.
+func matchTrace(trace json.RawMessage, fromAddrs, toAddrs []common.Address) (bool, error) {
+	if len(fromAddrs) == 0 && len(toAddrs) == 0 {
+		return true, nil
+	}
+	var frame struct {
+		Action struct {
+			From          *common.Address `json:"from"`
+			To            *common.Address `json:"to"`
+			Address       *common.Address `json:"address"`
+			RefundAddress *common.Address `json:"refundAddress"`
+		} `json:"action"`
+		Result *struct {
+			Address *common.Address `json:"address"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(trace, &frame); err != nil {
+		return false, err
+	}
+	from, to := frame.Action.From, frame.Action.To
+	if frame.Action.Address != nil {
+		from, to = frame.Action.Address, frame.Action.RefundAddress
+	} else if to == nil && frame.Result != nil {
+		to = frame.Result.Address
+	}
+	return matchAddress(from, fromAddrs) && matchAddress(to, toAddrs), nil
+}
+
+func matchAddress(addr *common.Address, filter []common.Address) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	return addr != nil && slices.Contains(filter, *addr)
+}
+
+// stateDiffAccount is the change of an account in the format of OpenEthereum.
+// Each field is either "=" if unchanged, {"+": value} if the account was
+// created, {"-": value} if it was deleted or {"*": {"from": a, "to": b}} if
+// the value was modified.
+type stateDiffAccount struct {
+	Balance interface{}                 `json:"balance"`
+	Code    interface{}                 `json:"code"`
+	Nonce   interface{}                 `json:"nonce"`
+	Storage map[common.Hash]interface{} `json:"storage"`
+}
+
+// prestateAccount is an account as reported by the prestateTracer.
+type prestateAccount struct {
+	Balance *hexutil.Big                `json:"balance"`
+	Code    *hexutil.Bytes              `json:"code"`
+	Nonce   *uint64                     `json:"nonce"`
+	Storage map[common.Hash]common.Hash `json:"storage"`
+}
+
+func (a *prestateAccount) balance() *hexutil.Big {
+	if a.Balance == nil {
+		return (*hexutil.Big)(new(big.Int))
+	}
+	return a.Balance
+}
+
+func (a *prestateAccount) code() hexutil.Bytes {
+	if a.Code == nil {
+		return hexutil.Bytes{}
+	}
+	return *a.Code
+}
+
+func (a *prestateAccount) nonce() hexutil.Uint64 {
+	if a.Nonce == nil {
+		return 0
+	}
+	return hexutil.Uint64(*a.Nonce)
+}
+
+func diffAdded(v interface{}) interface{}   { return map[string]interface{}{"+": v} }
+func diffRemoved(v interface{}) interface{} { return map[string]interface{}{"-": v} }
+func diffChanged(from, to interface{}) interface{} {
+	return map[string]interface{}{"*": map[string]interface{}{"from": from, "to": to}}
+}
+
+// decodeStateDiff converts the result of the prestateTracer in diff mode into
+// the state diff format of OpenEthereum.
+func decodeStateDiff(res json.RawMessage) (map[common.Address]*stateDiffAccount, error) {
+	var state struct {
+		Pre  map[common.Address]*prestateAccount `json:"pre"`
+		Post map[common.Address]*prestateAccount `json:"post"`
+	}
+	if err := json.Unmarshal(res, &state); err != nil {
+		return nil, err
+	}
+	diff := make(map[common.Address]*stateDiffAccount)
+	for addr, pre := range state.Pre {
+		entry := &stateDiffAccount{Storage: make(map[common.Hash]interface{})}
+		post, ok := state.Post[addr]
+		if !ok {
+			// The account was deleted.
+			entry.Balance = diffRemoved(pre.balance())
+			entry.Code = diffRemoved(pre.code())
+			entry.Nonce = diffRemoved(pre.nonce())
+			for slot, val := range pre.Storage {
+				entry.Storage[slot] = diffRemoved(val)
+			}
+			diff[addr] = entry
+			continue
+		}
+		// The post state only contains the modified fields.
+		entry.Balance, entry.Code, entry.Nonce = "=", "=", "="
+		if post.Balance != nil {
+			entry.Balance = diffChanged(pre.balance(), post.Balance)
+		}
+		if post.Code != nil {
+			entry.Code = diffChanged(pre.code(), post.Code)
+		}
+		if post.Nonce != nil {
+			entry.Nonce = diffChanged(pre.nonce(), post.nonce())
+		}
+		for slot, val := range pre.Storage {
+			entry.Storage[slot] = diffChanged(val, post.Storage[slot])
+		}
+		for slot, val := range post.Storage {
+			if _, ok := pre.Storage[slot]; !ok {
+				entry.Storage[slot] = diffChanged(common.Hash{}, val)
+			}
+		}
+		diff[addr] = entry
+	}
+	for addr, post := range state.Post {
+		if _, ok := state.Pre[addr]; ok {
+			continue
+		}
+		// The account didn't exist before the transaction.
+		entry := &stateDiffAccount{
+			Balance: diffAdded(post.balance()),
+			Code:    diffAdded(post.code()),
+			Nonce:   diffAdded(post.nonce()),
+			Storage: make(map[common.Hash]interface{}),
+		}
+		for slot, val := range post.Storage {
+			entry.Storage[slot] = diffAdded(val)
+		}
+		diff[addr] = entry
+	}
+	return diff, nil
+}
@@ -0,0 +1,298 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type testFlatTrace struct {
+	Action struct {
+		CallType string           `json:"callType"`
+		From     common.Address   `json:"from"`
+		To       common.Address   `json:"to"`
+		Value    *json.RawMessage `json:"value"`
+	} `json:"action"`
+	BlockNumber         uint64      `json:"blockNumber"`
+	TraceAddress        []int       `json:"traceAddress"`
+	TransactionHash     common.Hash `json:"transactionHash"`
+	TransactionPosition uint64      `json:"transactionPosition"`
+	Type                string      `json:"type"`
+}
+
+func decodeTestTraces(t *testing.T, raw []json.RawMessage) []testFlatTrace {
+	t.Helper()
+	traces := make([]testFlatTrace, len(raw))
+	for i, r := range raw {
+		if err := json.Unmarshal(r, &traces[i]); err != nil {
+			t.Fatalf("invalid trace %d: %v", i, err)
+		}
+	}
+	return traces
+}
+
+// newTraceTestBackend creates a chain of two blocks. The first one contains a
+// plain transfer and a call to a contract which writes a storage slot and forwards
+// the call value to the beneficiary, the second one contains a transfer back.
+func newTraceTestBackend(t *testing.T) (*testBackend, []Account, common.Address, common.Address, []common.Hash) {
+	var (
+		accounts    = newAccounts(2)
+		contract    = common.HexToAddress("0xc0ffee")
+		beneficiary = common.HexToAddress("0xbeef")
+		signer      = types.HomesteadSigner{}
+		txHashes    []common.Hash
+	)
+	// SSTORE(0, 1); CALL(gas, beneficiary, callvalue, 0, 0, 0, 0)
+	code := append([]byte{0x60, 0x01, 0x60, 0x00, 0x55, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x34, 0x73}, beneficiary.Bytes()...)
+	code = append(code, 0x5a, 0xf1, 0x00)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			accounts[1].addr: {Balance: big.NewInt(params.Ether)},
+			contract:         {Code: code},
+		},
+	}
+	backend := newTestBackend(t, 2, genesis, func(i int, b *core.BlockGen) {
+		send := func(from Account, nonce uint64, to common.Address, value int64, gas uint64) {
+			tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+				Nonce:    nonce,
+				To:       &to,
+				Value:    big.NewInt(value),
+				Gas:      gas,
+				GasPrice: b.BaseFee(),
+			}), signer, from.key)
+			b.AddTx(tx)
+			txHashes = append(txHashes, tx.Hash())
+		}
+		switch i {
+		case 0:
+			send(accounts[0], 0, accounts[1].addr, 1000, params.TxGas)
+			send(accounts[0], 1, contract, 500, 100000)
+		case 1:
+			send(accounts[1], 0, accounts[0].addr, 1000, params.TxGas)
+		}
+	})
+	return backend, accounts, contract, beneficiary, txHashes
+}
+
+func TestTraceAPIBlockAndTransaction(t *testing.T) {
+	t.Parallel()
+
+	backend, accounts, contract, beneficiary, txHashes := newTraceTestBackend(t)
+	defer backend.teardown()
+	api := NewTraceAPI(backend)
+
+	raw, err := api.Block(context.Background(), rpc.BlockNumberOrHashWithNumber(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	traces := decodeTestTraces(t, raw)
+	if len(traces) != 3 {
+		t.Fatalf("wrong number of traces: have %d, want 3", len(traces))
+	}
+	want := []struct {
+		from, to     common.Address
+		traceAddress []int
+		position     uint64
+		txHash       common.Hash
+	}{
+		{accounts[0].addr, accounts[1].addr, []int{}, 0, txHashes[0]},
+		{accounts[0].addr, contract, []int{}, 1, txHashes[1]},
+		{contract, beneficiary, []int{0}, 1, txHashes[1]},
+	}
+	for i, w := range want {
+		have := traces[i]
+		if have.Type != "call" || have.Action.From != w.from || have.Action.To != w.to {
+			t.Errorf("trace %d: wrong action %+v", i, have.Action)
+		}
+		if !reflect.DeepEqual(have.TraceAddress, w.traceAddress) {
+			t.Errorf("trace %d: wrong trace address %v, want %v", i, have.TraceAddress, w.traceAddress)
+		}
+		if have.BlockNumber != 1 || have.TransactionPosition != w.position || have.TransactionHash != w.txHash {
+			t.Errorf("trace %d: wrong location %d/%d/%x", i, have.BlockNumber, have.TransactionPosition, have.TransactionHash)
+		}
+	}
+
+	// Tracing a transaction should return the traces of the transaction only.
+	raw, err = api.Transaction(context.Background(), txHashes[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decodeTestTraces(t, raw), traces[1:]) {
+		t.Fatalf("transaction traces differ from block traces")
+	}
+
+	if _, err := api.Block(context.Background(), rpc.BlockNumberOrHashWithNumber(0)); err == nil {
+		t.Fatal("expected error tracing genesis")
+	}
+}
+
+func TestTraceAPIFilter(t *testing.T) {
+	t.Parallel()
+
+	backend, accounts, contract, beneficiary, txHashes := newTraceTestBackend(t)
+	defer backend.teardown()
+	api := NewTraceAPI(backend)
+
+	var (
+		one      = rpc.BlockNumber(1)
+		two      = rpc.BlockNumber(2)
+		genesis  = rpc.BlockNumber(0)
+		uintPtr  = func(n uint64) *uint64 { return &n }
+		distance = rpc.BlockNumber(maxTraceFilterBlocks)
+	)
+	tests := []struct {
+		args TraceFilterArgs
+		want []common.Hash // transaction hashes of the matching traces
+	}{
+		{
+			args: TraceFilterArgs{FromBlock: &genesis},
+			want: []common.Hash{txHashes[0], txHashes[1], txHashes[1], txHashes[2]},
+		},
+		{
+			args: TraceFilterArgs{FromBlock: &one, ToBlock: &two, FromAddress: []common.Address{accounts[0].addr}},
+			want: []common.Hash{txHashes[0], txHashes[1]},
+		},
+		{
+			args: TraceFilterArgs{FromBlock: &one, ToBlock: &two, ToAddress: []common.Address{beneficiary}},
+			want: []common.Hash{txHashes[1]},
+		},
+		{
+			args: TraceFilterArgs{FromBlock: &one, ToBlock: &two, FromAddress: []common.Address{contract}, ToAddress: []common.Address{accounts[0].addr}},
+			want: []common.Hash{},
+		},
+		{
+			args: TraceFilterArgs{FromBlock: &two, ToAddress: []common.Address{accounts[0].addr}},
+			want: []common.Hash{txHashes[2]},
+		},
+		{
+			args: TraceFilterArgs{FromBlock: &one, ToBlock: &two, After: uintPtr(1), Count: uintPtr(2)},
+			want: []common.Hash{txHashes[1], txHashes[1]},
+		},
+		{
+			args: TraceFilterArgs{FromBlock: &one, ToBlock: &two, Count: uintPtr(1)},
+			want: []common.Hash{txHashes[0]},
+		},
+		{
+			args: TraceFilterArgs{FromBlock: &one, ToBlock: &two, Count: uintPtr(0)},
+			want: []common.Hash{},
+		},
+	}
+	for i, test := range tests {
+		raw, err := api.Filter(context.Background(), test.args)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		have := []common.Hash{}
+		for _, trace := range decodeTestTraces(t, raw) {
+			have = append(have, trace.TransactionHash)
+		}
+		if !reflect.DeepEqual(have, test.want) {
+			t.Errorf("test %d: wrong traces %x, want %x", i, have, test.want)
+		}
+	}
+
+	// Invalid ranges should be rejected.
+	if _, err := api.Filter(context.Background(), TraceFilterArgs{FromBlock: &two, ToBlock: &one}); err == nil {
+		t.Error("expected error for reversed range")
+	}
+	if _, err := api.Filter(context.Background(), TraceFilterArgs{FromBlock: &genesis, ToBlock: &distance}); err == nil {
+		t.Error("expected error for range exceeding the limit")
+	}
+}
+
+func TestTraceAPIReplayBlockTransactions(t *testing.T) {
+	t.Parallel()
+
+	backend, accounts, contract, beneficiary, txHashes := newTraceTestBackend(t)
+	defer backend.teardown()
+	api := NewTraceAPI(backend)
+
+	replays, err := api.ReplayBlockTransactions(context.Background(), rpc.BlockNumberOrHashWithNumber(1), []string{"trace", "stateDiff"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replays) != 2 {
+		t.Fatalf("wrong number of results: %d", len(replays))
+	}
+	replay := replays[1]
+	if replay.TransactionHash != txHashes[1] || len(replay.Trace) != 2 {
+		t.Fatalf("wrong replay result: hash %x, %d traces", replay.TransactionHash, len(replay.Trace))
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(replay.Trace[0], &fields); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"blockHash", "blockNumber", "transactionHash", "transactionPosition"} {
+		if _, ok := fields[key]; ok {
+			t.Errorf("replayed trace contains %q", key)
+		}
+	}
+
+	// Check the state diff of the contract call.
+	checkDiff := func(addr common.Address, field string, want string) {
+		t.Helper()
+		account := replay.StateDiff[addr]
+		if account == nil {
+			t.Fatalf("no state diff for %x", addr)
+		}
+		var value interface{}
+		switch field {
+		case "balance":
+			value = account.Balance
+		case "nonce":
+			value = account.Nonce
+		case "code":
+			value = account.Code
+		default:
+			value = account.Storage[common.HexToHash(field)]
+		}
+		have, _ := json.Marshal(value)
+		if string(have) != want {
+			t.Errorf("wrong %s diff of %x: have %s, want %s", field, addr, have, want)
+		}
+	}
+	checkDiff(accounts[0].addr, "nonce", `{"*":{"from":"0x1","to":"0x2"}}`)
+	checkDiff(accounts[0].addr, "code", `"="`)
+	checkDiff(contract, "balance", `"="`)
+	checkDiff(contract, "0x0", `{"*":{"from":"0x0000000000000000000000000000000000000000000000000000000000000000","to":"0x0000000000000000000000000000000000000000000000000000000000000001"}}`)
+	checkDiff(beneficiary, "balance", `{"+":"0x1f4"}`)
+	checkDiff(beneficiary, "nonce", `{"+":"0x0"}`)
+
+	// Without stateDiff, only the traces should be returned.
+	replays, err = api.ReplayBlockTransactions(context.Background(), rpc.BlockNumberOrHashWithNumber(1), []string{"trace"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replays[1].StateDiff != nil || len(replays[1].Trace) != 2 {
+		t.Fatalf("wrong replay result without state diff")
+	}
+	if _, err := api.ReplayBlockTransactions(context.Background(), rpc.BlockNumberOrHashWithNumber(1), []string{"vmTrace"}); err == nil {
+		t.Fatal("expected error for vmTrace")
+	}
+}
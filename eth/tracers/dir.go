@@ -48,6 +48,10 @@ type Tracer struct {
 type ctorFn func(*Context, json.RawMessage, *params.ChainConfig) (*Tracer, error)
 type jsCtorFn func(string, *Context, json.RawMessage, *params.ChainConfig) (*Tracer, error)
 
+// callConvFn derives the result of a tracer from the callTracer result of the
+// same transaction, executed under the given rules.
+type callConvFn func(callTrace json.RawMessage, ctx *Context, cfg json.RawMessage, rules params.Rules) (json.RawMessage, error)
+
 type elem struct {
 	ctor ctorFn
 	isJS bool
@@ -62,6 +66,7 @@ var DefaultDirectory = directory{elems: make(map[string]elem)}
 type directory struct {
 	elems  map[string]elem
 	jsEval jsCtorFn
+	conv   map[string]callConvFn
 }
 
 // Register registers a method as a lookup for tracers, meaning that
@@ -70,6 +75,15 @@ func (d *directory) Register(name string, f ctorFn, isJS bool) {
 	d.elems[name] = elem{ctor: f, isJS: isJS}
 }
 
+// RegisterCallConverter registers a method deriving the results of the named
+// tracer from callTracer results, allowing stored call traces to serve it.
+func (d *directory) RegisterCallConverter(name string, f callConvFn) {
+	if d.conv == nil {
+		d.conv = make(map[string]callConvFn)
+	}
+	d.conv[name] = f
+}
+
 // RegisterJSEval registers a tracer that is able to parse
 // dynamic user-provided JS code.
 func (d *directory) RegisterJSEval(f jsCtorFn) {
@@ -100,3 +114,10 @@ func (d *directory) IsJS(name string) bool {
 	// JS eval will execute JS code
 	return true
 }
+
+// CallConverter returns the method deriving the results of the named tracer
+// from callTracer results, if any.
+func (d *directory) CallConverter(name string) (callConvFn, bool) {
+	f, ok := d.conv[name]
+	return f, ok
+}
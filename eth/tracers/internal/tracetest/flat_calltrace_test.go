@@ -174,6 +174,79 @@ func testFlatCallTracer(tracerName string, dirPath string, t *testing.T) {
 	}
 }
 
+// Iterates over all the input-output datasets in the tracer parity test harness and
+// checks that deriving the flat traces from the callTracer results matches them.
+func TestFlatCallTracerFromCallTrace(t *testing.T) {
+	dirPath := "call_tracer_flat"
+	files, err := os.ReadDir(filepath.Join("testdata", dirPath))
+	if err != nil {
+		t.Fatalf("failed to retrieve tracer test suite: %v", err)
+	}
+	conv, ok := tracers.DefaultDirectory.CallConverter("flatCallTracer")
+	if !ok {
+		t.Fatal("no call trace converter for the flatCallTracer")
+	}
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		t.Run(camel(strings.TrimSuffix(file.Name(), ".json")), func(t *testing.T) {
+			t.Parallel()
+
+			blob, err := os.ReadFile(filepath.Join("testdata", dirPath, file.Name()))
+			if err != nil {
+				t.Fatalf("failed to read testcase: %v", err)
+			}
+			test := new(flatCallTracerTest)
+			if err := json.Unmarshal(blob, test); err != nil {
+				t.Fatalf("failed to parse testcase: %v", err)
+			}
+			tx := new(types.Transaction)
+			if err := rlp.DecodeBytes(common.FromHex(test.Input), tx); err != nil {
+				t.Fatalf("failed to parse testcase input: %v", err)
+			}
+			signer := types.MakeSigner(test.Genesis.Config, new(big.Int).SetUint64(uint64(test.Context.Number)), uint64(test.Context.Time))
+			context := test.Context.toBlockContext(test.Genesis)
+			state := tests.MakePreState(rawdb.NewMemoryDatabase(), test.Genesis.Alloc, false, rawdb.HashScheme)
+			defer state.Close()
+
+			// Trace the transaction with the callTracer and derive the flat trace
+			tracer, err := tracers.DefaultDirectory.New("callTracer", new(tracers.Context), nil, test.Genesis.Config)
+			if err != nil {
+				t.Fatalf("failed to create call tracer: %v", err)
+			}
+			msg, err := core.TransactionToMessage(tx, signer, context.BaseFee)
+			if err != nil {
+				t.Fatalf("failed to prepare transaction for tracing: %v", err)
+			}
+			evm := vm.NewEVM(context, state.StateDB, test.Genesis.Config, vm.Config{Tracer: tracer.Hooks})
+			tracer.OnTxStart(evm.GetVMContext(), tx, msg.From)
+			vmRet, err := core.ApplyMessage(evm, msg, nil)
+			if err != nil {
+				t.Fatalf("failed to execute transaction: %v", err)
+			}
+			tracer.OnTxEnd(&types.Receipt{GasUsed: vmRet.UsedGas}, nil)
+
+			callTrace, err := tracer.GetResult()
+			if err != nil {
+				t.Fatalf("failed to retrieve trace result: %v", err)
+			}
+			rules := test.Genesis.Config.Rules(context.BlockNumber, context.Random != nil, context.Time)
+			res, err := conv(callTrace, new(tracers.Context), test.TracerConfig, rules)
+			if err != nil {
+				t.Fatalf("failed to derive flat trace: %v", err)
+			}
+			ret := make([]flatCallTrace, 0)
+			if err := json.Unmarshal(res, &ret); err != nil {
+				t.Fatalf("failed to unmarshal trace result: %v", err)
+			}
+			if !jsonEqualFlat(ret, test.Result) {
+				t.Fatalf("trace mismatch: \nhave %+v\nwant %+v", ret, test.Result)
+			}
+		})
+	}
+}
+
 // jsonEqualFlat is similar to reflect.DeepEqual, but does a 'bounce' via json prior to
 // comparison
 func jsonEqualFlat(x, y interface{}) bool {
@@ -19,7 +19,9 @@ package tracers
 import (
 	"encoding/json"
 	"errors"
+	"sync"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/tracing"
 )
 
@@ -29,8 +31,20 @@ type ctorFunc func(config json.RawMessage) (*tracing.Hooks, error)
 // during normal block import operations.
 var LiveDirectory = liveDirectory{elems: make(map[string]ctorFunc)}
 
+// CallTraceReader is implemented by live tracers which persist the call traces
+// of imported blocks. If one is set, the tracing API serves callTracer requests
+// from it instead of re-executing the block.
+type CallTraceReader interface {
+	// CallTraces returns the callTracer results of all transactions in the block
+	// with the given number and hash, or nil if the block isn't available.
+	CallTraces(number uint64, hash common.Hash) []json.RawMessage
+}
+
 type liveDirectory struct {
 	elems map[string]ctorFunc
+
+	lock   sync.RWMutex
+	traces CallTraceReader
 }
 
 // Register registers a tracer constructor by name.
@@ -48,3 +62,17 @@ func (d *liveDirectory) New(name string, config json.RawMessage) (*tracing.Hooks
 	}
 	return nil, errors.New("not found")
 }
+
+// SetCallTraceReader sets the store of persisted call traces, nil removes it.
+func (d *liveDirectory) SetCallTraceReader(r CallTraceReader) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.traces = r
+}
+
+// CallTraceReader returns the store of persisted call traces, if any.
+func (d *liveDirectory) CallTraceReader() CallTraceReader {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.traces
+}
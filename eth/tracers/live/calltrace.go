@@ -0,0 +1,334 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package live
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/pebble"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func init() {
+	tracers.LiveDirectory.Register("calltrace", newCallTraceIndexer)
+}
+
+// callTraceFrame is the compact form of a callTracer frame persisted by the
+// call trace indexer. Its JSON encoding matches the output of the callTracer.
+type callTraceFrame struct {
+	Type         byte
+	From         common.Address
+	To           *common.Address `rlp:"nil"`
+	Gas          uint64
+	GasUsed      uint64
+	Input        []byte
+	Output       []byte
+	Error        string
+	RevertReason string
+	Value        *big.Int
+	Calls        []*callTraceFrame
+}
+
+type callTraceFrameJSON struct {
+	From         common.Address    `json:"from"`
+	Gas          hexutil.Uint64    `json:"gas"`
+	GasUsed      hexutil.Uint64    `json:"gasUsed"`
+	To           *common.Address   `json:"to,omitempty"`
+	Input        hexutil.Bytes     `json:"input"`
+	Output       hexutil.Bytes     `json:"output,omitempty"`
+	Error        string            `json:"error,omitempty"`
+	RevertReason string            `json:"revertReason,omitempty"`
+	Calls        []*callTraceFrame `json:"calls,omitempty"`
+	Value        *hexutil.Big      `json:"value,omitempty"`
+	Type         string            `json:"type"`
+}
+
+// MarshalJSON marshals as JSON.
+func (f *callTraceFrame) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&callTraceFrameJSON{
+		From:         f.From,
+		Gas:          hexutil.Uint64(f.Gas),
+		GasUsed:      hexutil.Uint64(f.GasUsed),
+		To:           f.To,
+		Input:        f.Input,
+		Output:       f.Output,
+		Error:        f.Error,
+		RevertReason: f.RevertReason,
+		Calls:        f.Calls,
+		Value:        (*hexutil.Big)(f.Value),
+		Type:         vm.OpCode(f.Type).String(),
+	})
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (f *callTraceFrame) UnmarshalJSON(input []byte) error {
+	var dec callTraceFrameJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*f = callTraceFrame{
+		Type:         byte(vm.StringToOp(dec.Type)),
+		From:         dec.From,
+		To:           dec.To,
+		Gas:          uint64(dec.Gas),
+		GasUsed:      uint64(dec.GasUsed),
+		Input:        dec.Input,
+		Output:       dec.Output,
+		Error:        dec.Error,
+		RevertReason: dec.RevertReason,
+		Value:        (*big.Int)(dec.Value),
+		Calls:        dec.Calls,
+	}
+	return nil
+}
+
+// normalize restores the fields which can't be distinguished in RLP. All frames
+// except static calls carry a value, even if it is zero.
+func (f *callTraceFrame) normalize() {
+	if vm.OpCode(f.Type) == vm.STATICCALL {
+		f.Value = nil
+	} else if f.Value == nil {
+		f.Value = new(big.Int)
+	}
+	if f.Input == nil {
+		f.Input = []byte{}
+	}
+	for _, call := range f.Calls {
+		call.normalize()
+	}
+}
+
+// callTraceIndexer is a live tracer which runs the callTracer on every imported
+// transaction and persists the results per block, so that trace requests can be
+// served without re-executing the block.
+//
+// Traces are keyed by block number and hash. Lookups are always done with the
+// canonical hash, which makes the store safe across reorgs: traces of blocks
+// that were reorged out are simply never read again, and are removed once they
+// fall out of the retention window.
+type callTraceIndexer struct {
+	db          ethdb.KeyValueStore
+	window      uint64
+	pruned      uint64 // traces of all blocks below this number are deleted
+	chainConfig *params.ChainConfig
+
+	block  *types.Block
+	tracer *tracers.Tracer   // callTracer of the current transaction
+	traces []*callTraceFrame // results of the executed transactions of the block
+	failed bool              // set if any transaction of the block couldn't be traced
+}
+
+type callTraceIndexerConfig struct {
+	Path   string `json:"path"`   // Path to the directory where the trace database will be stored
+	Window uint64 `json:"window"` // Window is the number of recent blocks to keep traces for. Zero keeps all blocks.
+}
+
+func newCallTraceIndexer(cfg json.RawMessage) (*tracing.Hooks, error) {
+	var config callTraceIndexerConfig
+	if err := json.Unmarshal(cfg, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %v", err)
+	}
+	if config.Path == "" {
+		return nil, errors.New("calltrace tracer database path is required")
+	}
+	db, err := pebble.New(config.Path, 16, 16, "eth/tracers/calltrace/", false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace database: %v", err)
+	}
+	t := &callTraceIndexer{db: db, window: config.Window}
+	tracers.LiveDirectory.SetCallTraceReader(t)
+
+	return &tracing.Hooks{
+		OnBlockchainInit: t.onBlockchainInit,
+		OnBlockStart:     t.onBlockStart,
+		OnBlockEnd:       t.onBlockEnd,
+		OnTxStart:        t.onTxStart,
+		OnTxEnd:          t.onTxEnd,
+		OnEnter:          t.onEnter,
+		OnExit:           t.onExit,
+		OnClose:          t.onClose,
+	}, nil
+}
+
+// callTraceKey = blockNumber (uint64 big endian) + blockHash
+func callTraceKey(number uint64, hash common.Hash) []byte {
+	return append(callTraceNumberKey(number), hash.Bytes()...)
+}
+
+func callTraceNumberKey(number uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, number)
+}
+
+func (t *callTraceIndexer) onBlockchainInit(chainConfig *params.ChainConfig) {
+	t.chainConfig = chainConfig
+}
+
+func (t *callTraceIndexer) onBlockStart(ev tracing.BlockEvent) {
+	t.block = ev.Block
+	t.tracer = nil
+	t.traces = make([]*callTraceFrame, 0, len(ev.Block.Transactions()))
+	t.failed = false
+}
+
+func (t *callTraceIndexer) onTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	if t.block == nil || t.failed {
+		return
+	}
+	ctx := &tracers.Context{
+		BlockHash:   t.block.Hash(),
+		BlockNumber: t.block.Number(),
+		TxIndex:     len(t.traces),
+		TxHash:      tx.Hash(),
+	}
+	tracer, err := tracers.DefaultDirectory.New("callTracer", ctx, nil, t.chainConfig)
+	if err != nil {
+		log.Warn("Failed to create call tracer", "err", err)
+		t.failed = true
+		return
+	}
+	t.tracer = tracer
+	if tracer.OnTxStart != nil {
+		tracer.OnTxStart(env, tx, from)
+	}
+}
+
+func (t *callTraceIndexer) onEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	// System calls happen outside of transactions and aren't traced.
+	if t.tracer != nil && t.tracer.OnEnter != nil {
+		t.tracer.OnEnter(depth, typ, from, to, input, gas, value)
+	}
+}
+
+func (t *callTraceIndexer) onExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.tracer != nil && t.tracer.OnExit != nil {
+		t.tracer.OnExit(depth, output, gasUsed, err, reverted)
+	}
+}
+
+func (t *callTraceIndexer) onTxEnd(receipt *types.Receipt, err error) {
+	tracer := t.tracer
+	if tracer == nil {
+		return
+	}
+	t.tracer = nil
+	if tracer.OnTxEnd != nil {
+		tracer.OnTxEnd(receipt, err)
+	}
+	if err != nil {
+		t.failed = true
+		return
+	}
+	res, err := tracer.GetResult()
+	if err != nil {
+		t.failed = true
+		return
+	}
+	frame := new(callTraceFrame)
+	if err := json.Unmarshal(res, frame); err != nil {
+		log.Warn("Failed to decode call trace", "err", err)
+		t.failed = true
+		return
+	}
+	t.traces = append(t.traces, frame)
+}
+
+func (t *callTraceIndexer) onBlockEnd(err error) {
+	block := t.block
+	t.block, t.tracer = nil, nil
+	if block == nil || err != nil || t.failed || len(t.traces) != len(block.Transactions()) {
+		return
+	}
+	enc, err := rlp.EncodeToBytes(t.traces)
+	if err != nil {
+		log.Warn("Failed to encode call traces", "number", block.NumberU64(), "err", err)
+		return
+	}
+	number := block.NumberU64()
+	if err := t.db.Put(callTraceKey(number, block.Hash()), enc); err != nil {
+		log.Warn("Failed to store call traces", "number", number, "err", err)
+		return
+	}
+	// Blocks of a reorg may be stored below the already pruned height, make sure
+	// they are pruned again once they expire.
+	if number < t.pruned {
+		t.pruned = number
+	}
+	if t.window > 0 && number >= t.window {
+		t.prune(number - t.window + 1)
+	}
+}
+
+// prune deletes the traces of all blocks below the given number. Only the range
+// that expired since the last prune is deleted.
+func (t *callTraceIndexer) prune(limit uint64) {
+	if limit <= t.pruned {
+		return
+	}
+	for {
+		err := t.db.DeleteRange(callTraceNumberKey(t.pruned), callTraceNumberKey(limit))
+		if !errors.Is(err, ethdb.ErrTooManyKeys) {
+			if err != nil {
+				log.Warn("Failed to prune call traces", "from", t.pruned, "limit", limit, "err", err)
+				return
+			}
+			t.pruned = limit
+			return
+		}
+	}
+}
+
+// CallTraces implements tracers.CallTraceReader.
+func (t *callTraceIndexer) CallTraces(number uint64, hash common.Hash) []json.RawMessage {
+	enc, err := t.db.Get(callTraceKey(number, hash))
+	if err != nil || len(enc) == 0 {
+		return nil
+	}
+	var frames []*callTraceFrame
+	if err := rlp.DecodeBytes(enc, &frames); err != nil {
+		log.Warn("Failed to decode stored call traces", "number", number, "hash", hash, "err", err)
+		return nil
+	}
+	results := make([]json.RawMessage, len(frames))
+	for i, frame := range frames {
+		frame.normalize()
+		if results[i], err = json.Marshal(frame); err != nil {
+			return nil
+		}
+	}
+	return results
+}
+
+func (t *callTraceIndexer) onClose() {
+	tracers.LiveDirectory.SetCallTraceReader(nil)
+	if err := t.db.Close(); err != nil {
+		log.Warn("Failed to close call trace database", "err", err)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package live
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestCallTraceFrameEncoding(t *testing.T) {
+	frames := []string{
+		// Plain call with a zero value
+		`{"from":"0x0100000000000000000000000000000000000000","gas":"0x5208","gasUsed":"0x5208","to":"0x0200000000000000000000000000000000000000","input":"0x","value":"0x0","type":"CALL"}`,
+		// Nested static call without value and reverted call with reason
+		`{"from":"0x0100000000000000000000000000000000000000","gas":"0x186a0","gasUsed":"0x6d60","to":"0x0200000000000000000000000000000000000000","input":"0x1234","output":"0x08c379a0","error":"execution reverted","revertReason":"boom","calls":[{"from":"0x0200000000000000000000000000000000000000","gas":"0x1000","gasUsed":"0x10","to":"0x0300000000000000000000000000000000000000","input":"0x","output":"0x01","type":"STATICCALL"}],"value":"0x1","type":"CALL"}`,
+		// Failed contract creation
+		`{"from":"0x0100000000000000000000000000000000000000","gas":"0x186a0","gasUsed":"0x186a0","input":"0x6000","error":"out of gas","value":"0x0","type":"CREATE"}`,
+	}
+	for i, want := range frames {
+		var frame callTraceFrame
+		if err := json.Unmarshal([]byte(want), &frame); err != nil {
+			t.Fatalf("frame %d: %v", i, err)
+		}
+		enc, err := rlp.EncodeToBytes(&frame)
+		if err != nil {
+			t.Fatalf("frame %d: %v", i, err)
+		}
+		var dec callTraceFrame
+		if err := rlp.DecodeBytes(enc, &dec); err != nil {
+			t.Fatalf("frame %d: %v", i, err)
+		}
+		dec.normalize()
+		have, _ := json.Marshal(&dec)
+		if string(have) != want {
+			t.Errorf("frame %d: wrong encoding\nhave %s\nwant %s", i, have, want)
+		}
+	}
+}
+
+func TestCallTraceIndexer(t *testing.T) {
+	var (
+		key, _      = crypto.GenerateKey()
+		sender      = crypto.PubkeyToAddress(key.PublicKey)
+		contract    = common.HexToAddress("0xc0ffee")
+		beneficiary = common.HexToAddress("0xbeef")
+		signer      = types.HomesteadSigner{}
+	)
+	// CALL(gas, beneficiary, callvalue, 0, 0, 0, 0)
+	code := append([]byte{0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x34, 0x73}, beneficiary.Bytes()...)
+	code = append(code, 0x5a, 0xf1, 0x00)
+	gspec := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			sender:   {Balance: big.NewInt(params.Ether)},
+			contract: {Code: code},
+		},
+	}
+	// Each block calls the contract with a different value, the fork uses
+	// different values than the main chain.
+	generate := func(offset int64) func(i int, b *core.BlockGen) {
+		return func(i int, b *core.BlockGen) {
+			tx, _ := types.SignTx(types.NewTransaction(b.TxNonce(sender), contract, big.NewInt(offset+int64(i)), 100000, b.BaseFee(), nil), signer, key)
+			b.AddTx(tx)
+		}
+	}
+	engine := ethash.NewFaker()
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, engine, 4, generate(1))
+	_, fork, _ := core.GenerateChainWithGenesis(gspec, engine, 6, generate(100))
+
+	hooks, err := tracers.LiveDirectory.New("calltrace", json.RawMessage(fmt.Sprintf(`{"path":%q,"window":3}`, t.TempDir())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := core.DefaultConfig()
+	options.VmConfig = vm.Config{Tracer: hooks}
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer chain.Stop()
+
+	reader := tracers.LiveDirectory.CallTraceReader()
+	if reader == nil {
+		t.Fatal("call trace reader not registered")
+	}
+	checkTraces := func(block *types.Block, value int64) {
+		t.Helper()
+		traces := reader.CallTraces(block.NumberU64(), block.Hash())
+		if len(traces) != 1 {
+			t.Fatalf("block %d: wrong number of traces %d", block.NumberU64(), len(traces))
+		}
+		var frame callTraceFrame
+		if err := json.Unmarshal(traces[0], &frame); err != nil {
+			t.Fatal(err)
+		}
+		if frame.From != sender || *frame.To != contract || frame.Value.Int64() != value {
+			t.Fatalf("block %d: wrong top frame %s", block.NumberU64(), traces[0])
+		}
+		if len(frame.Calls) != 1 || *frame.Calls[0].To != beneficiary || frame.Calls[0].Value.Int64() != value {
+			t.Fatalf("block %d: wrong inner frame %s", block.NumberU64(), traces[0])
+		}
+	}
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatal(err)
+	}
+	for i, block := range blocks {
+		if i == 0 {
+			// Block 1 is out of the retention window.
+			if traces := reader.CallTraces(block.NumberU64(), block.Hash()); traces != nil {
+				t.Fatalf("block 1 not pruned")
+			}
+			continue
+		}
+		checkTraces(block, int64(i+1))
+	}
+
+	// Reorg to the longer fork. The traces of the new canonical blocks must be
+	// served, even though traces of the old blocks exist at the same heights.
+	if _, err := chain.InsertChain(fork); err != nil {
+		t.Fatal(err)
+	}
+	if head := chain.CurrentBlock(); head.Hash() != fork[len(fork)-1].Hash() {
+		t.Fatalf("reorg didn't happen")
+	}
+	for _, block := range fork[:len(fork)-3] {
+		if traces := reader.CallTraces(block.NumberU64(), block.Hash()); traces != nil {
+			t.Fatalf("fork block %d not pruned", block.NumberU64())
+		}
+	}
+	for _, block := range fork[len(fork)-3:] {
+		checkTraces(block, int64(100+block.NumberU64()-1))
+	}
+}
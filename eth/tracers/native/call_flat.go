@@ -39,6 +39,7 @@ import (
 
 func init() {
 	tracers.DefaultDirectory.Register("flatCallTracer", newFlatCallTracer, false)
+	tracers.DefaultDirectory.RegisterCallConverter("flatCallTracer", flatFromCallTrace)
 }
 
 var parityErrorMapping = map[string]string{
@@ -250,6 +251,68 @@ func (t *flatCallTracer) isPrecompiled(addr common.Address) bool {
 	return slices.Contains(t.activePrecompiles, addr)
 }
 
+// callFrameTypes holds the types of a callTracer result's frames, which are not
+// decoded along with the frames.
+type callFrameTypes struct {
+	Type  string           `json:"type"`
+	Calls []callFrameTypes `json:"calls,omitempty"`
+}
+
+// flatFromCallTrace derives the flatCallTracer result of a transaction from its
+// callTracer result, as if the transaction was traced with the flatCallTracer.
+func flatFromCallTrace(callTrace json.RawMessage, ctx *tracers.Context, cfg json.RawMessage, rules params.Rules) (json.RawMessage, error) {
+	var config flatCallTracerConfig
+	if len(cfg) > 0 {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	var (
+		frame      callFrame
+		frameTypes callFrameTypes
+	)
+	if err := json.Unmarshal(callTrace, &frame); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(callTrace, &frameTypes); err != nil {
+		return nil, err
+	}
+	var precompiles []common.Address
+	if !config.IncludePrecompiles {
+		precompiles = vm.ActivePrecompiles(rules)
+	}
+	prepareCallTrace(&frame, &frameTypes, precompiles)
+
+	flat, err := flatFromNested(&frame, []int{}, config.ConvertParityErrors, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(flat)
+}
+
+// prepareCallTrace restores the frame types of a decoded callTracer result, and
+// applies the adjustments of the flatCallTracer: child calls always carry a
+// value, and calls to the given precompiles are removed.
+func prepareCallTrace(frame *callFrame, frameTypes *callFrameTypes, precompiles []common.Address) {
+	frame.Type = vm.StringToOp(frameTypes.Type)
+
+	calls := frame.Calls[:0]
+	for i := range frame.Calls {
+		call := frame.Calls[i]
+		if i < len(frameTypes.Calls) {
+			prepareCallTrace(&call, &frameTypes.Calls[i], precompiles)
+		}
+		if call.Value == nil {
+			call.Value = big.NewInt(0)
+		}
+		if (call.Type == vm.CALL || call.Type == vm.STATICCALL) && call.To != nil && slices.Contains(precompiles, *call.To) {
+			continue
+		}
+		calls = append(calls, call)
+	}
+	frame.Calls = calls
+}
+
 func flatFromNested(input *callFrame, traceAddress []int, convertErrs bool, ctx *tracers.Context) (output []flatCallFrame, err error) {
 	var frame *flatCallFrame
 	switch input.Type {
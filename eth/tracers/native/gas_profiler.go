@@ -0,0 +1,280 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/google/pprof/profile"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("gasProfiler", newGasProfiler, false)
+}
+
+// Sample labels for gas which isn't spent by an opcode.
+const (
+	gasProfilerIntrinsic  = "(intrinsic)"
+	gasProfilerPrecompile = "(precompile)"
+)
+
+// gasProfilerResult is the output of the gasProfiler. Profile is a gzipped pprof
+// profile, which can be opened with `go tool pprof`.
+type gasProfilerResult struct {
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+	Profile hexutil.Bytes  `json:"profile"`
+}
+
+// gasProfilerFrame is a call frame which is currently executing.
+type gasProfilerFrame struct {
+	addr     common.Address      // address of the executed code
+	callers  []*profile.Location // call sites of the frame, innermost first
+	startGas uint64
+
+	executed bool      // set once the first opcode was executed
+	lastPC   uint64    // pc of the last executed opcode
+	lastOp   vm.OpCode // last executed opcode
+	lastGas  uint64    // gas available before the last executed opcode
+	childGas uint64    // gas used by the frames entered by the last executed opcode
+}
+
+// gasProfiler is a native tracer which attributes the gas used by a transaction
+// to the executed code. Each sample of the profile is a call stack, made up of the
+// addresses of the executed contracts and the program counters of the calls
+// between them. The leaf is the opcode which spent the gas, it's also recorded
+// in the "op" label of the sample.
+//
+// The gas spent by an opcode is measured as the difference of the gas available
+// before it and before the next opcode of the same frame, minus the gas used by
+// the frames it entered. This accounts for the gas forwarded to calls and any
+// call stipend, so the samples sum up to the gas used before refunds.
+type gasProfiler struct {
+	prof      *profile.Profile
+	functions map[string]*profile.Function
+	locations map[string]*profile.Location
+	samples   map[string]*profile.Sample
+
+	frames    []*gasProfilerFrame
+	gasLimit  uint64
+	gasUsed   uint64
+	interrupt atomic.Bool           // Atomic flag to signal execution interruption
+	reason    atomic.Pointer[error] // Reason for the interruption, populated by Stop
+}
+
+func newGasProfiler(ctx *tracers.Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*tracers.Tracer, error) {
+	gasType := &profile.ValueType{Type: "gas", Unit: "count"}
+	t := &gasProfiler{
+		prof: &profile.Profile{
+			SampleType:        []*profile.ValueType{gasType},
+			DefaultSampleType: "gas",
+			PeriodType:        gasType,
+			Period:            1,
+		},
+		functions: make(map[string]*profile.Function),
+		locations: make(map[string]*profile.Location),
+		samples:   make(map[string]*profile.Sample),
+	}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnTxStart: t.OnTxStart,
+			OnTxEnd:   t.OnTxEnd,
+			OnEnter:   t.OnEnter,
+			OnExit:    t.OnExit,
+			OnOpcode:  t.OnOpcode,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+func (t *gasProfiler) OnTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	t.gasLimit = tx.Gas()
+}
+
+func (t *gasProfiler) OnTxEnd(receipt *types.Receipt, err error) {
+	if err == nil && receipt != nil {
+		t.gasUsed += receipt.GasUsed
+	}
+}
+
+// OnEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *gasProfiler) OnEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if t.interrupt.Load() {
+		return
+	}
+	frame := &gasProfilerFrame{addr: to, startGas: gas}
+	if len(t.frames) == 0 {
+		// Everything charged before the outermost call is intrinsic gas.
+		if t.gasLimit > gas {
+			fn := t.function(gasProfilerIntrinsic)
+			t.addSample([]*profile.Location{t.location(fn, gasProfilerIntrinsic, 0)}, gasProfilerIntrinsic, t.gasLimit-gas)
+		}
+	} else {
+		parent := t.frames[len(t.frames)-1]
+		frame.callers = append([]*profile.Location{t.codeLocation(parent.addr, parent.lastPC)}, parent.callers...)
+	}
+	t.frames = append(t.frames, frame)
+}
+
+// OnExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *gasProfiler) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.interrupt.Load() || len(t.frames) == 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+
+	switch {
+	case frame.executed:
+		var left uint64
+		if frame.startGas > gasUsed {
+			left = frame.startGas - gasUsed
+		}
+		t.addOpcodeSample(frame, left)
+	case gasUsed > 0:
+		// Frames without code only use gas if they are precompiles.
+		loc := t.codeLocation(frame.addr, 0)
+		t.addSample(append([]*profile.Location{loc}, frame.callers...), gasProfilerPrecompile, gasUsed)
+	}
+	if len(t.frames) > 0 {
+		t.frames[len(t.frames)-1].childGas += gasUsed
+	}
+}
+
+// OnOpcode is called before each opcode is executed.
+func (t *gasProfiler) OnOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	if t.interrupt.Load() || len(t.frames) == 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	if frame.executed {
+		t.addOpcodeSample(frame, gas)
+	}
+	frame.executed = true
+	frame.lastPC, frame.lastOp, frame.lastGas = pc, vm.OpCode(op), gas
+	frame.childGas = 0
+}
+
+// addOpcodeSample attributes the gas spent by the last executed opcode of the
+// frame, given the gas left after it.
+func (t *gasProfiler) addOpcodeSample(frame *gasProfilerFrame, left uint64) {
+	if frame.lastGas < left+frame.childGas {
+		return
+	}
+	used := frame.lastGas - left - frame.childGas
+	if used == 0 {
+		return
+	}
+	loc := t.codeLocation(frame.addr, frame.lastPC)
+	t.addSample(append([]*profile.Location{loc}, frame.callers...), frame.lastOp.String(), used)
+}
+
+func (t *gasProfiler) addSample(stack []*profile.Location, op string, gas uint64) {
+	var key strings.Builder
+	for _, loc := range stack {
+		key.WriteString(strconv.FormatUint(loc.ID, 10))
+		key.WriteByte(',')
+	}
+	key.WriteString(op)
+
+	if sample, ok := t.samples[key.String()]; ok {
+		sample.Value[0] += int64(gas)
+		return
+	}
+	sample := &profile.Sample{
+		Location: stack,
+		Value:    []int64{int64(gas)},
+		Label:    map[string][]string{"op": {op}},
+	}
+	t.samples[key.String()] = sample
+	t.prof.Sample = append(t.prof.Sample, sample)
+}
+
+// codeLocation returns the location of a program counter in the code of a contract.
+func (t *gasProfiler) codeLocation(addr common.Address, pc uint64) *profile.Location {
+	name := addr.Hex()
+	return t.location(t.function(name), name, pc)
+}
+
+func (t *gasProfiler) location(fn *profile.Function, name string, pc uint64) *profile.Location {
+	key := name + ":" + strconv.FormatUint(pc, 10)
+	if loc, ok := t.locations[key]; ok {
+		return loc
+	}
+	loc := &profile.Location{
+		ID:      uint64(len(t.prof.Location) + 1),
+		Address: pc,
+		Line:    []profile.Line{{Function: fn, Line: int64(pc)}},
+	}
+	t.locations[key] = loc
+	t.prof.Location = append(t.prof.Location, loc)
+	return loc
+}
+
+func (t *gasProfiler) function(name string) *profile.Function {
+	if fn, ok := t.functions[name]; ok {
+		return fn
+	}
+	fn := &profile.Function{
+		ID:         uint64(len(t.prof.Function) + 1),
+		Name:       name,
+		SystemName: name,
+		Filename:   name,
+	}
+	t.functions[name] = fn
+	t.prof.Function = append(t.prof.Function, fn)
+	return fn
+}
+
+// GetResult returns the json-encoded gas profile, and any error arising from
+// the encoding or forceful termination (via `Stop`).
+func (t *gasProfiler) GetResult() (json.RawMessage, error) {
+	var buf bytes.Buffer
+	if err := t.prof.Write(&buf); err != nil {
+		return nil, err
+	}
+	res, err := json.Marshal(&gasProfilerResult{
+		GasUsed: hexutil.Uint64(t.gasUsed),
+		Profile: buf.Bytes(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if p := t.reason.Load(); p != nil {
+		return res, *p
+	}
+	return res, nil
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *gasProfiler) Stop(err error) {
+	t.reason.Store(&err)
+	t.interrupt.Store(true)
+}
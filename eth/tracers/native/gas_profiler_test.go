@@ -0,0 +1,123 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/google/pprof/profile"
+)
+
+func TestGasProfiler(t *testing.T) {
+	var (
+		outer = common.HexToAddress("0xaa")
+		inner = common.HexToAddress("0xbb")
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	// inner: SSTORE(0, 1)
+	statedb.SetCode(inner, []byte{
+		byte(vm.PUSH1), 0x01, byte(vm.PUSH1), 0x00, byte(vm.SSTORE), byte(vm.STOP),
+	}, tracing.CodeChangeUnspecified)
+	// outer: CALL(gas, inner, 0, 0, 0, 0, 0); CALL(gas, 0x4, 0, 0, 0, 0, 0)
+	outerCode := []byte{}
+	for _, target := range []byte{0xbb, 0x04} {
+		outerCode = append(outerCode,
+			byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00,
+			byte(vm.PUSH1), 0x00, byte(vm.PUSH1), target, byte(vm.GAS), byte(vm.CALL), byte(vm.POP),
+		)
+	}
+	outerCode = append(outerCode, byte(vm.STOP))
+	statedb.SetCode(outer, outerCode, tracing.CodeChangeUnspecified)
+
+	tracer, err := tracers.DefaultDirectory.New("gasProfiler", new(tracers.Context), nil, params.MainnetChainConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = runtime.Call(outer, nil, &runtime.Config{
+		State:     statedb,
+		GasLimit:  1000000,
+		EVMConfig: vm.Config{Tracer: tracer.Hooks},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := tracer.GetResult()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result gasProfilerResult
+	if err := json.Unmarshal(res, &result); err != nil {
+		t.Fatal(err)
+	}
+	prof, err := profile.Parse(bytes.NewReader(result.Profile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The samples must add up to the gas used, and every sample must have
+	// the expected call stack.
+	var (
+		total int64
+		ops   = make(map[string]int64)
+	)
+	for _, sample := range prof.Sample {
+		total += sample.Value[0]
+		op := sample.Label["op"][0]
+		ops[op] += sample.Value[0]
+
+		var stack []string
+		for _, loc := range sample.Location {
+			stack = append(stack, loc.Line[0].Function.Name)
+		}
+		switch op {
+		case "SSTORE":
+			if len(stack) != 2 || stack[0] != inner.Hex() || stack[1] != outer.Hex() {
+				t.Errorf("wrong stack for SSTORE: %v", stack)
+			}
+			if caller := sample.Location[1].Line[0].Line; caller != 13 {
+				t.Errorf("wrong call site of inner call: %d", caller)
+			}
+		case gasProfilerPrecompile:
+			if len(stack) != 2 || stack[0] != common.BytesToAddress([]byte{4}).Hex() {
+				t.Errorf("wrong stack for precompile: %v", stack)
+			}
+		}
+	}
+	if total != int64(result.GasUsed) {
+		t.Errorf("samples add up to %d, gas used is %d", total, result.GasUsed)
+	}
+	if ops["SSTORE"] != int64(params.SstoreSetGasEIP2200+params.ColdSloadCostEIP2929) {
+		t.Errorf("wrong SSTORE gas: %d", ops["SSTORE"])
+	}
+	if ops[gasProfilerPrecompile] != int64(params.IdentityBaseGas) {
+		t.Errorf("wrong precompile gas: %d", ops[gasProfilerPrecompile])
+	}
+	// Cold access of inner, warm access of the precompile.
+	if want := int64(params.ColdAccountAccessCostEIP2929 + params.WarmStorageReadCostEIP2929); ops["CALL"] != want {
+		t.Errorf("wrong CALL gas: %d, want %d", ops["CALL"], want)
+	}
+}
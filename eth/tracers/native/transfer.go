@@ -0,0 +1,220 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("transferTracer", newTransferTracer, false)
+}
+
+// Kinds of transfers reported by the transferTracer.
+const (
+	transferKindETH     = "eth"
+	transferKindERC20   = "erc20"
+	transferKindERC721  = "erc721"
+	transferKindERC1155 = "erc1155"
+)
+
+var (
+	// Transfer(address,address,uint256) is shared by ERC-20 and ERC-721, which
+	// are told apart by the number of indexed parameters.
+	transferEventTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	// ERC-1155 transfer events.
+	transferSingleEventTopic = crypto.Keccak256Hash([]byte("TransferSingle(address,address,address,uint256,uint256)"))
+	transferBatchEventTopic  = crypto.Keccak256Hash([]byte("TransferBatch(address,address,address,uint256[],uint256[])"))
+
+	transferBatchArguments = func() abi.Arguments {
+		uintArray, _ := abi.NewType("uint256[]", "", nil)
+		return abi.Arguments{{Type: uintArray}, {Type: uintArray}}
+	}()
+)
+
+// transfer is a single transfer of ETH or tokens.
+type transfer struct {
+	Kind         string          `json:"kind"`
+	Token        *common.Address `json:"token,omitempty"`
+	From         common.Address  `json:"from"`
+	To           common.Address  `json:"to"`
+	Value        *hexutil.Big    `json:"value,omitempty"`
+	TokenID      *hexutil.Big    `json:"tokenId,omitempty"`
+	Operator     *common.Address `json:"operator,omitempty"`
+	CallType     string          `json:"callType,omitempty"` // Type of the frame moving ETH
+	LogIndex     *hexutil.Uint   `json:"logIndex,omitempty"` // Index of the log a token transfer was decoded from
+	Depth        int             `json:"depth"`
+	TraceAddress []int           `json:"traceAddress"` // Position of the originating frame in the call tree
+}
+
+// transferTracer reports the ETH and token transfers of a transaction as a flat
+// list, in execution order. ETH transfers are taken from the value of call
+// frames, including internal calls, contract creations and selfdestructs. Token
+// transfers are decoded from the ERC-20, ERC-721 and ERC-1155 transfer events.
+// Transfers of reverted frames are omitted.
+type transferTracer struct {
+	tracer *callTracer
+}
+
+func newTransferTracer(ctx *tracers.Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*tracers.Tracer, error) {
+	// The call tracer collects the frames and their logs, including the
+	// position of the logs relative to subcalls.
+	t, err := newCallTracerObject(ctx, json.RawMessage(`{"withLog":true}`))
+	if err != nil {
+		return nil, err
+	}
+	tt := &transferTracer{tracer: t}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnTxStart: t.OnTxStart,
+			OnTxEnd:   t.OnTxEnd,
+			OnEnter:   t.OnEnter,
+			OnExit:    t.OnExit,
+			OnLog:     t.OnLog,
+		},
+		GetResult: tt.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+// GetResult returns the json-encoded list of transfers, and any error arising
+// from the encoding or forceful termination (via `Stop`).
+func (t *transferTracer) GetResult() (json.RawMessage, error) {
+	if len(t.tracer.callstack) != 1 {
+		return nil, errors.New("incorrect number of top-level calls")
+	}
+	transfers := make([]*transfer, 0)
+	collectTransfers(&t.tracer.callstack[0], 0, []int{}, &transfers)
+
+	res, err := json.Marshal(transfers)
+	if err != nil {
+		return nil, err
+	}
+	if p := t.tracer.reason.Load(); p != nil {
+		return res, *p
+	}
+	return res, nil
+}
+
+// collectTransfers appends the transfers of a frame and its subcalls. Logs are
+// interleaved with subcalls according to their position.
+func collectTransfers(frame *callFrame, depth int, traceAddress []int, transfers *[]*transfer) {
+	if frame.failed() {
+		return
+	}
+	if t := valueTransfer(frame); t != nil {
+		t.Depth, t.TraceAddress = depth, traceAddress
+		*transfers = append(*transfers, t)
+	}
+	logs := frame.Logs
+	for i := range frame.Calls {
+		for len(logs) > 0 && int(logs[0].Position) <= i {
+			*transfers = append(*transfers, decodeTransferLog(&logs[0], depth, traceAddress)...)
+			logs = logs[1:]
+		}
+		childAddress := append(append([]int{}, traceAddress...), i)
+		collectTransfers(&frame.Calls[i], depth+1, childAddress, transfers)
+	}
+	for i := range logs {
+		*transfers = append(*transfers, decodeTransferLog(&logs[i], depth, traceAddress)...)
+	}
+}
+
+// valueTransfer returns the ETH transfer made by entering a frame, if any.
+func valueTransfer(frame *callFrame) *transfer {
+	if frame.Value == nil || frame.Value.Sign() == 0 || frame.To == nil {
+		return nil
+	}
+	switch frame.Type {
+	case vm.CALL, vm.CREATE, vm.CREATE2, vm.SELFDESTRUCT:
+	default:
+		// Delegate calls and callcodes don't move ETH between accounts.
+		return nil
+	}
+	return &transfer{
+		Kind:     transferKindETH,
+		From:     frame.From,
+		To:       *frame.To,
+		Value:    (*hexutil.Big)(new(big.Int).Set(frame.Value)),
+		CallType: frame.Type.String(),
+	}
+}
+
+// decodeTransferLog decodes the token transfers of an event, if it's one of the
+// standard transfer events.
+func decodeTransferLog(log *callLog, depth int, traceAddress []int) []*transfer {
+	if len(log.Topics) == 0 {
+		return nil
+	}
+	var (
+		token    = log.Address
+		index    = log.Index
+		base     = transfer{Token: &token, LogIndex: &index, Depth: depth, TraceAddress: traceAddress}
+		topicArg = func(i int) common.Address { return common.BytesToAddress(log.Topics[i][12:]) }
+		topicInt = func(i int) *hexutil.Big { return (*hexutil.Big)(new(big.Int).SetBytes(log.Topics[i][:])) }
+		wordInt  = func(i int) *hexutil.Big { return (*hexutil.Big)(new(big.Int).SetBytes(log.Data[i*32 : (i+1)*32])) }
+	)
+	switch {
+	case log.Topics[0] == transferEventTopic && len(log.Topics) == 3 && len(log.Data) == 32:
+		t := base
+		t.Kind, t.From, t.To, t.Value = transferKindERC20, topicArg(1), topicArg(2), wordInt(0)
+		return []*transfer{&t}
+
+	case log.Topics[0] == transferEventTopic && len(log.Topics) == 4 && len(log.Data) == 0:
+		t := base
+		t.Kind, t.From, t.To, t.TokenID = transferKindERC721, topicArg(1), topicArg(2), topicInt(3)
+		return []*transfer{&t}
+
+	case log.Topics[0] == transferSingleEventTopic && len(log.Topics) == 4 && len(log.Data) == 64:
+		operator := topicArg(1)
+		t := base
+		t.Kind, t.Operator, t.From, t.To = transferKindERC1155, &operator, topicArg(2), topicArg(3)
+		t.TokenID, t.Value = wordInt(0), wordInt(1)
+		return []*transfer{&t}
+
+	case log.Topics[0] == transferBatchEventTopic && len(log.Topics) == 4:
+		values, err := transferBatchArguments.Unpack(log.Data)
+		if err != nil {
+			return nil
+		}
+		ids, amounts := values[0].([]*big.Int), values[1].([]*big.Int)
+		if len(ids) != len(amounts) {
+			return nil
+		}
+		operator := topicArg(1)
+		transfers := make([]*transfer, len(ids))
+		for i := range ids {
+			t := base
+			t.Kind, t.Operator, t.From, t.To = transferKindERC1155, &operator, topicArg(2), topicArg(3)
+			t.TokenID, t.Value = (*hexutil.Big)(ids[i]), (*hexutil.Big)(amounts[i])
+			transfers[i] = &t
+		}
+		return transfers
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func TestTransferTracer(t *testing.T) {
+	var (
+		origin      = common.HexToAddress("0x01")
+		token       = common.HexToAddress("0xaa")
+		destructor  = common.HexToAddress("0xbb")
+		reverter    = common.HexToAddress("0xcc")
+		beneficiary = common.HexToAddress("0xdd")
+		holderFrom  = common.HexToAddress("0xee")
+		holderTo    = common.HexToAddress("0xff")
+	)
+	callWithValue := func(to common.Address, value byte) []byte {
+		code := []byte{byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), value, byte(vm.PUSH20)}
+		code = append(code, to.Bytes()...)
+		return append(code, byte(vm.GAS), byte(vm.CALL), byte(vm.POP))
+	}
+	// token: emits an ERC-20 transfer of 42, then calls the destructor with
+	// value 5 and the reverter with value 7.
+	code := []byte{byte(vm.PUSH1), 42, byte(vm.PUSH1), 0, byte(vm.MSTORE), byte(vm.PUSH20)}
+	code = append(code, holderTo.Bytes()...)
+	code = append(code, byte(vm.PUSH20))
+	code = append(code, holderFrom.Bytes()...)
+	code = append(code, byte(vm.PUSH32))
+	code = append(code, transferEventTopic.Bytes()...)
+	code = append(code, byte(vm.PUSH1), 32, byte(vm.PUSH1), 0, byte(vm.LOG3))
+	code = append(code, callWithValue(destructor, 5)...)
+	code = append(code, callWithValue(reverter, 7)...)
+	code = append(code, byte(vm.STOP))
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.SetBalance(origin, uint256.NewInt(params.Ether), tracing.BalanceChangeUnspecified)
+	statedb.SetCode(token, code, tracing.CodeChangeUnspecified)
+	statedb.SetCode(destructor, append([]byte{byte(vm.PUSH20)}, append(beneficiary.Bytes(), byte(vm.SELFDESTRUCT))...), tracing.CodeChangeUnspecified)
+	statedb.SetCode(reverter, []byte{byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.REVERT)}, tracing.CodeChangeUnspecified)
+
+	tracer, err := tracers.DefaultDirectory.New("transferTracer", new(tracers.Context), nil, params.MainnetChainConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Execute the call like a transaction, on a hooked state so that logs are
+	// passed to the tracer.
+	blockCtx := vm.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		BlockNumber: big.NewInt(1),
+		Time:        1,
+		GasLimit:    params.GenesisGasLimit,
+		BaseFee:     big.NewInt(0),
+		BlobBaseFee: big.NewInt(0),
+		Difficulty:  big.NewInt(0),
+		Random:      &common.Hash{},
+	}
+	evm := vm.NewEVM(blockCtx, state.NewHookedState(statedb, tracer.Hooks), params.MergedTestChainConfig, vm.Config{Tracer: tracer.Hooks})
+	msg := &core.Message{
+		From:      origin,
+		To:        &token,
+		Value:     uint256.NewInt(10),
+		GasLimit:  1000000,
+		GasPrice:  uint256.NewInt(0),
+		GasFeeCap: uint256.NewInt(0),
+		GasTipCap: uint256.NewInt(0),
+	}
+	tx := types.NewTx(&types.LegacyTx{To: &token, Value: big.NewInt(10), Gas: msg.GasLimit})
+	tracer.OnTxStart(evm.GetVMContext(), tx, origin)
+	result, err := core.ApplyMessage(evm, msg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracer.OnTxEnd(&types.Receipt{GasUsed: result.UsedGas}, nil)
+	res, err := tracer.GetResult()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var have []*transfer
+	if err := json.Unmarshal(res, &have); err != nil {
+		t.Fatal(err)
+	}
+	logIndex := hexutil.Uint(0)
+	want := []*transfer{
+		{Kind: "eth", From: origin, To: token, Value: (*hexutil.Big)(big.NewInt(10)), CallType: "CALL", Depth: 0, TraceAddress: []int{}},
+		{Kind: "erc20", Token: &token, From: holderFrom, To: holderTo, Value: (*hexutil.Big)(big.NewInt(42)), LogIndex: &logIndex, Depth: 0, TraceAddress: []int{}},
+		{Kind: "eth", From: token, To: destructor, Value: (*hexutil.Big)(big.NewInt(5)), CallType: "CALL", Depth: 1, TraceAddress: []int{0}},
+		{Kind: "eth", From: destructor, To: beneficiary, Value: (*hexutil.Big)(big.NewInt(5)), CallType: "SELFDESTRUCT", Depth: 2, TraceAddress: []int{0, 0}},
+	}
+	if !reflect.DeepEqual(have, want) {
+		haveJSON, _ := json.MarshalIndent(have, "", "  ")
+		wantJSON, _ := json.MarshalIndent(want, "", "  ")
+		t.Fatalf("wrong transfers\nhave %s\nwant %s", haveJSON, wantJSON)
+	}
+}
+
+func TestDecodeTransferLog(t *testing.T) {
+	var (
+		token    = common.HexToAddress("0xaa")
+		operator = common.HexToAddress("0xbb")
+		from     = common.HexToAddress("0xcc")
+		to       = common.HexToAddress("0xdd")
+		word     = func(n int64) []byte { return common.BigToHash(big.NewInt(n)).Bytes() }
+		topic    = func(a common.Address) common.Hash { return common.BytesToHash(a.Bytes()) }
+		hexBig   = func(n int64) *hexutil.Big { return (*hexutil.Big)(big.NewInt(n)) }
+		index    = hexutil.Uint(3)
+	)
+	batchData, err := transferBatchArguments.Pack([]*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(10), big.NewInt(20)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		log  callLog
+		want []*transfer
+	}{
+		// ERC-721
+		{
+			log: callLog{Address: token, Index: index, Topics: []common.Hash{transferEventTopic, topic(from), topic(to), common.BytesToHash(word(9))}},
+			want: []*transfer{
+				{Kind: "erc721", Token: &token, From: from, To: to, TokenID: hexBig(9), LogIndex: &index, TraceAddress: []int{1}, Depth: 1},
+			},
+		},
+		// ERC-1155 single
+		{
+			log: callLog{Address: token, Index: index, Topics: []common.Hash{transferSingleEventTopic, topic(operator), topic(from), topic(to)}, Data: append(word(4), word(5)...)},
+			want: []*transfer{
+				{Kind: "erc1155", Token: &token, Operator: &operator, From: from, To: to, TokenID: hexBig(4), Value: hexBig(5), LogIndex: &index, TraceAddress: []int{1}, Depth: 1},
+			},
+		},
+		// ERC-1155 batch
+		{
+			log: callLog{Address: token, Index: index, Topics: []common.Hash{transferBatchEventTopic, topic(operator), topic(from), topic(to)}, Data: batchData},
+			want: []*transfer{
+				{Kind: "erc1155", Token: &token, Operator: &operator, From: from, To: to, TokenID: hexBig(1), Value: hexBig(10), LogIndex: &index, TraceAddress: []int{1}, Depth: 1},
+				{Kind: "erc1155", Token: &token, Operator: &operator, From: from, To: to, TokenID: hexBig(2), Value: hexBig(20), LogIndex: &index, TraceAddress: []int{1}, Depth: 1},
+			},
+		},
+		// Transfer event with unexpected layout
+		{
+			log: callLog{Address: token, Index: index, Topics: []common.Hash{transferEventTopic, topic(from)}, Data: word(1)},
+		},
+		// Unrelated event
+		{
+			log: callLog{Address: token, Index: index, Topics: []common.Hash{{1}}},
+		},
+	}
+	for i, test := range tests {
+		have := decodeTransferLog(&test.log, 1, []int{1})
+		if !reflect.DeepEqual(have, test.want) {
+			haveJSON, _ := json.Marshal(have)
+			wantJSON, _ := json.Marshal(test.want)
+			t.Errorf("test %d: wrong transfers\nhave %s\nwant %s", i, haveJSON, wantJSON)
+		}
+	}
+}
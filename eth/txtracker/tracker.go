@@ -54,6 +54,7 @@ const (
 type PeerStats struct {
 	RecentFinalized float64 // EMA of per-block finalization credits (slow)
 	RecentIncluded  float64 // EMA of per-block inclusions (fast)
+	Finalized       uint64  // Total finalization credits since the peer connected
 }
 
 // Chain is the blockchain interface needed by the tracker.
@@ -67,6 +68,7 @@ type Chain interface {
 type peerStats struct {
 	recentFinalized float64
 	recentIncluded  float64
+	finalized       uint64
 }
 
 // TxInfo records the per-transaction state the tracker maintains.
@@ -181,6 +183,7 @@ func (t *Tracker) GetAllPeerStats() map[string]PeerStats {
 		result[id] = PeerStats{
 			RecentFinalized: ps.recentFinalized,
 			RecentIncluded:  ps.recentIncluded,
+			Finalized:       ps.finalized,
 		}
 	}
 	return result
@@ -246,6 +249,7 @@ func (t *Tracker) handleChainHead(ev core.ChainHeadEvent) {
 	for peer, ps := range t.peers {
 		ps.recentIncluded = (1-emaAlpha)*ps.recentIncluded + emaAlpha*float64(blockIncl[peer])
 		ps.recentFinalized = (1-finalizedEMAAlpha)*ps.recentFinalized + finalizedEMAAlpha*float64(blockFinal[peer])
+		ps.finalized += uint64(blockFinal[peer])
 	}
 }
 
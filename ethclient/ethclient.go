@@ -471,6 +471,9 @@ func (ec *Client) NonceAtHash(ctx context.Context, account common.Address, block
 
 // FilterLogs executes a filter query.
 func (ec *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	if q.Limit != 0 || q.Cursor != nil {
+		return nil, errors.New("paginated filter queries must use FilterLogsPage")
+	}
 	var result []types.Log
 	arg, err := toFilterArg(q)
 	if err != nil {
@@ -480,6 +483,26 @@ func (ec *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]typ
 	return result, err
 }
 
+// FilterLogsPage executes a paginated filter query, returning at most q.Limit logs
+// along with the cursor of the next page. The cursor is nil on the last page.
+func (ec *Client) FilterLogsPage(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, []byte, error) {
+	if q.Limit == 0 {
+		return nil, nil, errors.New("paginated filter query without limit")
+	}
+	var result struct {
+		Logs   []types.Log   `json:"logs"`
+		Cursor hexutil.Bytes `json:"cursor"`
+	}
+	arg, err := toFilterArg(q)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := ec.c.CallContext(ctx, &result, "eth_getLogsPage", arg); err != nil {
+		return nil, nil, err
+	}
+	return result.Logs, result.Cursor, nil
+}
+
 // SubscribeFilterLogs subscribes to the results of a streaming filter query.
 func (ec *Client) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
 	arg, err := toFilterArg(q)
@@ -521,6 +544,15 @@ func toFilterArg(q ethereum.FilterQuery) (interface{}, error) {
 		}
 		arg["toBlock"] = toBlockNumArg(q.ToBlock)
 	}
+	if q.Limit != 0 {
+		arg["limit"] = hexutil.Uint64(q.Limit)
+	}
+	if q.Cursor != nil {
+		arg["cursor"] = hexutil.Bytes(q.Cursor)
+	}
+	if q.Replay {
+		arg["replay"] = true
+	}
 	return arg, nil
 }
 
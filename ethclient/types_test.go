@@ -85,6 +85,19 @@ func TestToFilterArg(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"with replay",
+			ethereum.FilterQuery{
+				FromBlock: big.NewInt(1),
+				Replay:    true,
+			},
+			map[string]interface{}{
+				"fromBlock": "0x1",
+				"toBlock":   "latest",
+				"replay":    true,
+			},
+			nil,
+		},
 		{
 			"with nil fromBlock and nil toBlock",
 			ethereum.FilterQuery{
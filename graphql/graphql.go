@@ -241,8 +241,11 @@ func (t *Transaction) resolve(ctx context.Context) (*types.Transaction, *Block)
 		t.index = index
 		return t.tx, t.block
 	}
-	// No finalized transaction, try to retrieve it from the pool
-	t.tx = t.r.backend.GetPoolTransaction(t.hash)
+	// No finalized transaction, try to retrieve it from the pool. Private
+	// transactions are hidden, as GraphQL has no notion of trusted callers.
+	if _, private := t.r.backend.TxPoolPrivate()[t.hash]; !private {
+		t.tx = t.r.backend.GetPoolTransaction(t.hash)
+	}
 	return t.tx, nil
 }
 
@@ -1233,13 +1236,32 @@ type Pending struct {
 	r *Resolver
 }
 
-func (p *Pending) TransactionCount(ctx context.Context) (hexutil.Uint64, error) {
+// poolTransactions returns the transactions in the pool, without the private ones.
+func (p *Pending) poolTransactions() (types.Transactions, error) {
 	txs, err := p.r.backend.GetPoolTransactions()
+	if err != nil {
+		return nil, err
+	}
+	private := p.r.backend.TxPoolPrivate()
+	if len(private) == 0 {
+		return txs, nil
+	}
+	filtered := make(types.Transactions, 0, len(txs))
+	for _, tx := range txs {
+		if _, ok := private[tx.Hash()]; !ok {
+			filtered = append(filtered, tx)
+		}
+	}
+	return filtered, nil
+}
+
+func (p *Pending) TransactionCount(ctx context.Context) (hexutil.Uint64, error) {
+	txs, err := p.poolTransactions()
 	return hexutil.Uint64(len(txs)), err
 }
 
 func (p *Pending) Transactions(ctx context.Context) (*[]*Transaction, error) {
-	txs, err := p.r.backend.GetPoolTransactions()
+	txs, err := p.poolTransactions()
 	if err != nil {
 		return nil, err
 	}
@@ -211,13 +211,25 @@ type FilterQuery struct {
 	// {{A}, {B}}         matches topic A in first position AND B in second position
 	// {{A, B}, {C, D}}   matches topic (A OR B) in first position AND (C OR D) in second position
 	Topics [][]common.Hash
+
+	// Limit and Cursor paginate eth_getLogsPage queries: each page contains at
+	// most Limit logs, along with the Cursor to query the next page with. They
+	// must be unset for eth_getLogs, which returns all logs at once.
+	Limit  uint64
+	Cursor []byte
+
+	// Replay makes a log subscription deliver the matching historical logs before
+	// the new ones, starting at FromBlock, or after BlockHash as the last block
+	// processed by the client. It must be unset for one-off queries.
+	Replay bool
 }
 
 // LogFilterer provides access to contract log events using a one-off query or continuous
 // event subscription.
 //
 // Logs received through a streaming query subscription may have Removed set to true,
-// indicating that the log was reverted due to a chain reorganisation.
+// indicating that the log was reverted due to a chain reorganisation. Subscriptions
+// with Replay set deliver the matching historical logs before streaming the new ones.
 type LogFilterer interface {
 	FilterLogs(ctx context.Context, q FilterQuery) ([]types.Log, error)
 	SubscribeFilterLogs(ctx context.Context, q FilterQuery, ch chan<- types.Log) (Subscription, error)
@@ -18,6 +18,7 @@ package ethapi
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -36,10 +37,12 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/forkid"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/gasestimator"
+	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/internal/ethapi/override"
 	"github.com/ethereum/go-ethereum/log"
@@ -61,8 +64,23 @@ const maxGetStorageSlots = 1024
 // requested in a single eth_getProof call.
 const maxGetProofKeys = 1024
 
+// defaultPrivateTxLifetime and maxPrivateTxLifetime are the default and maximum
+// number of blocks a private transaction is kept in the pool for.
+const (
+	defaultPrivateTxLifetime = 25
+	maxPrivateTxLifetime     = 1024
+)
+
+// defaultAddrTxsLimit and maxAddrTxsLimit are the default and maximum number of
+// transactions returned per eth_getTransactionsByAddress page.
+const (
+	defaultAddrTxsLimit = 100
+	maxAddrTxsLimit     = 1000
+)
+
 var errBlobTxNotSupported = errors.New("signing blob transactions not supported")
 var errSubClosed = errors.New("chain subscription closed")
+var errAddrIndexing = errors.New("address indexing is in progress")
 
 // EthereumAPI provides an API to access Ethereum related information.
 type EthereumAPI struct {
@@ -141,6 +159,59 @@ func (api *EthereumAPI) FeeHistory(ctx context.Context, blockCount math.HexOrDec
 	return results, nil
 }
 
+// defaultBlobForecast is the number of blocks the blob fee is forecast for by
+// eth_feeEstimate if not specified.
+const defaultBlobForecast = 6
+
+type feeSuggestionResult struct {
+	MaxFeePerGas         *hexutil.Big   `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big   `json:"maxPriorityFeePerGas"`
+	Delay                hexutil.Uint64 `json:"expectedDelay"`
+}
+
+func newFeeSuggestionResult(s gasprice.FeeSuggestion) feeSuggestionResult {
+	return feeSuggestionResult{
+		MaxFeePerGas:         (*hexutil.Big)(s.MaxFeePerGas),
+		MaxPriorityFeePerGas: (*hexutil.Big)(s.MaxPriorityFeePerGas),
+		Delay:                hexutil.Uint64(s.Delay),
+	}
+}
+
+type feeEstimateResult struct {
+	BaseFee          *hexutil.Big        `json:"baseFeePerGas"`
+	Slow             feeSuggestionResult `json:"slow"`
+	Standard         feeSuggestionResult `json:"standard"`
+	Fast             feeSuggestionResult `json:"fast"`
+	BlobBaseFee      []*hexutil.Big      `json:"baseFeePerBlobGas,omitempty"`
+	MaxFeePerBlobGas *hexutil.Big        `json:"maxFeePerBlobGas,omitempty"`
+}
+
+// FeeEstimate returns slow, standard and fast fee suggestions for dynamic fee
+// transactions, each with the expected inclusion delay in blocks. If blobs are
+// supported, it also forecasts the blob base fee of the next blobBlocks blocks,
+// and suggests a blob fee cap keeping a transaction includable over all of them.
+func (api *EthereumAPI) FeeEstimate(ctx context.Context, blobBlocks *math.HexOrDecimal64) (*feeEstimateResult, error) {
+	blocks := uint64(defaultBlobForecast)
+	if blobBlocks != nil {
+		blocks = uint64(*blobBlocks)
+	}
+	estimate, err := api.b.FeeEstimate(ctx, blocks)
+	if err != nil {
+		return nil, err
+	}
+	result := &feeEstimateResult{
+		BaseFee:          (*hexutil.Big)(estimate.BaseFee),
+		Slow:             newFeeSuggestionResult(estimate.Slow),
+		Standard:         newFeeSuggestionResult(estimate.Standard),
+		Fast:             newFeeSuggestionResult(estimate.Fast),
+		MaxFeePerBlobGas: (*hexutil.Big)(estimate.MaxFeePerBlobGas),
+	}
+	for _, fee := range estimate.BlobBaseFee {
+		result.BlobBaseFee = append(result.BlobBaseFee, (*hexutil.Big)(fee))
+	}
+	return result, nil
+}
+
 // BlobBaseFee returns the base fee for blob gas at the current head.
 func (api *EthereumAPI) BlobBaseFee(ctx context.Context) *hexutil.Big {
 	return (*hexutil.Big)(api.b.BlobBaseFee(ctx))
@@ -211,9 +282,96 @@ func flattenTxs(txs types.Transactions, header *types.Header, cfg *params.ChainC
 	return dump
 }
 
+// privateTxMethod is the method a scoped client must be allowed to call to be
+// trusted with private pool transactions.
+const privateTxMethod = "txpool_content"
+
+// PrivateTxsAllowed reports whether the caller is trusted with private pool
+// transactions, i.e. it's connected over IPC or authenticated with a JWT whose
+// permissions allow access to the pool content.
+func PrivateTxsAllowed(ctx context.Context) bool {
+	peer := rpc.PeerInfoFromContext(ctx)
+	if peer.Transport == "ipc" {
+		return true
+	}
+	if !peer.HTTP.Authenticated {
+		return false
+	}
+	for _, perms := range peer.HTTP.Permissions {
+		if !perms.Allows(privateTxMethod) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterPrivate removes the private transactions from a set of pool transactions,
+// unless the caller is trusted with them.
+func filterPrivate(ctx context.Context, b Backend, txs types.Transactions) types.Transactions {
+	if PrivateTxsAllowed(ctx) {
+		return txs
+	}
+	private := b.TxPoolPrivate()
+	if len(private) == 0 {
+		return txs
+	}
+	filtered := make(types.Transactions, 0, len(txs))
+	for _, tx := range txs {
+		if _, ok := private[tx.Hash()]; !ok {
+			filtered = append(filtered, tx)
+		}
+	}
+	return filtered
+}
+
+// hidePrivate reports whether the pool transaction with the given hash must be
+// hidden from the caller.
+func hidePrivate(ctx context.Context, b Backend, hash common.Hash) bool {
+	if PrivateTxsAllowed(ctx) {
+		return false
+	}
+	_, ok := b.TxPoolPrivate()[hash]
+	return ok
+}
+
+// publicPoolNonce lowers the pool nonce of an account to the nonce of its first
+// private transaction, unless the caller is trusted with private transactions.
+func publicPoolNonce(ctx context.Context, b Backend, addr common.Address, nonce uint64) uint64 {
+	if PrivateTxsAllowed(ctx) {
+		return nonce
+	}
+	private := b.TxPoolPrivate()
+	if len(private) == 0 {
+		return nonce
+	}
+	pending, _ := b.TxPoolContentFrom(addr)
+	for _, tx := range pending {
+		if _, ok := private[tx.Hash()]; ok && tx.Nonce() < nonce {
+			nonce = tx.Nonce()
+		}
+	}
+	return nonce
+}
+
+// filterPrivateContent removes the private transactions from the pool content,
+// unless the caller is trusted with them. Accounts left without transactions are
+// removed too.
+func (api *TxPoolAPI) filterPrivateContent(ctx context.Context, content map[common.Address][]*types.Transaction) {
+	for account, txs := range content {
+		if txs = filterPrivate(ctx, api.b, txs); len(txs) == 0 {
+			delete(content, account)
+		} else {
+			content[account] = txs
+		}
+	}
+}
+
 // Content returns the transactions contained within the transaction pool.
-func (api *TxPoolAPI) Content() map[string]map[string]map[string]*RPCTransaction {
+// Private transactions are only returned to trusted callers.
+func (api *TxPoolAPI) Content(ctx context.Context) map[string]map[string]map[string]*RPCTransaction {
 	pending, queue := api.b.TxPoolContent()
+	api.filterPrivateContent(ctx, pending)
+	api.filterPrivateContent(ctx, queue)
 	content := map[string]map[string]map[string]*RPCTransaction{
 		"pending": make(map[string]map[string]*RPCTransaction, len(pending)),
 		"queued":  make(map[string]map[string]*RPCTransaction, len(queue)),
@@ -231,9 +389,11 @@ func (api *TxPoolAPI) Content() map[string]map[string]map[string]*RPCTransaction
 }
 
 // ContentFrom returns the transactions contained within the transaction pool.
-func (api *TxPoolAPI) ContentFrom(addr common.Address) map[string]map[string]*RPCTransaction {
+// Private transactions are only returned to trusted callers.
+func (api *TxPoolAPI) ContentFrom(ctx context.Context, addr common.Address) map[string]map[string]*RPCTransaction {
 	content := make(map[string]map[string]*RPCTransaction, 2)
 	pending, queue := api.b.TxPoolContentFrom(addr)
+	pending, queue = filterPrivate(ctx, api.b, pending), filterPrivate(ctx, api.b, queue)
 	curHeader := api.b.CurrentHeader()
 
 	// Build the pending transactions
@@ -255,9 +415,12 @@ func (api *TxPoolAPI) Status() map[string]hexutil.Uint {
 }
 
 // Inspect retrieves the content of the transaction pool and flattens it into an
-// easily inspectable list.
-func (api *TxPoolAPI) Inspect() map[string]map[string]map[string]string {
+// easily inspectable list. Private transactions are only returned to authenticated
+// callers.
+func (api *TxPoolAPI) Inspect(ctx context.Context) map[string]map[string]map[string]string {
 	pending, queue := api.b.TxPoolContent()
+	api.filterPrivateContent(ctx, pending)
+	api.filterPrivateContent(ctx, queue)
 	content := map[string]map[string]map[string]string{
 		"pending": make(map[string]map[string]string, len(pending)),
 		"queued":  make(map[string]map[string]string, len(queue)),
@@ -289,6 +452,78 @@ func (api *TxPoolAPI) Inspect() map[string]map[string]map[string]string {
 	return content
 }
 
+// RPCTxLifecycle is a state change of a pool transaction, as reported by the
+// lifecycle subscription.
+type RPCTxLifecycle struct {
+	Hash        common.Hash            `json:"hash"`
+	Event       txpool.TxLifecycleKind `json:"event"`
+	Replacement *common.Hash           `json:"replacement,omitempty"`
+	Reason      txpool.TxDropReason    `json:"reason,omitempty"`
+	BlockNumber *hexutil.Uint64        `json:"blockNumber,omitempty"`
+}
+
+// newRPCTxLifecycle converts a pool transaction state change to its RPC form.
+func newRPCTxLifecycle(ev txpool.TxLifecycle) *RPCTxLifecycle {
+	result := &RPCTxLifecycle{
+		Hash:   ev.Hash,
+		Event:  ev.Kind,
+		Reason: ev.Reason,
+	}
+	switch ev.Kind {
+	case txpool.TxReplaced:
+		replacement := ev.Replacement
+		result.Replacement = &replacement
+	case txpool.TxIncluded:
+		number := hexutil.Uint64(ev.Block)
+		result.BlockNumber = &number
+	}
+	return result
+}
+
+// Lifecycle creates a subscription that is triggered each time a transaction
+// changes state in the pool, i.e. when it's added, promoted, demoted, replaced,
+// evicted, dropped or included. Private transactions are only reported to trusted
+// callers.
+func (api *TxPoolAPI) Lifecycle(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	var (
+		rpcSub = notifier.CreateSubscription()
+		events = make(chan txpool.LifecycleEvent, 128)
+		sub    = api.b.SubscribeTxLifecycleEvent(events)
+	)
+	allowed := PrivateTxsAllowed(ctx)
+	go func() {
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-events:
+				var private map[common.Hash]uint64
+				if !allowed {
+					private = api.b.TxPoolPrivate()
+				}
+				for _, tx := range ev.Txs {
+					if _, ok := private[tx.Hash]; ok {
+						continue
+					}
+					if _, ok := private[tx.Replacement]; ok && tx.Kind == txpool.TxReplaced {
+						continue
+					}
+					notifier.Notify(rpcSub.ID, newRPCTxLifecycle(tx))
+				}
+			case <-rpcSub.Err():
+				return
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
 // EthereumAccountAPI provides an API to access accounts managed by this node.
 // It offers only methods that can retrieve accounts.
 type EthereumAccountAPI struct {
@@ -669,7 +904,7 @@ func (api *BlockChainAPI) GetBlockReceipts(ctx context.Context, blockNrOrHash rp
 		receipts types.Receipts
 	)
 	if blockNr, ok := blockNrOrHash.Number(); ok && blockNr == rpc.PendingBlockNumber {
-		block, receipts, _ = api.b.Pending()
+		block, receipts, _ = api.b.Pending(ctx)
 		if block == nil {
 			return nil, errors.New("pending receipts is not available")
 		}
@@ -1510,6 +1745,7 @@ func (api *TransactionAPI) GetTransactionCount(ctx context.Context, address comm
 		if err != nil {
 			return nil, err
 		}
+		nonce = publicPoolNonce(ctx, api.b, address, nonce)
 		return (*hexutil.Uint64)(&nonce), nil
 	}
 	// Resolve block number and use its state to ask for the nonce
@@ -1527,7 +1763,7 @@ func (api *TransactionAPI) GetTransactionByHash(ctx context.Context, hash common
 	found, tx, blockHash, blockNumber, index := api.b.GetCanonicalTransaction(hash)
 	if !found {
 		// No finalized transaction, try to retrieve it from the pool
-		if tx := api.b.GetPoolTransaction(hash); tx != nil {
+		if tx := api.b.GetPoolTransaction(hash); tx != nil && !hidePrivate(ctx, api.b, hash) {
 			return NewRPCPendingTransaction(tx, api.b.CurrentHeader(), api.b.ChainConfig()), nil
 		}
 		// If also not in the pool there is a chance the tx indexer is still in progress.
@@ -1549,7 +1785,7 @@ func (api *TransactionAPI) GetRawTransactionByHash(ctx context.Context, hash com
 	// Retrieve a finalized transaction, or a pooled otherwise
 	found, tx, _, _, _ := api.b.GetCanonicalTransaction(hash)
 	if !found {
-		if tx = api.b.GetPoolTransaction(hash); tx != nil {
+		if tx = api.b.GetPoolTransaction(hash); tx != nil && !hidePrivate(ctx, api.b, hash) {
 			return tx.MarshalBinary()
 		}
 		// If also not in the pool there is a chance the tx indexer is still in progress.
@@ -1562,6 +1798,94 @@ func (api *TransactionAPI) GetRawTransactionByHash(ctx context.Context, hash com
 	return tx.MarshalBinary()
 }
 
+// GetTransactionBySenderAndNonce returns the transaction sent by the given
+// account with the given nonce, looking it up in the address index, or in the
+// pool if it's not included yet. Private pool transactions are only returned to
+// trusted callers. The address index must be enabled.
+func (api *TransactionAPI) GetTransactionBySenderAndNonce(ctx context.Context, sender common.Address, nonce hexutil.Uint64) (*RPCTransaction, error) {
+	found, err := api.b.GetTransactionBySenderAndNonce(sender, uint64(nonce))
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		// No finalized transaction, try to retrieve it from the pool
+		pending, queued := api.b.TxPoolContentFrom(sender)
+		for _, tx := range filterPrivate(ctx, api.b, append(pending, queued...)) {
+			if tx.Nonce() == uint64(nonce) {
+				return NewRPCPendingTransaction(tx, api.b.CurrentHeader(), api.b.ChainConfig()), nil
+			}
+		}
+		// If also not in the pool there is a chance the address indexer is still in progress.
+		if !api.b.AddrIndexDone() {
+			return nil, errAddrIndexing
+		}
+		return nil, nil
+	}
+	header, err := api.b.HeaderByHash(ctx, found.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	// The block may have been pruned or reorged out since it was indexed
+	if header == nil {
+		return nil, nil
+	}
+	return newRPCTransaction(found.Tx, found.BlockHash, found.BlockNumber, header.Time, found.Index, header.BaseFee, api.b.ChainConfig()), nil
+}
+
+// AddressTransactions is a page of the transactions of an account. The cursor is
+// set if there are more transactions, to be passed to the next query.
+type AddressTransactions struct {
+	Transactions []*RPCTransaction `json:"transactions"`
+	Cursor       hexutil.Bytes     `json:"cursor,omitempty"`
+}
+
+// GetTransactionsByAddress returns the canonical transactions sent, received or
+// created by the given account in chain order, at most limit of them starting at
+// the cursor returned by the previous page. The address index must be enabled,
+// and only the transactions of the indexed blocks are returned.
+func (api *TransactionAPI) GetTransactionsByAddress(ctx context.Context, address common.Address, limit *hexutil.Uint64, cursor *hexutil.Bytes) (*AddressTransactions, error) {
+	count := defaultAddrTxsLimit
+	if limit != nil {
+		if *limit == 0 || *limit > maxAddrTxsLimit {
+			return nil, fmt.Errorf("limit must be between 1 and %d", maxAddrTxsLimit)
+		}
+		count = int(*limit)
+	}
+	var (
+		number uint64
+		index  uint32
+	)
+	if cursor != nil {
+		if len(*cursor) != 12 {
+			return nil, errors.New("invalid cursor")
+		}
+		number, index = binary.BigEndian.Uint64(*cursor), binary.BigEndian.Uint32((*cursor)[8:])
+	}
+	if !api.b.AddrIndexDone() {
+		return nil, errAddrIndexing
+	}
+	txs, next, err := api.b.GetTransactionsByAddress(address, number, index, count)
+	if err != nil {
+		return nil, err
+	}
+	result := &AddressTransactions{Transactions: make([]*RPCTransaction, 0, len(txs))}
+	for _, tx := range txs {
+		header, err := api.b.HeaderByHash(ctx, tx.BlockHash)
+		if err != nil {
+			return nil, err
+		}
+		// Skip the transactions of blocks pruned or reorged out since indexing
+		if header == nil {
+			continue
+		}
+		result.Transactions = append(result.Transactions, newRPCTransaction(tx.Tx, tx.BlockHash, tx.BlockNumber, header.Time, tx.Index, header.BaseFee, api.b.ChainConfig()))
+	}
+	if next != nil {
+		result.Cursor = binary.BigEndian.AppendUint32(binary.BigEndian.AppendUint64(nil, next.Number), next.Index)
+	}
+	return result, nil
+}
+
 // GetTransactionReceipt returns the transaction receipt for the given transaction hash.
 func (api *TransactionAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
 	found, tx, blockHash, blockNumber, index := api.b.GetCanonicalTransaction(hash)
@@ -1636,16 +1960,25 @@ func (api *TransactionAPI) sign(addr common.Address, tx *types.Transaction) (*ty
 	return wallet.SignTx(account, tx, api.b.ChainConfig().ChainID)
 }
 
-// SubmitTransaction is a helper function that submits tx to txPool and logs a message.
-func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (common.Hash, error) {
+// checkSubmission ensures a transaction submitted over RPC pays a reasonable fee
+// and is replay-protected, if required.
+func checkSubmission(b Backend, tx *types.Transaction) error {
 	// If the transaction fee cap is already specified, ensure the
 	// fee of the given transaction is _reasonable_.
 	if err := checkTxFee(tx.GasPrice(), tx.Gas(), b.RPCTxFeeCap()); err != nil {
-		return common.Hash{}, err
+		return err
 	}
 	if !b.UnprotectedAllowed() && !tx.Protected() {
 		// Ensure only eip155 signed transactions are submitted if EIP155Required is set.
-		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
+		return errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
+	}
+	return nil
+}
+
+// SubmitTransaction is a helper function that submits tx to txPool and logs a message.
+func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (common.Hash, error) {
+	if err := checkSubmission(b, tx); err != nil {
+		return common.Hash{}, err
 	}
 	if err := b.SendTx(ctx, tx); err != nil {
 		return common.Hash{}, err
@@ -1756,6 +2089,48 @@ func (api *TransactionAPI) SendRawTransaction(ctx context.Context, input hexutil
 	return SubmitTransaction(ctx, api.b, tx)
 }
 
+// SendPrivateRawTransaction adds the signed transaction to the transaction pool
+// without propagating it to the network, so that only the local miner includes
+// it. The transaction is dropped if it's not included by the given block number,
+// which defaults to 25 blocks after the current head.
+func (api *TransactionAPI) SendPrivateRawTransaction(ctx context.Context, input hexutil.Bytes, maxBlockNumber *hexutil.Uint64) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+
+	// Convert legacy blob transaction proofs.
+	// TODO: remove in go-ethereum v1.17.x
+	if sc := tx.BlobTxSidecar(); sc != nil {
+		exp := api.currentBlobSidecarVersion()
+		if sc.Version == types.BlobSidecarVersion0 && exp == types.BlobSidecarVersion1 {
+			if err := sc.ToV1(); err != nil {
+				return common.Hash{}, fmt.Errorf("blob sidecar conversion failed: %v", err)
+			}
+			tx = tx.WithBlobTxSidecar(sc)
+		}
+	}
+	head := api.b.CurrentBlock().Number.Uint64()
+	expiry := head + defaultPrivateTxLifetime
+	if maxBlockNumber != nil {
+		expiry = uint64(*maxBlockNumber)
+		if expiry <= head {
+			return common.Hash{}, fmt.Errorf("max block number %d already reached (head %d)", expiry, head)
+		}
+		if expiry > head+maxPrivateTxLifetime {
+			return common.Hash{}, fmt.Errorf("max block number %d too far in the future (max %d)", expiry, head+maxPrivateTxLifetime)
+		}
+	}
+	if err := checkSubmission(api.b, tx); err != nil {
+		return common.Hash{}, err
+	}
+	if err := api.b.SendPrivateTx(ctx, tx, expiry); err != nil {
+		return common.Hash{}, err
+	}
+	log.Info("Submitted private transaction", "hash", tx.Hash().Hex(), "nonce", tx.Nonce(), "expiry", expiry)
+	return tx.Hash(), nil
+}
+
 // SendRawTransactionSync will add the signed transaction to the transaction pool
 // and wait until the transaction has been included in a block and return the receipt, or the timeout.
 func (api *TransactionAPI) SendRawTransactionSync(ctx context.Context, input hexutil.Bytes, timeoutMs *uint64) (map[string]interface{}, error) {
@@ -1936,11 +2311,12 @@ func (api *TransactionAPI) SignTransaction(ctx context.Context, args Transaction
 
 // PendingTransactions returns the transactions that are in the transaction pool
 // and have a from address that is one of the accounts this node manages.
-func (api *TransactionAPI) PendingTransactions() ([]*RPCTransaction, error) {
+func (api *TransactionAPI) PendingTransactions(ctx context.Context) ([]*RPCTransaction, error) {
 	pending, err := api.b.GetPoolTransactions()
 	if err != nil {
 		return nil, err
 	}
+	pending = filterPrivate(ctx, api.b, pending)
 	accounts := make(map[common.Address]struct{})
 	for _, wallet := range api.b.AccountManager().Wallets() {
 		for _, account := range wallet.Accounts() {
@@ -2093,7 +2469,7 @@ func (api *DebugAPI) GetRawTransaction(ctx context.Context, hash common.Hash) (h
 	// Retrieve a finalized transaction, or a pooled otherwise
 	found, tx, _, _, _ := api.b.GetCanonicalTransaction(hash)
 	if !found {
-		if tx = api.b.GetPoolTransaction(hash); tx != nil {
+		if tx = api.b.GetPoolTransaction(hash); tx != nil && !hidePrivate(ctx, api.b, hash) {
 			return tx.MarshalBinary()
 		}
 		// If also not in the pool there is a chance the tx indexer is still in progress.
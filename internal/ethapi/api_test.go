@@ -46,10 +46,12 @@ import (
 	"github.com/ethereum/go-ethereum/core/filtermaps"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/internal/blocktest"
@@ -499,6 +501,9 @@ func (b testBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
 func (b testBackend) FeeHistory(ctx context.Context, blockCount uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, []*big.Int, []float64, error) {
 	return nil, nil, nil, nil, nil, nil, nil
 }
+func (b testBackend) FeeEstimate(ctx context.Context, blobBlocks uint64) (*gasprice.FeeEstimate, error) {
+	return nil, nil
+}
 func (b testBackend) BlobBaseFee(ctx context.Context) *big.Int { return new(big.Int) }
 func (b testBackend) BaseFee(ctx context.Context) *big.Int     { return new(big.Int) }
 func (b testBackend) ChainDb() ethdb.Database                  { return b.db }
@@ -582,7 +587,7 @@ func (b testBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOr
 	}
 	panic("only implemented for number")
 }
-func (b testBackend) Pending() (*types.Block, types.Receipts, *state.StateDB) {
+func (b testBackend) Pending(ctx context.Context) (*types.Block, types.Receipts, *state.StateDB) {
 	block := b.pending
 	if block == nil {
 		return nil, nil, nil
@@ -671,6 +676,15 @@ func (b *testBackend) GetCanonicalReceipt(tx *types.Transaction, blockHash commo
 func (b testBackend) TxIndexDone() bool {
 	return true
 }
+func (b testBackend) GetTransactionBySenderAndNonce(sender common.Address, nonce uint64) (*core.AddrTransaction, error) {
+	return b.chain.GetTransactionBySenderAndNonce(sender, nonce)
+}
+func (b testBackend) GetTransactionsByAddress(addr common.Address, number uint64, index uint32, limit int) ([]*core.AddrTransaction, *rawdb.AddrTxEntry, error) {
+	return b.chain.GetTransactionsByAddress(addr, number, index, limit)
+}
+func (b testBackend) AddrIndexDone() bool {
+	return true
+}
 func (b testBackend) GetPoolTransactions() (types.Transactions, error)         { panic("implement me") }
 func (b testBackend) GetPoolTransaction(txHash common.Hash) *types.Transaction { panic("implement me") }
 func (b testBackend) GetPoolNonce(ctx context.Context, addr common.Address) (uint64, error) {
@@ -683,9 +697,16 @@ func (b testBackend) TxPoolContent() (map[common.Address][]*types.Transaction, m
 func (b testBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
 	panic("implement me")
 }
+func (b testBackend) TxPoolPrivate() map[common.Hash]uint64 { panic("implement me") }
+func (b testBackend) SendPrivateTx(ctx context.Context, signedTx *types.Transaction, expiry uint64) error {
+	panic("implement me")
+}
 func (b testBackend) SubscribeNewTxsEvent(events chan<- core.NewTxsEvent) event.Subscription {
 	panic("implement me")
 }
+func (b testBackend) SubscribeTxLifecycleEvent(events chan<- txpool.LifecycleEvent) event.Subscription {
+	panic("implement me")
+}
 func (b testBackend) ChainConfig() *params.ChainConfig { return b.chain.Config() }
 func (b testBackend) Engine() consensus.Engine         { return b.chain.Engine() }
 func (b testBackend) GetLogs(ctx context.Context, blockHash common.Hash, number uint64) ([][]*types.Log, error) {
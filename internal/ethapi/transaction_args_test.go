@@ -31,9 +31,12 @@ import (
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/filtermaps"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/params"
@@ -338,6 +341,9 @@ func (b *backendMock) SyncProgress(ctx context.Context) ethereum.SyncProgress {
 func (b *backendMock) FeeHistory(ctx context.Context, blockCount uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, []*big.Int, []float64, error) {
 	return nil, nil, nil, nil, nil, nil, nil
 }
+func (b *backendMock) FeeEstimate(ctx context.Context, blobBlocks uint64) (*gasprice.FeeEstimate, error) {
+	return nil, nil
+}
 func (b *backendMock) ChainDb() ethdb.Database           { return nil }
 func (b *backendMock) AccountManager() *accounts.Manager { return nil }
 func (b *backendMock) ExtRPCEnabled() bool               { return false }
@@ -374,7 +380,7 @@ func (b *backendMock) StateAndHeaderByNumber(ctx context.Context, number rpc.Blo
 func (b *backendMock) StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
 	return nil, nil, nil
 }
-func (b *backendMock) Pending() (*types.Block, types.Receipts, *state.StateDB) { return nil, nil, nil }
+func (b *backendMock) Pending(ctx context.Context) (*types.Block, types.Receipts, *state.StateDB) { return nil, nil, nil }
 func (b *backendMock) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
 	return nil, nil
 }
@@ -395,7 +401,14 @@ func (b *backendMock) SendTx(ctx context.Context, signedTx *types.Transaction) e
 func (b *backendMock) GetCanonicalTransaction(txHash common.Hash) (bool, *types.Transaction, common.Hash, uint64, uint64) {
 	return false, nil, [32]byte{}, 0, 0
 }
+func (b *backendMock) GetTransactionBySenderAndNonce(sender common.Address, nonce uint64) (*core.AddrTransaction, error) {
+	return nil, nil
+}
+func (b *backendMock) GetTransactionsByAddress(addr common.Address, number uint64, index uint32, limit int) ([]*core.AddrTransaction, *rawdb.AddrTxEntry, error) {
+	return nil, nil, nil
+}
 func (b *backendMock) TxIndexDone() bool                                        { return true }
+func (b *backendMock) AddrIndexDone() bool                                      { return true }
 func (b *backendMock) GetPoolTransactions() (types.Transactions, error)         { return nil, nil }
 func (b *backendMock) GetPoolTransaction(txHash common.Hash) *types.Transaction { return nil }
 func (b *backendMock) GetPoolNonce(ctx context.Context, addr common.Address) (uint64, error) {
@@ -413,6 +426,13 @@ func (b *backendMock) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscript
 func (b *backendMock) SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription {
 	return nil
 }
+func (b *backendMock) SubscribeTxLifecycleEvent(chan<- txpool.LifecycleEvent) event.Subscription {
+	return nil
+}
+func (b *backendMock) SendPrivateTx(ctx context.Context, signedTx *types.Transaction, expiry uint64) error {
+	return nil
+}
+func (b *backendMock) TxPoolPrivate() map[common.Hash]uint64 { return nil }
 
 func (b *backendMock) Engine() consensus.Engine { return nil }
 
@@ -25,6 +25,7 @@ var Modules = map[string]string{
 	"miner":  MinerJs,
 	"net":    NetJs,
 	"rpc":    RpcJs,
+	"trace":  TraceJs,
 	"txpool": TxpoolJs,
 	"dev":    DevJs,
 }
@@ -110,6 +111,32 @@ web3._extend({
 			call: 'admin_removeTrustedPeer',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'peerReputation',
+			call: 'admin_peerReputation',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'setPeerReputation',
+			call: 'admin_setPeerReputation',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'setNetRestrict',
+			call: 'admin_setNetRestrict',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'banPeer',
+			call: 'admin_banPeer',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Method({
+			name: 'unbanPeer',
+			call: 'admin_unbanPeer',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'exportChain',
 			call: 'admin_exportChain',
@@ -163,12 +190,21 @@ web3._extend({
 			call: 'debug_clearTxpool',
 			params: 0
 		}),
+		new web3._extend.Method({
+			name: 'setTxPolicyLists',
+			call: 'admin_setTxPolicyLists',
+			params: 1
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
 			name: 'nodeInfo',
 			getter: 'admin_nodeInfo'
 		}),
+		new web3._extend.Property({
+			name: 'txPolicyLists',
+			getter: 'admin_txPolicyLists'
+		}),
 		new web3._extend.Property({
 			name: 'peers',
 			getter: 'admin_peers'
@@ -531,6 +567,12 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputTransactionFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'sendPrivateRawTransaction',
+			call: 'eth_sendPrivateRawTransaction',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
 		new web3._extend.Method({
 			name: 'getHeaderByNumber',
 			call: 'eth_getHeaderByNumber',
@@ -567,6 +609,18 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.utils.toHex]
 		}),
+		new web3._extend.Method({
+			name: 'getTransactionBySenderAndNonce',
+			call: 'eth_getTransactionBySenderAndNonce',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.utils.toHex]
+		}),
+		new web3._extend.Method({
+			name: 'getTransactionsByAddress',
+			call: 'eth_getTransactionsByAddress',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, null]
+		}),
 		new web3._extend.Method({
 			name: 'getProof',
 			call: 'eth_getProof',
@@ -591,11 +645,22 @@ web3._extend({
 			params: 3,
 			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter, null]
 		}),
+		new web3._extend.Method({
+			name: 'feeEstimate',
+			call: 'eth_feeEstimate',
+			params: 1,
+			inputFormatter: [null]
+		}),
 		new web3._extend.Method({
 			name: 'getLogs',
 			call: 'eth_getLogs',
 			params: 1,
 		}),
+		new web3._extend.Method({
+			name: 'getLogsPage',
+			call: 'eth_getLogsPage',
+			params: 1,
+		}),
 		new web3._extend.Method({
 			name: 'call',
 			call: 'eth_call',
@@ -667,6 +732,11 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.utils.fromDecimal]
 		}),
+		new web3._extend.Method({
+			name: 'addBundle',
+			call: 'miner_addBundle',
+			params: 1
+		}),
 	],
 	properties: []
 });
@@ -698,6 +768,37 @@ web3._extend({
 });
 `
 
+const TraceJs = `
+web3._extend({
+	property: 'trace',
+	methods: [
+		new web3._extend.Method({
+			name: 'block',
+			call: 'trace_block',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'transaction',
+			call: 'trace_transaction',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'replayBlockTransactions',
+			call: 'trace_replayBlockTransactions',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'filter',
+			call: 'trace_filter',
+			params: 1
+		}),
+	],
+	properties: []
+});
+`
+
 const TxpoolJs = `
 web3._extend({
 	property: 'txpool',
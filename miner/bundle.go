@@ -0,0 +1,281 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+const (
+	// maxBundles is the maximum number of bundles waiting for inclusion.
+	maxBundles = 1024
+
+	// maxBundleTxs is the maximum number of transactions in a single bundle.
+	maxBundleTxs = 64
+)
+
+var (
+	errEmptyBundle       = errors.New("empty bundle")
+	errBundleTooLarge    = fmt.Errorf("bundle exceeds %d transactions", maxBundleTxs)
+	errBundleBlobTx      = errors.New("blob transactions are not supported in bundles")
+	errBundleRange       = errors.New("invalid bundle block range")
+	errBundleExpired     = errors.New("bundle block range already passed")
+	errBundleKnown       = errors.New("bundle already known")
+	errBundlePoolFull    = errors.New("too many pending bundles")
+	errBundleTxReverted  = errors.New("bundle transaction reverted")
+	errBundleUnderpriced = errors.New("bundle pays no tip")
+)
+
+// Bundle is an ordered list of transactions which must be included in a block
+// consecutively and in full, or not at all.
+type Bundle struct {
+	Txs       []*types.Transaction // Transactions of the bundle, in inclusion order
+	MinBlock  uint64               // First block the bundle may be included in (0 = no lower bound)
+	MaxBlock  uint64               // Last block the bundle may be included in
+	Reverting []common.Hash        // Transactions allowed to revert without invalidating the bundle
+}
+
+// Hash returns the identifier of the bundle, the hash of its transaction hashes.
+func (b *Bundle) Hash() common.Hash {
+	hashes := make([]byte, 0, len(b.Txs)*common.HashLength)
+	for _, tx := range b.Txs {
+		hashes = append(hashes, tx.Hash().Bytes()...)
+	}
+	return crypto.Keccak256Hash(hashes)
+}
+
+// eligible returns whether the bundle may be included in the given block.
+func (b *Bundle) eligible(number uint64) bool {
+	return b.MinBlock <= number && number <= b.MaxBlock
+}
+
+// simulatedBundle is a bundle which was executed successfully on top of the
+// block being built, along with the effective tip it pays.
+type simulatedBundle struct {
+	bundle *Bundle
+	tip    *uint256.Int // Average miner tip per gas unit paid by the bundle
+}
+
+// AddBundle queues a bundle for inclusion into the blocks built in its block
+// range. Bundles are simulated on top of each block being built and included
+// in full only if none of their transactions fail.
+func (miner *Miner) AddBundle(bundle *Bundle) (common.Hash, error) {
+	switch {
+	case len(bundle.Txs) == 0:
+		return common.Hash{}, errEmptyBundle
+	case len(bundle.Txs) > maxBundleTxs:
+		return common.Hash{}, errBundleTooLarge
+	case bundle.MaxBlock < bundle.MinBlock:
+		return common.Hash{}, errBundleRange
+	}
+	for _, tx := range bundle.Txs {
+		if tx.Type() == types.BlobTxType {
+			return common.Hash{}, errBundleBlobTx
+		}
+	}
+	next := miner.chain.CurrentBlock().Number.Uint64() + 1
+	if bundle.MaxBlock < next {
+		return common.Hash{}, errBundleExpired
+	}
+	hash := bundle.Hash()
+
+	miner.bundleMu.Lock()
+	defer miner.bundleMu.Unlock()
+
+	miner.pruneBundles(next)
+	if _, ok := miner.bundles[hash]; ok {
+		return common.Hash{}, errBundleKnown
+	}
+	if len(miner.bundles) >= maxBundles {
+		return common.Hash{}, errBundlePoolFull
+	}
+	miner.bundles[hash] = bundle
+	return hash, nil
+}
+
+// pruneBundles drops the bundles which can't be included in the given block or
+// any later one. The caller must hold bundleMu.
+func (miner *Miner) pruneBundles(number uint64) {
+	for hash, bundle := range miner.bundles {
+		if bundle.MaxBlock < number {
+			delete(miner.bundles, hash)
+		}
+	}
+}
+
+// eligibleBundles returns the bundles which may be included in the given block.
+func (miner *Miner) eligibleBundles(number uint64) []*Bundle {
+	miner.bundleMu.Lock()
+	defer miner.bundleMu.Unlock()
+
+	miner.pruneBundles(number)
+
+	var bundles []*Bundle
+	for _, bundle := range miner.bundles {
+		if bundle.eligible(number) {
+			bundles = append(bundles, bundle)
+		}
+	}
+	return bundles
+}
+
+// dropBundles removes the given bundles from the pending ones.
+func (miner *Miner) dropBundles(hashes []common.Hash) {
+	miner.bundleMu.Lock()
+	defer miner.bundleMu.Unlock()
+
+	for _, hash := range hashes {
+		delete(miner.bundles, hash)
+	}
+}
+
+// staleBundle reports whether a transaction of the bundle has a nonce which is
+// already used in the state, i.e. the bundle was included or can never be.
+func staleBundle(env *environment, bundle *Bundle) bool {
+	for _, tx := range bundle.Txs {
+		from, err := types.Sender(env.signer, tx)
+		if err != nil || tx.Nonce() < env.state.GetNonce(from) {
+			return true
+		}
+	}
+	return false
+}
+
+// simulateBundles executes the eligible bundles on top of the block being built,
+// returning the ones which succeeded sorted by decreasing effective tip. The
+// bundles which were included already or became invalid are dropped.
+func (miner *Miner) simulateBundles(env *environment) []*simulatedBundle {
+	var (
+		simulated []*simulatedBundle
+		stale     []common.Hash
+	)
+	for _, bundle := range miner.eligibleBundles(env.header.Number.Uint64()) {
+		if staleBundle(env, bundle) {
+			stale = append(stale, bundle.Hash())
+			continue
+		}
+		tip, err := miner.simulateBundle(env, bundle)
+		if err != nil {
+			log.Trace("Bundle simulation failed", "hash", bundle.Hash(), "err", err)
+			continue
+		}
+		simulated = append(simulated, &simulatedBundle{bundle: bundle, tip: tip})
+	}
+	miner.dropBundles(stale)
+
+	slices.SortStableFunc(simulated, func(a, b *simulatedBundle) int {
+		return b.tip.Cmp(a.tip)
+	})
+	return simulated
+}
+
+// simulateBundle executes the transactions of the bundle on a copy of the state
+// of the block being built, returning the average tip per gas unit it pays.
+// The environment is left unchanged.
+func (miner *Miner) simulateBundle(env *environment, bundle *Bundle) (*uint256.Int, error) {
+	var (
+		state = env.state.Copy()
+		gp    = env.gasPool.Snapshot()
+		evm   = vm.NewEVM(env.evm.Context, state, miner.chainConfig, vm.Config{})
+		fees  = new(uint256.Int)
+		gas   uint64
+	)
+	defer evm.Release()
+
+	for i, tx := range bundle.Txs {
+		state.SetTxContext(tx.Hash(), env.tcount+i, uint32(env.tcount+i+1))
+
+		receipt, _, err := core.ApplyTransaction(evm, gp, state, env.header, tx)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d (%x): %w", i, tx.Hash(), err)
+		}
+		if receipt.Status == types.ReceiptStatusFailed && !slices.Contains(bundle.Reverting, tx.Hash()) {
+			return nil, fmt.Errorf("transaction %d (%x): %w", i, tx.Hash(), errBundleTxReverted)
+		}
+		tip, err := tx.EffectiveGasTip(env.header.BaseFee)
+		if err != nil {
+			return nil, err
+		}
+		fees.Add(fees, new(uint256.Int).Mul(uint256.MustFromBig(tip), uint256.NewInt(receipt.GasUsed)))
+		gas += receipt.GasUsed
+	}
+	if gas == 0 || fees.IsZero() {
+		return nil, errBundleUnderpriced
+	}
+	return fees.Div(fees, uint256.NewInt(gas)), nil
+}
+
+// commitBundle includes all transactions of the bundle into the block being
+// built, or none of them if any fails. The bundle is simulated again on top of
+// the current state first, since the transactions included since the ranking
+// may have invalidated it. The block is only updated once all transactions
+// succeeded on the live state, the state changes are reverted otherwise.
+func (miner *Miner) commitBundle(env *environment, bundle *Bundle) error {
+	var size uint64
+	for _, tx := range bundle.Txs {
+		size += tx.Size()
+	}
+	if env.size+size >= params.MaxBlockSize-maxBlockSizeBufferZone {
+		return errors.New("bundle exceeds block size")
+	}
+	if _, err := miner.simulateBundle(env, bundle); err != nil {
+		return err
+	}
+	var (
+		snap     = env.state.Snapshot()
+		gp       = env.gasPool.Snapshot()
+		receipts = make([]*types.Receipt, 0, len(bundle.Txs))
+		bals     = make([]*bal.ConstructionBlockAccessList, 0, len(bundle.Txs))
+	)
+	for i, tx := range bundle.Txs {
+		env.state.SetTxContext(tx.Hash(), env.tcount+i, uint32(env.tcount+i+1))
+
+		receipt, txBal, err := miner.applyTransaction(env, tx)
+		if err == nil && receipt.Status == types.ReceiptStatusFailed && !slices.Contains(bundle.Reverting, tx.Hash()) {
+			err = errBundleTxReverted
+		}
+		if err != nil {
+			log.Error("Bundle transaction failed after simulation", "hash", bundle.Hash(), "index", i, "err", err)
+			env.state.RevertToSnapshot(snap)
+			env.gasPool.Set(gp)
+			env.header.GasUsed = env.gasPool.Used()
+			return err
+		}
+		receipts = append(receipts, receipt)
+		bals = append(bals, txBal)
+	}
+	env.txs = append(env.txs, bundle.Txs...)
+	env.receipts = append(env.receipts, receipts...)
+	env.size += size
+	env.tcount += len(bundle.Txs)
+	for _, txBal := range bals {
+		env.bal.Merge(txBal)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestBundleInclusion(t *testing.T) {
+	var (
+		searcherKey, _  = crypto.GenerateKey()
+		searcherAddress = crypto.PubkeyToAddress(searcherKey.PublicKey)
+		signer          = types.LatestSigner(params.TestChainConfig)
+		gspec           = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				testBankAddress: {Balance: testBankFunds},
+				searcherAddress: {Balance: testBankFunds},
+			},
+		}
+	)
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), gspec, ethash.NewFaker(), nil)
+	if err != nil {
+		t.Fatalf("Failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	pool, _ := txpool.New(testTxPoolConfig.PriceLimit, chain, []txpool.SubPool{legacypool.New(testTxPoolConfig, chain)})
+	defer pool.Close()
+
+	makeTx := func(key *ecdsa.PrivateKey, nonce uint64, tip int64) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			Nonce:     nonce,
+			To:        &testUserAddress,
+			Value:     big.NewInt(1000),
+			Gas:       params.TxGas,
+			GasTipCap: big.NewInt(tip * params.GWei),
+			GasFeeCap: big.NewInt(100 * params.GWei),
+		})
+	}
+	poolTx := makeTx(testBankKey, 0, 1)
+	if errs := pool.Add([]*types.Transaction{poolTx}, true); errs[0] != nil {
+		t.Fatalf("Failed to add pool transaction: %v", errs[0])
+	}
+	miner := New(&testWorkerBackend{chain: chain, txPool: pool}, testConfig, ethash.NewFaker())
+
+	// The first bundle outbids the pool, the second one can't be executed fully
+	// and must be left out entirely
+	good := &Bundle{Txs: []*types.Transaction{makeTx(searcherKey, 0, 5), makeTx(searcherKey, 1, 5)}, MaxBlock: 2}
+	bad := &Bundle{Txs: []*types.Transaction{makeTx(testBankKey, 0, 10), makeTx(searcherKey, 5, 10)}, MaxBlock: 1}
+	future := &Bundle{Txs: []*types.Transaction{makeTx(searcherKey, 2, 20)}, MinBlock: 2, MaxBlock: 2}
+
+	for _, bundle := range []*Bundle{good, bad, future} {
+		if _, err := miner.AddBundle(bundle); err != nil {
+			t.Fatalf("Failed to add bundle: %v", err)
+		}
+	}
+	if _, err := miner.AddBundle(good); !errors.Is(err, errBundleKnown) {
+		t.Fatalf("duplicate bundle: have %v, want %v", err, errBundleKnown)
+	}
+	if _, err := miner.AddBundle(&Bundle{Txs: good.Txs, MaxBlock: 0}); !errors.Is(err, errBundleExpired) {
+		t.Fatalf("expired bundle: have %v, want %v", err, errBundleExpired)
+	}
+	timestamp := uint64(time.Now().Unix())
+	generate := func(want []common.Hash) *types.Block {
+		t.Helper()
+
+		result := miner.generateWork(context.Background(), &generateParams{
+			timestamp: timestamp,
+			coinbase:  common.HexToAddress("0xdeadbeef"),
+		}, false)
+		if result.err != nil {
+			t.Fatalf("Failed to generate work: %v", result.err)
+		}
+		txs := result.block.Transactions()
+		if len(txs) != len(want) {
+			t.Fatalf("wrong transaction count: have %d, want %d", len(txs), len(want))
+		}
+		for i, tx := range txs {
+			if tx.Hash() != want[i] {
+				t.Errorf("transaction %d: have %x, want %x", i, tx.Hash(), want[i])
+			}
+		}
+		return result.block
+	}
+	block := generate([]common.Hash{good.Txs[0].Hash(), good.Txs[1].Hash(), poolTx.Hash()})
+
+	// Once the bundle is included, it must be dropped instead of simulated again
+	if _, err := chain.InsertChain(types.Blocks{block}); err != nil {
+		t.Fatalf("Failed to insert block: %v", err)
+	}
+	timestamp++
+	generate([]common.Hash{future.Txs[0].Hash()})
+
+	miner.bundleMu.Lock()
+	defer miner.bundleMu.Unlock()
+	if _, ok := miner.bundles[good.Hash()]; ok {
+		t.Error("included bundle not dropped")
+	}
+	if _, ok := miner.bundles[future.Hash()]; !ok {
+		t.Error("pending bundle dropped")
+	}
+}
@@ -30,7 +30,9 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/txorder"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -50,6 +52,8 @@ type Config struct {
 	GasPrice            *big.Int       // Minimum gas price for mining a transaction
 	Recommit            time.Duration  // The time interval for miner to re-create mining work.
 	MaxBlobsPerBlock    int            // Maximum number of blobs per block (0 for unset uses protocol default)
+	TxOrdering          string         `toml:",omitempty"` // Transaction ordering strategy for payload building (empty = price)
+	TxOrderingSenderCap int            `toml:",omitempty"` // Maximum number of transactions per sender of the sender ordering (0 = unlimited)
 }
 
 // DefaultConfig contains default settings for miner.
@@ -73,20 +77,34 @@ type Miner struct {
 	engine      consensus.Engine
 	txpool      *txpool.TxPool
 	prio        []common.Address // A list of senders to prioritize
+	order       txorder.Strategy // The ordering strategy of the pending transactions
 	chain       *core.BlockChain
 	pending     *pending
-	pendingMu   sync.Mutex // Lock protects the pending block
+	public      *pending   // Pending block without the private pool transactions
+	pendingMu   sync.Mutex // Lock protects the pending blocks
+
+	bundles  map[common.Hash]*Bundle // Bundles waiting for inclusion, keyed by hash
+	bundleMu sync.Mutex              // Lock protects the bundles
 }
 
-// New creates a new miner with provided config.
+// New creates a new miner with provided config. If the configured transaction
+// ordering is invalid, the price ordering is used.
 func New(eth Backend, config Config, engine consensus.Engine) *Miner {
+	order, err := txorder.New(config.TxOrdering, txorder.Config{SenderCap: config.TxOrderingSenderCap})
+	if err != nil {
+		log.Error("Invalid miner transaction ordering, using price ordering", "ordering", config.TxOrdering, "err", err)
+		order, _ = txorder.New(txorder.PriceOrdering, txorder.Config{})
+	}
 	return &Miner{
 		config:      &config,
 		chainConfig: eth.BlockChain().Config(),
 		engine:      engine,
 		txpool:      eth.TxPool(),
+		order:       order,
 		chain:       eth.BlockChain(),
 		pending:     &pending{},
+		public:      &pending{},
+		bundles:     make(map[common.Hash]*Bundle),
 	}
 }
 
@@ -94,7 +112,18 @@ func New(eth Backend, config Config, engine consensus.Engine) *Miner {
 // and statedb. The returned values can be nil in case the pending block is
 // not initialized.
 func (miner *Miner) Pending() (*types.Block, types.Receipts, *state.StateDB) {
-	pending := miner.getPending()
+	pending := miner.getPending(false)
+	if pending == nil {
+		return nil, nil, nil
+	}
+	return pending.block, pending.receipts, pending.stateDB.Copy()
+}
+
+// PendingPublic is like Pending, but the returned block doesn't include any of
+// the private pool transactions, so it can be served to callers who must not
+// learn about them.
+func (miner *Miner) PendingPublic() (*types.Block, types.Receipts, *state.StateDB) {
+	pending := miner.getPending(len(miner.txpool.PrivateTxs()) > 0)
 	if pending == nil {
 		return nil, nil, nil
 	}
@@ -140,14 +169,19 @@ func (miner *Miner) BuildPayload(ctx context.Context, args *BuildPayloadArgs, wi
 	return miner.buildPayload(ctx, args, witness)
 }
 
-// getPending retrieves the pending block based on the current head block.
-// The result might be nil if pending generation is failed.
-func (miner *Miner) getPending() *newPayloadResult {
+// getPending retrieves the pending block based on the current head block,
+// optionally excluding the private pool transactions. The result might be nil
+// if pending generation is failed.
+func (miner *Miner) getPending(noPrivate bool) *newPayloadResult {
 	header := miner.chain.CurrentHeader()
 	miner.pendingMu.Lock()
 	defer miner.pendingMu.Unlock()
 
-	if cached := miner.pending.resolve(header.Hash()); cached != nil {
+	cache := miner.pending
+	if noPrivate {
+		cache = miner.public
+	}
+	if cached := cache.resolve(header.Hash()); cached != nil {
 		return cached
 	}
 	var (
@@ -180,10 +214,11 @@ func (miner *Miner) getPending() *newPayloadResult {
 			beaconRoot:  nil,
 			slotNum:     slotNum,
 			noTxs:       false,
+			noPrivate:   noPrivate,
 		}, false) // we will never make a witness for a pending block
 	if ret.err != nil {
 		return nil
 	}
-	miner.pending.update(header.Hash(), ret)
+	cache.update(header.Hash(), ret)
 	return ret
 }
@@ -24,6 +24,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
@@ -110,6 +111,27 @@ func TestBuildPendingBlocks(t *testing.T) {
 	wg.Wait()
 }
 
+func TestBuildPendingPublic(t *testing.T) {
+	miner, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+	if err := b.txPool.AddPrivate(newTxs[0], 100, true); err != nil {
+		t.Fatalf("failed to add private transaction: %v", err)
+	}
+	block, _, _ := miner.Pending()
+	if block == nil || len(block.Transactions()) != 2 {
+		t.Fatalf("pending block doesn't include all transactions")
+	}
+	block, receipts, state := miner.PendingPublic()
+	if block == nil || len(block.Transactions()) != 1 || len(receipts) != 1 {
+		t.Fatalf("public pending block includes private transactions")
+	}
+	if block.Transactions()[0].Hash() != pendingTxs[0].Hash() {
+		t.Fatalf("public pending block doesn't include public transaction")
+	}
+	if nonce := state.GetNonce(testBankAddress); nonce != 1 {
+		t.Fatalf("public pending state has wrong nonce: have %d, want 1", nonce)
+	}
+}
+
 func minerTestGenesisBlock(period uint64, gasLimit uint64, faucet common.Address) *core.Genesis {
 	config := *params.AllCliqueProtocolChanges
 	config.Clique = &params.CliqueConfig{
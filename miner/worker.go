@@ -127,6 +127,7 @@ type generateParams struct {
 	slotNum        *uint64           // The slot number (amsterdam field).
 	targetGasLimit *uint64           // The target gas limit requested by the CL (amsterdam field).
 	noTxs          bool              // Flag whether an empty block without any transaction is expected
+	noPrivate      bool              // Flag whether the private pool transactions must be excluded
 
 	forceOverrides    bool // Flag whether we should overwrite extraData and transactions
 	overrideExtraData []byte
@@ -184,7 +185,7 @@ func (miner *Miner) generateWork(ctx context.Context, genParam *generateParams,
 			})
 			defer timer.Stop()
 
-			err := miner.fillTransactions(ctx, interrupt, work)
+			err := miner.fillTransactions(ctx, interrupt, work, genParam.noPrivate)
 			if errors.Is(err, errBlockInterruptedByTimeout) {
 				log.Warn("Block building is interrupted", "allowance", common.PrettyDuration(miner.config.Recommit))
 			}
@@ -432,7 +433,7 @@ func (miner *Miner) applyTransaction(env *environment, tx *types.Transaction) (*
 	return receipt, bal, nil
 }
 
-func (miner *Miner) commitTransactions(ctx context.Context, env *environment, plainTxs, blobTxs *txorder.TransactionsByPriceAndNonce, interrupt *atomic.Int32) error {
+func (miner *Miner) commitTransactions(ctx context.Context, env *environment, plainTxs, blobTxs txorder.TransactionSet, bundles []*simulatedBundle, interrupt *atomic.Int32) error {
 	ctx, _, spanEnd := telemetry.StartSpan(ctx, "miner.commitTransactions")
 	defer spanEnd(nil)
 
@@ -459,22 +460,29 @@ func (miner *Miner) commitTransactions(ctx context.Context, env *environment, pl
 		// Retrieve the next transaction and abort if all done.
 		var (
 			ltx *txpool.LazyTransaction
-			txs *txorder.TransactionsByPriceAndNonce
+			tip *uint256.Int
+			txs txorder.TransactionSet
 		)
 		pltx, ptip := plainTxs.Peek()
 		bltx, btip := blobTxs.Peek()
 
 		switch {
 		case pltx == nil:
-			txs, ltx = blobTxs, bltx
+			txs, ltx, tip = blobTxs, bltx, btip
 		case bltx == nil:
-			txs, ltx = plainTxs, pltx
+			txs, ltx, tip = plainTxs, pltx, ptip
+		case txorder.First(plainTxs, blobTxs) == blobTxs:
+			txs, ltx, tip = blobTxs, bltx, btip
 		default:
-			if ptip.Lt(btip) {
-				txs, ltx = blobTxs, bltx
-			} else {
-				txs, ltx = plainTxs, pltx
+			txs, ltx, tip = plainTxs, pltx, ptip
+		}
+		// Include the bundles paying at least as much as the next transaction
+		if len(bundles) > 0 && (ltx == nil || !bundles[0].tip.Lt(tip)) {
+			if err := miner.commitBundle(env, bundles[0].bundle); err != nil {
+				log.Debug("Bundle failed, skipped", "hash", bundles[0].bundle.Hash(), "err", err)
 			}
+			bundles = bundles[1:]
+			continue
 		}
 		if ltx == nil {
 			break
@@ -547,9 +555,9 @@ func (miner *Miner) commitTransactions(ctx context.Context, env *environment, pl
 }
 
 // fillTransactions retrieves the pending transactions from the txpool and fills them
-// into the given sealing block. The transaction selection and ordering strategy can
-// be customized with the plugin in the future.
-func (miner *Miner) fillTransactions(ctx context.Context, interrupt *atomic.Int32, env *environment) (err error) {
+// into the given sealing block, in the order of the configured ordering strategy.
+// If noPrivate is set, the private pool transactions are left out.
+func (miner *Miner) fillTransactions(ctx context.Context, interrupt *atomic.Int32, env *environment, noPrivate bool) (err error) {
 	ctx, span, spanEnd := telemetry.StartSpan(ctx, "miner.fillTransactions")
 	defer spanEnd(&err)
 
@@ -581,6 +589,12 @@ func (miner *Miner) fillTransactions(ctx context.Context, interrupt *atomic.Int3
 		filter.BlobVersion = types.BlobSidecarVersion0
 	}
 	pendingBlobTxs, blobTxCount := miner.txpool.Pending(filter)
+
+	if noPrivate {
+		private := miner.txpool.PrivateTxs()
+		dropPrivate(pendingPlainTxs, private)
+		dropPrivate(pendingBlobTxs, private)
+	}
 	span.SetAttributes(
 		telemetry.IntAttribute("pending.plain.count", plainTxCount),
 		telemetry.IntAttribute("pending.blob.count", blobTxCount),
@@ -602,24 +616,46 @@ func (miner *Miner) fillTransactions(ctx context.Context, interrupt *atomic.Int3
 	}
 	// Fill the block with all available pending transactions.
 	if len(prioPlainTxs) > 0 || len(prioBlobTxs) > 0 {
-		plainTxs := txorder.NewTransactionsByPriceAndNonce(env.signer, prioPlainTxs, env.header.BaseFee)
-		blobTxs := txorder.NewTransactionsByPriceAndNonce(env.signer, prioBlobTxs, env.header.BaseFee)
+		plainTxs := miner.order(env.signer, prioPlainTxs, env.header.BaseFee)
+		blobTxs := miner.order(env.signer, prioBlobTxs, env.header.BaseFee)
 
-		if err := miner.commitTransactions(ctx, env, plainTxs, blobTxs, interrupt); err != nil {
+		if err := miner.commitTransactions(ctx, env, plainTxs, blobTxs, nil, interrupt); err != nil {
 			return err
 		}
 	}
-	if len(normalPlainTxs) > 0 || len(normalBlobTxs) > 0 {
-		plainTxs := txorder.NewTransactionsByPriceAndNonce(env.signer, normalPlainTxs, env.header.BaseFee)
-		blobTxs := txorder.NewTransactionsByPriceAndNonce(env.signer, normalBlobTxs, env.header.BaseFee)
+	// Rank the bundles against the remote transactions by their effective tip
+	bundles := miner.simulateBundles(env)
+
+	if len(normalPlainTxs) > 0 || len(normalBlobTxs) > 0 || len(bundles) > 0 {
+		plainTxs := miner.order(env.signer, normalPlainTxs, env.header.BaseFee)
+		blobTxs := miner.order(env.signer, normalBlobTxs, env.header.BaseFee)
 
-		if err := miner.commitTransactions(ctx, env, plainTxs, blobTxs, interrupt); err != nil {
+		if err := miner.commitTransactions(ctx, env, plainTxs, blobTxs, bundles, interrupt); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// dropPrivate removes the private transactions from the pending transactions of
+// each account. As the transactions are nonce ordered, all transactions after a
+// private one are dropped too.
+func dropPrivate(pending map[common.Address][]*txpool.LazyTransaction, private map[common.Hash]uint64) {
+	for addr, txs := range pending {
+		for i, tx := range txs {
+			if _, ok := private[tx.Hash]; !ok {
+				continue
+			}
+			if i == 0 {
+				delete(pending, addr)
+			} else {
+				pending[addr] = txs[:i]
+			}
+			break
+		}
+	}
+}
+
 // totalFees computes total consumed miner fees in Wei. Block transactions and receipts have to have the same order.
 func totalFees(block *types.Block, receipts []*types.Receipt) *big.Int {
 	baseFee := block.BaseFee()
@@ -19,7 +19,9 @@ package node
 import (
 	"context"
 	"fmt"
+	"net/netip"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -28,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/netutil"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -120,6 +123,132 @@ func (api *adminAPI) RemoveTrustedPeer(url string) (bool, error) {
 	return true, nil
 }
 
+// PeerReputation returns the reputation score of a node, given by its enode URL
+// or hex encoded node ID.
+func (api *adminAPI) PeerReputation(node string) (float64, error) {
+	server := api.node.Server()
+	if server == nil {
+		return 0, ErrNodeStopped
+	}
+	id, err := parseNodeID(node)
+	if err != nil {
+		return 0, err
+	}
+	return server.Reputation(id), nil
+}
+
+// SetPeerReputation sets the reputation score of a node, given by its enode URL
+// or hex encoded node ID, returning the score after bounding it. Good scores make
+// the node preferred when dialing and protect it from being dropped, while bad
+// ones keep it from being dialed.
+func (api *adminAPI) SetPeerReputation(node string, score float64) (float64, error) {
+	server := api.node.Server()
+	if server == nil {
+		return 0, ErrNodeStopped
+	}
+	id, err := parseNodeID(node)
+	if err != nil {
+		return 0, err
+	}
+	return server.SetReputation(id, score)
+}
+
+// SetNetRestrict replaces the comma separated list of CIDR masks of the networks
+// peers must be in, lifting the restriction if empty. Connected peers outside of
+// the new list are disconnected.
+func (api *adminAPI) SetNetRestrict(cidrs string) (bool, error) {
+	server := api.node.Server()
+	if server == nil {
+		return false, ErrNodeStopped
+	}
+	var list *netutil.Netlist
+	if strings.TrimSpace(cidrs) != "" {
+		var err error
+		if list, err = netutil.ParseNetlist(cidrs); err != nil {
+			return false, fmt.Errorf("invalid netrestrict list: %v", err)
+		}
+	}
+	if err := server.SetNetRestrict(list); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// BanPeer disconnects and bans a node, given by its enode URL or hex encoded node
+// ID, or all nodes in a network, given by an IP address or CIDR mask. The ban
+// lasts for the given number of seconds, or forever if omitted or zero, and is
+// kept across restarts.
+func (api *adminAPI) BanPeer(target string, duration *uint64) (bool, error) {
+	server := api.node.Server()
+	if server == nil {
+		return false, ErrNodeStopped
+	}
+	var expires time.Time
+	if duration != nil && *duration > 0 {
+		expires = time.Now().Add(time.Duration(*duration) * time.Second)
+	}
+	id, network, err := parseBanTarget(target)
+	if err != nil {
+		return false, err
+	}
+	if network.IsValid() {
+		err = server.BanNetwork(network, expires)
+	} else {
+		err = server.BanNode(id, expires)
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UnbanPeer lifts the ban of a node or network.
+func (api *adminAPI) UnbanPeer(target string) (bool, error) {
+	server := api.node.Server()
+	if server == nil {
+		return false, ErrNodeStopped
+	}
+	id, network, err := parseBanTarget(target)
+	if err != nil {
+		return false, err
+	}
+	if network.IsValid() {
+		err = server.UnbanNetwork(network)
+	} else {
+		err = server.UnbanNode(id)
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// parseBanTarget parses the target of a ban, which is either a node given by its
+// enode URL or hex encoded node ID, or a network given by an IP address or CIDR
+// mask. The returned network is valid for network targets.
+func parseBanTarget(target string) (enode.ID, netip.Prefix, error) {
+	if network, err := netip.ParsePrefix(target); err == nil {
+		return enode.ID{}, network, nil
+	}
+	if ip, err := netip.ParseAddr(target); err == nil {
+		return enode.ID{}, netip.PrefixFrom(ip, ip.BitLen()), nil
+	}
+	id, err := parseNodeID(target)
+	return id, netip.Prefix{}, err
+}
+
+// parseNodeID parses an enode URL or a hex encoded node ID.
+func parseNodeID(node string) (enode.ID, error) {
+	if id, err := enode.ParseID(node); err == nil {
+		return id, nil
+	}
+	n, err := enode.Parse(enode.ValidSchemes, node)
+	if err != nil {
+		return enode.ID{}, fmt.Errorf("invalid enode: %v", err)
+	}
+	return n.ID(), nil
+}
+
 // PeerEvents creates an RPC subscription which receives peer events from the
 // node's p2p.Server
 func (api *adminAPI) PeerEvents(ctx context.Context) (*rpc.Subscription, error) {
@@ -181,6 +310,9 @@ func (api *adminAPI) StartHTTP(host *string, port *int, cors *string, apis *stri
 		rpcEndpointConfig: rpcEndpointConfig{
 			batchItemLimit:         api.node.config.BatchRequestLimit,
 			batchResponseSizeLimit: api.node.config.BatchResponseMaxSize,
+			rateLimit:              api.node.config.RPCRateLimit,
+			recorder:               api.node.rpcRecorder,
+			jwtKeys:                api.node.rpcJWTKeys,
 		},
 	}
 	if cors != nil {
@@ -259,6 +391,9 @@ func (api *adminAPI) StartWS(host *string, port *int, allowedOrigins *string, ap
 		rpcEndpointConfig: rpcEndpointConfig{
 			batchItemLimit:         api.node.config.BatchRequestLimit,
 			batchResponseSizeLimit: api.node.config.BatchResponseMaxSize,
+			rateLimit:              api.node.config.RPCRateLimit,
+			recorder:               api.node.rpcRecorder,
+			jwtKeys:                api.node.rpcJWTKeys,
 		},
 	}
 	if apis != nil {
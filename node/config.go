@@ -133,12 +133,20 @@ type Config struct {
 	// HTTPPathPrefix specifies a path prefix on which http-rpc is to be served.
 	HTTPPathPrefix string `toml:",omitempty"`
 
+	// HTTPTLS configures TLS and optional client certificate authentication on
+	// the HTTP RPC server.
+	HTTPTLS TLSConfig `toml:",omitempty"`
+
 	// AuthAddr is the listening address on which authenticated APIs are provided.
 	AuthAddr string `toml:",omitempty"`
 
 	// AuthPort is the port number on which authenticated APIs are provided.
 	AuthPort int `toml:",omitempty"`
 
+	// AuthTLS configures TLS and optional client certificate authentication on
+	// the authenticated API server.
+	AuthTLS TLSConfig `toml:",omitempty"`
+
 	// AuthVirtualHosts is the list of virtual hostnames which are allowed on incoming requests
 	// for the authenticated api. This is by default {'localhost'}.
 	AuthVirtualHosts []string `toml:",omitempty"`
@@ -155,6 +163,11 @@ type Config struct {
 	// WSPathPrefix specifies a path prefix on which ws-rpc is to be served.
 	WSPathPrefix string `toml:",omitempty"`
 
+	// WSTLS configures TLS and optional client certificate authentication on the
+	// websocket RPC server. When sharing the port of the HTTP server, both share
+	// the TLS configuration too.
+	WSTLS TLSConfig `toml:",omitempty"`
+
 	// WSOrigins is the list of domain to accept websocket requests from. Please be
 	// aware that the server can only act upon the HTTP request the client sends and
 	// cannot verify the validity of the request header.
@@ -201,9 +214,28 @@ type Config struct {
 	// HTTPBodyLimit is the maximum size (in bytes) of an HTTP request body.
 	HTTPBodyLimit int `toml:",omitempty"`
 
+	// RPCRateLimit configures per-client rate limiting for the HTTP and WebSocket
+	// RPC endpoints. On the authenticated endpoints, only the per-subject limits
+	// apply. Rate limiting is disabled by default.
+	RPCRateLimit rpc.RateLimitConfig `toml:",omitempty"`
+
+	// RPCRecordFile is the path of a file which receives a recording of all calls
+	// handled by the HTTP and WebSocket RPC endpoints, in the JSONL format of
+	// rpc.Recorder. The authenticated endpoints are not recorded.
+	RPCRecordFile string `toml:",omitempty"`
+
+	// Health configures the /health and /ready endpoints of the HTTP server.
+	Health HealthConfig `toml:",omitempty"`
+
 	// JWTSecret is the path to the hex-encoded jwt secret.
 	JWTSecret string `toml:",omitempty"`
 
+	// JWTKeys are the keys authenticating clients of the HTTP and WebSocket
+	// endpoints. If set, clients must present a token signed by one of the keys,
+	// and may only call the methods permitted for it. The authenticated engine
+	// API endpoints keep using JWTSecret.
+	JWTKeys []JWTKeyConfig `toml:",omitempty"`
+
 	// EnablePersonal enables the deprecated personal namespace.
 	EnablePersonal bool `toml:"-"`
 
@@ -21,6 +21,7 @@ import (
 	"os/user"
 	"path/filepath"
 	"runtime"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/p2p"
@@ -69,6 +70,11 @@ var DefaultConfig = Config{
 	BatchResponseMaxSize: 25 * 1000 * 1000,
 	HTTPBodyLimit:        5 * 1024 * 1024,
 	GraphQLVirtualHosts:  []string{"localhost"},
+	Health: HealthConfig{
+		MinPeers:         1,
+		MaxHeadAge:       2 * time.Minute,
+		MaxForkchoiceAge: 2 * time.Minute,
+	},
 	P2P: p2p.Config{
 		ListenAddr:  ":30303",
 		MaxPeers:    50,
@@ -0,0 +1,160 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	healthPath = "/health"
+	readyPath  = "/ready"
+)
+
+// HealthConfig configures the /health and /ready endpoints of the HTTP server,
+// which are meant for the liveness and readiness probes of orchestrators. The
+// node is healthy while it is running, and ready if all readiness checks pass.
+type HealthConfig struct {
+	// MinPeers is the minimum number of connected peers for the node to be
+	// ready, capped at the maximum number of peers. Zero disables the check.
+	MinPeers int `toml:",omitempty"`
+
+	// MaxHeadAge is the maximum age of the chain head for the node to be ready.
+	// Zero disables the check.
+	MaxHeadAge time.Duration `toml:",omitempty"`
+
+	// MaxForkchoiceAge is the maximum time since the last forkchoice update of
+	// the consensus client for the node to be ready. Zero disables the check.
+	MaxForkchoiceAge time.Duration `toml:",omitempty"`
+
+	// AllowSyncing makes the node ready while it is syncing.
+	AllowSyncing bool `toml:",omitempty"`
+
+	// The responses of the endpoints, the zero values selecting the defaults.
+	Healthy   HealthResponse `toml:",omitempty"`
+	Unhealthy HealthResponse `toml:",omitempty"`
+	Ready     HealthResponse `toml:",omitempty"`
+	NotReady  HealthResponse `toml:",omitempty"`
+}
+
+// HealthResponse configures the response of a health endpoint.
+type HealthResponse struct {
+	// Status is the HTTP status code, 200 for passing and 503 for failing
+	// checks by default.
+	Status int `toml:",omitempty"`
+
+	// Body is the JSON response body. By default, the result of each check is
+	// reported.
+	Body string `toml:",omitempty"`
+}
+
+// validate checks that the configured response bodies are valid JSON.
+func (c HealthConfig) validate() error {
+	for name, resp := range map[string]HealthResponse{"healthy": c.Healthy, "unhealthy": c.Unhealthy, "ready": c.Ready, "not ready": c.NotReady} {
+		if resp.Body != "" && !json.Valid([]byte(resp.Body)) {
+			return fmt.Errorf("invalid JSON body of %s response", name)
+		}
+		if resp.Status != 0 && (resp.Status < 100 || resp.Status > 599) {
+			return fmt.Errorf("invalid status code %d of %s response", resp.Status, name)
+		}
+	}
+	return nil
+}
+
+// HealthCheck reports whether a service is ready, returning the reason if not.
+type HealthCheck func() error
+
+// namedHealthCheck is a readiness check registered on the node.
+type namedHealthCheck struct {
+	name  string
+	check HealthCheck
+}
+
+// healthReport is the default response body of the health endpoints.
+type healthReport struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// healthHandler serves the /health and /ready endpoints.
+type healthHandler struct {
+	node *Node
+}
+
+// ServeHTTP implements http.Handler.
+func (h *healthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		config = h.node.config.Health
+		report = healthReport{Status: "ok"}
+		resp   HealthResponse
+	)
+	h.node.lock.Lock()
+	running := h.node.state == runningState
+	checks := h.node.readinessChecks
+	h.node.lock.Unlock()
+
+	switch r.URL.Path {
+	case healthPath:
+		resp = config.Healthy
+		if !running {
+			report.Status, resp = "fail", config.Unhealthy
+		}
+	case readyPath:
+		resp = config.Ready
+		report.Checks = make(map[string]string, len(checks))
+		for _, c := range checks {
+			if err := c.check(); err != nil {
+				report.Checks[c.name] = err.Error()
+				report.Status, resp = "fail", config.NotReady
+			} else {
+				report.Checks[c.name] = "ok"
+			}
+		}
+		if !running {
+			report.Status, resp = "fail", config.NotReady
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if resp.Status == 0 {
+		resp.Status = http.StatusOK
+		if report.Status != "ok" {
+			resp.Status = http.StatusServiceUnavailable
+		}
+	}
+	body := []byte(resp.Body)
+	if len(body) == 0 {
+		body, _ = json.Marshal(report)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(resp.Status)
+	w.Write(body)
+}
+
+// checkPeers is the readiness check of the peer count.
+func (n *Node) checkPeers() error {
+	want := min(n.config.Health.MinPeers, n.config.P2P.MaxPeers)
+	if have := n.server.PeerCount(); have < want {
+		return fmt.Errorf("%d peers, want at least %d", have, want)
+	}
+	return nil
+}
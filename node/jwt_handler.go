@@ -17,35 +17,186 @@
 package node
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/golang-jwt/jwt/v4"
 )
 
 const jwtExpiryTimeout = 60 * time.Second
 
+// JWTKeyConfig configures a named key for authenticating RPC clients.
+//
+// Each key is either a shared secret for HS256 tokens, or the public key of
+// tokens signed with RSA, ECDSA or Ed25519, so that only the issuer of the
+// tokens holds the private key. The permissions restrict the methods clients
+// authenticated with the key may call. Tokens may narrow them further with an
+// "rpc" claim of the same format, e.g. {"rpc": {"allow": ["debug_trace*"]}}.
+type JWTKeyConfig struct {
+	// Name identifies the key. Tokens select the key with their "kid" header,
+	// and clients are audited by the name if their tokens carry no subject.
+	Name string
+
+	// Secret is the path of a file containing the hex-encoded shared secret,
+	// PublicKey is the path of a PEM encoded public key. Exactly one of them
+	// must be set.
+	Secret    string `toml:",omitempty"`
+	PublicKey string `toml:",omitempty"`
+
+	// Permissions restricts the methods the clients may call.
+	Permissions rpc.Permissions `toml:",omitempty"`
+}
+
+// jwtKey is a key accepted by the JWT handler.
+type jwtKey struct {
+	name    string
+	key     interface{} // Shared secret or public key
+	methods []string    // Signing methods allowed for the key
+	perms   *rpc.Permissions
+}
+
+// loadJWTKeys reads the configured keys from disk.
+func loadJWTKeys(configs []JWTKeyConfig) ([]*jwtKey, error) {
+	var (
+		keys  []*jwtKey
+		names = make(map[string]bool)
+	)
+	for _, config := range configs {
+		if config.Name == "" {
+			return nil, errors.New("JWT key without name")
+		}
+		if names[config.Name] {
+			return nil, fmt.Errorf("duplicate JWT key %q", config.Name)
+		}
+		names[config.Name] = true
+
+		if err := config.Permissions.Validate(); err != nil {
+			return nil, fmt.Errorf("JWT key %q: %w", config.Name, err)
+		}
+		key := &jwtKey{name: config.Name}
+		if len(config.Permissions.Allow) > 0 || len(config.Permissions.Deny) > 0 {
+			perms := config.Permissions
+			key.perms = &perms
+		}
+		var err error
+		switch {
+		case config.Secret != "" && config.PublicKey != "":
+			err = errors.New("both secret and public key set")
+		case config.Secret != "":
+			key.key, key.methods, err = loadJWTSecret(config.Secret)
+		case config.PublicKey != "":
+			key.key, key.methods, err = loadJWTPublicKey(config.PublicKey)
+		default:
+			err = errors.New("missing secret or public key")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("JWT key %q: %w", config.Name, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// loadJWTSecret reads a hex-encoded shared secret.
+func loadJWTSecret(file string) (interface{}, []string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	secret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(secret) < 32 {
+		return nil, nil, fmt.Errorf("secret too short: %d bytes, want at least 32", len(secret))
+	}
+	return secret, []string{"HS256"}, nil
+}
+
+// loadJWTPublicKey reads a PEM encoded public key, returning the signing methods
+// of its type.
+func loadJWTPublicKey(file string) (interface{}, []string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, nil, errors.New("no PEM data in public key file")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return key, []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}, nil
+	case *ecdsa.PublicKey:
+		return key, []string{"ES256", "ES384", "ES512"}, nil
+	case ed25519.PublicKey:
+		return key, []string{"EdDSA"}, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// jwtClaims are the claims of the tokens accepted by the handler.
+type jwtClaims struct {
+	jwt.RegisteredClaims
+
+	// RPC optionally restricts the methods the token may call.
+	RPC *rpc.Permissions `json:"rpc,omitempty"`
+}
+
 type jwtHandler struct {
-	keyFunc func(token *jwt.Token) (interface{}, error)
-	next    http.Handler
+	keys []*jwtKey
+	next http.Handler
+}
+
+// newJWTHandler creates a http.Handler with jwt authentication support, accepting
+// tokens signed by any of the given keys.
+func newJWTHandler(keys []*jwtKey, next http.Handler) http.Handler {
+	return &jwtHandler{keys: keys, next: next}
 }
 
-// newJWTHandler creates a http.Handler with jwt authentication support.
-func newJWTHandler(secret []byte, next http.Handler) http.Handler {
-	return &jwtHandler{
-		keyFunc: func(token *jwt.Token) (interface{}, error) {
-			return secret, nil
-		},
-		next: next,
+// parse verifies the token against the keys it may be signed with, returning
+// the key which signed it.
+func (handler *jwtHandler) parse(strToken string, claims *jwtClaims) (*jwt.Token, *jwtKey, error) {
+	var (
+		token *jwt.Token
+		err   = errors.New("unknown key")
+	)
+	for _, key := range handler.keys {
+		// We explicitly set only the signing methods of the key allowed, and
+		// also disable the claim-check: the RegisteredClaims internally requires
+		// 'iat' to be no later than 'now', but we allow for a bit of drift.
+		*claims = jwtClaims{}
+		token, err = jwt.ParseWithClaims(strToken, claims, func(token *jwt.Token) (interface{}, error) {
+			if kid, ok := token.Header["kid"].(string); ok && key.name != "" && kid != key.name {
+				return nil, errors.New("unknown key")
+			}
+			return key.key, nil
+		}, jwt.WithValidMethods(key.methods), jwt.WithoutClaimsValidation())
+		if err == nil {
+			return token, key, nil
+		}
 	}
+	return token, nil, err
 }
 
 // ServeHTTP implements http.Handler
 func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 	var (
 		strToken string
-		claims   jwt.RegisteredClaims
+		claims   jwtClaims
 	)
 	if auth := r.Header.Get("Authorization"); len(auth) >= 7 && strings.EqualFold(auth[:7], "bearer ") {
 		strToken = auth[7:]
@@ -54,12 +205,7 @@ func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 		http.Error(out, "missing token", http.StatusUnauthorized)
 		return
 	}
-	// We explicitly set only HS256 allowed, and also disables the
-	// claim-check: the RegisteredClaims internally requires 'iat' to
-	// be no later than 'now', but we allow for a bit of drift.
-	token, err := jwt.ParseWithClaims(strToken, &claims, handler.keyFunc,
-		jwt.WithValidMethods([]string{"HS256"}),
-		jwt.WithoutClaimsValidation())
+	token, key, err := handler.parse(strToken, &claims)
 
 	switch {
 	case err != nil:
@@ -74,7 +220,25 @@ func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 		http.Error(out, "stale token", http.StatusUnauthorized)
 	case time.Until(claims.IssuedAt.Time) > jwtExpiryTimeout:
 		http.Error(out, "future token", http.StatusUnauthorized)
+	case claims.RPC != nil && claims.RPC.Validate() != nil:
+		http.Error(out, "invalid rpc claim", http.StatusUnauthorized)
 	default:
-		handler.next.ServeHTTP(out, r)
+		subject := claims.Subject
+		if subject == "" {
+			subject = key.name
+		}
+		ctx := rpc.NewContextWithAuthSubject(r.Context(), subject)
+
+		var perms []rpc.Permissions
+		if key.perms != nil {
+			perms = append(perms, *key.perms)
+		}
+		if claims.RPC != nil {
+			perms = append(perms, *claims.RPC)
+		}
+		if len(perms) > 0 {
+			ctx = rpc.NewContextWithPermissions(ctx, perms...)
+		}
+		handler.next.ServeHTTP(out, r.WithContext(ctx))
 	}
 }
@@ -63,6 +63,11 @@ type Node struct {
 	wsAuth        *httpServer //
 	ipc           *ipcServer  // Stores information about the ipc http server
 	inprocHandler *rpc.Server // In-process RPC request handler to process the API requests
+	rpcRecorder   *rpc.Recorder
+	rpcRecordFile *os.File
+	rpcJWTKeys    []*jwtKey // Keys authenticating clients of the HTTP and WebSocket endpoints
+
+	readinessChecks []namedHealthCheck // Checks of the /ready endpoint
 
 	databases map[*closeTrackingDB]struct{} // All open databases
 }
@@ -145,6 +150,9 @@ func New(conf *Config) (*Node, error) {
 	if err := validatePrefix("WebSocket", conf.WSPathPrefix); err != nil {
 		return nil, err
 	}
+	if err := conf.Health.validate(); err != nil {
+		return nil, err
+	}
 
 	// Configure RPC servers.
 	node.http = newHTTPServer(node.log, conf.HTTPTimeouts)
@@ -155,6 +163,15 @@ func New(conf *Config) (*Node, error) {
 	node.wsAuth.disableHTTP2 = true
 	node.ipc = newIPCServer(node.log, conf.IPCEndpoint())
 
+	// Serve the health endpoints on the HTTP server.
+	health := &healthHandler{node: node}
+	node.http.mux.Handle(healthPath, health)
+	node.http.mux.Handle(readyPath, health)
+	node.http.handlerNames[healthPath] = "Health endpoints"
+	node.http.handlerNames[readyPath] = "Health endpoints"
+	if conf.Health.MinPeers > 0 {
+		node.readinessChecks = append(node.readinessChecks, namedHealthCheck{"peers", node.checkPeers})
+	}
 	return node, nil
 }
 
@@ -388,17 +405,40 @@ func (n *Node) startRPC() error {
 		servers           []*httpServer
 		openAPIs, allAPIs = n.getAPIs()
 	)
+	if n.config.RPCRecordFile != "" {
+		f, err := os.OpenFile(n.config.RPCRecordFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		if err != nil {
+			return fmt.Errorf("can't open RPC recording: %w", err)
+		}
+		n.log.Info("Recording RPC calls", "file", n.config.RPCRecordFile)
+		n.rpcRecordFile, n.rpcRecorder = f, rpc.NewRecorder(f)
+	}
+
+	keys, err := loadJWTKeys(n.config.JWTKeys)
+	if err != nil {
+		return err
+	}
+	if len(keys) > 0 {
+		n.log.Info("Requiring JWT authentication on RPC endpoints", "keys", len(keys))
+	}
+	n.rpcJWTKeys = keys
 
 	rpcConfig := rpcEndpointConfig{
+		jwtKeys:                n.rpcJWTKeys,
 		batchItemLimit:         n.config.BatchRequestLimit,
 		batchResponseSizeLimit: n.config.BatchResponseMaxSize,
 		httpBodyLimit:          n.config.HTTPBodyLimit,
+		rateLimit:              n.config.RPCRateLimit,
+		recorder:               n.rpcRecorder,
 	}
 
 	initHttp := func(server *httpServer, port int) error {
 		if err := server.setListenAddr(n.config.HTTPHost, port); err != nil {
 			return err
 		}
+		if err := server.setTLS(n.config.HTTPTLS); err != nil {
+			return err
+		}
 		if err := server.enableRPC(openAPIs, httpConfig{
 			CorsAllowedOrigins: n.config.HTTPCors,
 			Vhosts:             n.config.HTTPVirtualHosts,
@@ -417,6 +457,9 @@ func (n *Node) startRPC() error {
 		if err := server.setListenAddr(n.config.WSHost, port); err != nil {
 			return err
 		}
+		if err := server.setTLS(n.config.WSTLS); err != nil {
+			return err
+		}
 		if err := server.enableWS(openAPIs, wsConfig{
 			Modules:           n.config.WSModules,
 			Origins:           n.config.WSOrigins,
@@ -435,12 +478,19 @@ func (n *Node) startRPC() error {
 		if err := server.setListenAddr(n.config.AuthAddr, port); err != nil {
 			return err
 		}
+		if err := server.setTLS(n.config.AuthTLS); err != nil {
+			return err
+		}
 		sharedConfig := rpcEndpointConfig{
 			jwtSecret:              secret,
 			batchItemLimit:         engineAPIBatchItemLimit,
 			batchResponseSizeLimit: engineAPIBatchResponseSizeLimit,
 			httpBodyLimit:          engineAPIBodyLimit,
+			rateLimit:              n.config.RPCRateLimit,
 		}
+		// Clients of the authenticated endpoints are only limited by subject, the
+		// consensus client must never be throttled by its address.
+		sharedConfig.rateLimit.IPRate = 0
 		err := server.enableRPC(allAPIs, httpConfig{
 			CorsAllowedOrigins: DefaultAuthCors,
 			Vhosts:             n.config.AuthVirtualHosts,
@@ -459,6 +509,9 @@ func (n *Node) startRPC() error {
 		if err := server.setListenAddr(n.config.AuthAddr, port); err != nil {
 			return err
 		}
+		if err := server.setTLS(n.config.AuthTLS); err != nil {
+			return err
+		}
 		if err := server.enableWS(allAPIs, wsConfig{
 			Modules:           DefaultAuthModules,
 			Origins:           DefaultAuthOrigins,
@@ -522,6 +575,10 @@ func (n *Node) stopRPC() {
 	n.wsAuth.stop()
 	n.ipc.stop()
 	n.stopInProc()
+	if n.rpcRecordFile != nil {
+		n.rpcRecordFile.Close()
+		n.rpcRecordFile, n.rpcRecorder = nil, nil
+	}
 }
 
 // startInProc registers all RPC APIs on the inproc server.
@@ -607,6 +664,18 @@ func (n *Node) RegisterHandler(name, path string, handler http.Handler) {
 	n.http.handlerNames[path] = name
 }
 
+// RegisterReadinessCheck adds a check to the /ready endpoint of the HTTP server.
+// The node is only reported ready if all checks pass.
+func (n *Node) RegisterReadinessCheck(name string, check HealthCheck) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.state != initializingState {
+		panic("can't register readiness check on running/stopped node")
+	}
+	n.readinessChecks = append(n.readinessChecks, namedHealthCheck{name, check})
+}
+
 // Attach creates an RPC client attached to an in-process API handler.
 func (n *Node) Attach() *rpc.Client {
 	return rpc.DialInProc(n.inprocHandler)
@@ -667,28 +736,28 @@ func (n *Node) IPCEndpoint() string {
 // HTTPEndpoint returns the URL of the HTTP server. Note that this URL does not
 // contain the JSON-RPC path prefix set by HTTPPathPrefix.
 func (n *Node) HTTPEndpoint() string {
-	return "http://" + n.http.listenAddr()
+	return n.http.scheme("http") + "://" + n.http.listenAddr()
 }
 
 // WSEndpoint returns the current JSON-RPC over WebSocket endpoint.
 func (n *Node) WSEndpoint() string {
 	if n.http.wsAllowed() {
-		return "ws://" + n.http.listenAddr() + n.http.wsConfig.prefix
+		return n.http.scheme("ws") + "://" + n.http.listenAddr() + n.http.wsConfig.prefix
 	}
-	return "ws://" + n.ws.listenAddr() + n.ws.wsConfig.prefix
+	return n.ws.scheme("ws") + "://" + n.ws.listenAddr() + n.ws.wsConfig.prefix
 }
 
 // HTTPAuthEndpoint returns the URL of the authenticated HTTP server.
 func (n *Node) HTTPAuthEndpoint() string {
-	return "http://" + n.httpAuth.listenAddr()
+	return n.httpAuth.scheme("http") + "://" + n.httpAuth.listenAddr()
 }
 
 // WSAuthEndpoint returns the current authenticated JSON-RPC over WebSocket endpoint.
 func (n *Node) WSAuthEndpoint() string {
 	if n.httpAuth.wsAllowed() {
-		return "ws://" + n.httpAuth.listenAddr() + n.httpAuth.wsConfig.prefix
+		return n.httpAuth.scheme("ws") + "://" + n.httpAuth.listenAddr() + n.httpAuth.wsConfig.prefix
 	}
-	return "ws://" + n.wsAuth.listenAddr() + n.wsAuth.wsConfig.prefix
+	return n.wsAuth.scheme("ws") + "://" + n.wsAuth.listenAddr() + n.wsAuth.wsConfig.prefix
 }
 
 // OpenDatabaseWithOptions opens an existing database with the given name (or creates one if no
@@ -25,6 +25,7 @@ import (
 	"reflect"
 	"slices"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/crypto"
@@ -416,6 +417,52 @@ func TestRegisterHandler_Successful(t *testing.T) {
 	assert.Equal(t, "success", string(buf))
 }
 
+// Tests the responses of the health and readiness endpoints.
+func TestHealthEndpoints(t *testing.T) {
+	conf := &Config{
+		HTTPHost:     "127.0.0.1",
+		HTTPTimeouts: rpc.DefaultHTTPTimeouts,
+		Health: HealthConfig{
+			MinPeers: 1, // Capped to zero by the maximum peer count
+			NotReady: HealthResponse{Status: http.StatusInternalServerError, Body: `{"ready":false}`},
+		},
+	}
+	node, err := New(conf)
+	if err != nil {
+		t.Fatalf("could not create a new node: %v", err)
+	}
+	defer node.Close()
+
+	var synced atomic.Bool
+	node.RegisterReadinessCheck("sync", func() error {
+		if !synced.Load() {
+			return errors.New("syncing")
+		}
+		return nil
+	})
+	if err := node.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+	check := func(path string, wantStatus int, wantBody string) {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodGet, node.HTTPEndpoint()+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp := doHTTPRequest(t, req)
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != wantStatus || string(body) != wantBody {
+			t.Errorf("%s: response mismatch: have %d %s, want %d %s", path, resp.StatusCode, body, wantStatus, wantBody)
+		}
+	}
+	check("/health", http.StatusOK, `{"status":"ok"}`)
+	check("/ready", http.StatusInternalServerError, `{"ready":false}`)
+
+	synced.Store(true)
+	check("/ready", http.StatusOK, `{"status":"ok","checks":{"peers":"ok","sync":"ok"}}`)
+}
+
 // Tests whether websocket requests can be handled on the same port as a regular http server.
 func TestWebsocketHTTPOnSamePort_WebsocketRequest(t *testing.T) {
 	node := startHTTP(t, 0, 0)
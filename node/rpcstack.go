@@ -55,10 +55,28 @@ type wsConfig struct {
 }
 
 type rpcEndpointConfig struct {
-	jwtSecret              []byte // optional JWT secret
+	jwtSecret              []byte    // optional JWT secret
+	jwtKeys                []*jwtKey // optional named JWT keys, accepted alongside the secret
 	batchItemLimit         int
 	batchResponseSizeLimit int
 	httpBodyLimit          int
+	rateLimit              rpc.RateLimitConfig
+	recorder               *rpc.Recorder // optional
+}
+
+// authenticated reports whether clients must authenticate with a JWT token.
+func (c rpcEndpointConfig) authenticated() bool {
+	return len(c.jwtSecret) != 0 || len(c.jwtKeys) != 0
+}
+
+// jwtHandlerKeys returns the keys accepted for authenticating clients, the
+// unnamed JWT secret granting unrestricted access.
+func (c rpcEndpointConfig) jwtHandlerKeys() []*jwtKey {
+	var keys []*jwtKey
+	if len(c.jwtSecret) != 0 {
+		keys = append(keys, &jwtKey{key: c.jwtSecret, methods: []string{"HS256"}})
+	}
+	return append(keys, c.jwtKeys...)
 }
 
 type rpcHandler struct {
@@ -90,6 +108,9 @@ type httpServer struct {
 	host     string
 	port     int
 
+	// This is set by setTLS.
+	tlsConfig TLSConfig
+
 	handlerNames map[string]string
 
 	// disableHTTP2 disables HTTP/2 support on this server when set to true.
@@ -120,6 +141,42 @@ func (h *httpServer) setListenAddr(host string, port int) error {
 	return nil
 }
 
+// setTLS configures TLS on the server. The configuration can only be set while
+// the server isn't running. An empty configuration leaves the current one
+// unchanged, so that endpoints sharing the server with a TLS enabled one are
+// served over TLS too.
+func (h *httpServer) setTLS(config TLSConfig) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !config.enabled() {
+		return nil
+	}
+	if err := config.validate(); err != nil {
+		return err
+	}
+	if h.tlsConfig.enabled() && h.tlsConfig != config {
+		return fmt.Errorf("conflicting TLS configuration for endpoint %s", h.endpoint)
+	}
+	if h.listener != nil && h.tlsConfig != config {
+		return fmt.Errorf("HTTP server already running on %s", h.endpoint)
+	}
+	h.tlsConfig = config
+	return nil
+}
+
+// scheme returns the URL scheme of the server for the given protocol, either
+// "http" or "ws", with the secure variant if TLS is enabled.
+func (h *httpServer) scheme(protocol string) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.tlsConfig.enabled() {
+		return protocol + "s"
+	}
+	return protocol
+}
+
 // listenAddr returns the listening address of the server.
 func (h *httpServer) listenAddr() string {
 	h.mu.Lock()
@@ -147,6 +204,20 @@ func (h *httpServer) start() error {
 	if !h.disableHTTP2 {
 		h.server.Protocols.SetUnencryptedHTTP2(true)
 	}
+	if h.tlsConfig.enabled() {
+		reloader, err := newTLSReloader(h.tlsConfig, h.log)
+		if err != nil {
+			h.disableRPC()
+			h.disableWS()
+			return err
+		}
+		nextProtos := []string{"http/1.1"}
+		if !h.disableHTTP2 {
+			h.server.Protocols.SetHTTP2(true)
+			nextProtos = []string{"h2", "http/1.1"}
+		}
+		h.server.TLSConfig = reloader.tlsConfig(nextProtos)
+	}
 	if h.timeouts != (rpc.HTTPTimeouts{}) {
 		CheckTimeouts(&h.timeouts)
 		h.server.ReadTimeout = h.timeouts.ReadTimeout
@@ -165,10 +236,16 @@ func (h *httpServer) start() error {
 		return err
 	}
 	h.listener = listener
-	go h.server.Serve(listener)
+	scheme, wsScheme := "http", "ws"
+	if h.server.TLSConfig != nil {
+		scheme, wsScheme = "https", "wss"
+		go h.server.ServeTLS(listener, "", "")
+	} else {
+		go h.server.Serve(listener)
+	}
 
 	if h.wsAllowed() {
-		url := fmt.Sprintf("ws://%v", listener.Addr())
+		url := fmt.Sprintf("%s://%v", wsScheme, listener.Addr())
 		if h.wsConfig.prefix != "" {
 			url += h.wsConfig.prefix
 		}
@@ -180,7 +257,8 @@ func (h *httpServer) start() error {
 	}
 	// Log http endpoint.
 	h.log.Info("HTTP server started",
-		"endpoint", listener.Addr(), "auth", h.httpConfig.jwtSecret != nil,
+		"endpoint", listener.Addr(), "auth", h.httpConfig.authenticated(),
+		"tls", h.tlsConfig.enabled(), "clientauth", h.tlsConfig.ClientCAFile != "",
 		"prefix", h.httpConfig.prefix,
 		"cors", strings.Join(h.httpConfig.CorsAllowedOrigins, ","),
 		"vhosts", strings.Join(h.httpConfig.Vhosts, ","),
@@ -196,7 +274,7 @@ func (h *httpServer) start() error {
 	for _, path := range paths {
 		name := h.handlerNames[path]
 		if !logged[name] {
-			log.Info(name+" enabled", "url", "http://"+listener.Addr().String()+path)
+			log.Info(name+" enabled", "url", scheme+"://"+listener.Addr().String()+path)
 			logged[name] = true
 		}
 	}
@@ -300,6 +378,7 @@ func (h *httpServer) doStop() {
 
 	// Clear out everything to allow re-configuring it later.
 	h.host, h.port, h.endpoint = "", 0, ""
+	h.tlsConfig = TLSConfig{}
 	h.server, h.listener = nil, nil
 }
 
@@ -318,12 +397,14 @@ func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 	if config.httpBodyLimit > 0 {
 		srv.SetHTTPBodyLimit(config.httpBodyLimit)
 	}
+	srv.SetRateLimits(config.rateLimit)
+	srv.SetRecorder(config.recorder)
 	if err := RegisterApis(apis, config.Modules, srv); err != nil {
 		return err
 	}
 	h.httpConfig = config
 	h.httpHandler.Store(&rpcHandler{
-		Handler: NewHTTPHandlerStack(srv, config.CorsAllowedOrigins, config.Vhosts, config.jwtSecret, config.disableGzip),
+		Handler: newHTTPHandlerStack(srv, config.CorsAllowedOrigins, config.Vhosts, config.jwtHandlerKeys(), config.disableGzip),
 		prefix:  config.prefix,
 		server:  srv,
 	})
@@ -354,12 +435,14 @@ func (h *httpServer) enableWS(apis []rpc.API, config wsConfig) error {
 	if config.httpBodyLimit > 0 {
 		srv.SetHTTPBodyLimit(config.httpBodyLimit)
 	}
+	srv.SetRateLimits(config.rateLimit)
+	srv.SetRecorder(config.recorder)
 	if err := RegisterApis(apis, config.Modules, srv); err != nil {
 		return err
 	}
 	h.wsConfig = config
 	h.wsHandler.Store(&rpcHandler{
-		Handler: NewWSHandlerStack(srv.WebsocketHandler(config.Origins), config.jwtSecret),
+		Handler: newWSHandlerStack(srv.WebsocketHandler(config.Origins), config.jwtHandlerKeys()),
 		prefix:  config.prefix,
 		server:  srv,
 	})
@@ -406,11 +489,15 @@ func isWebsocket(r *http.Request) bool {
 
 // NewHTTPHandlerStack returns wrapped http-related handlers
 func NewHTTPHandlerStack(srv http.Handler, cors []string, vhosts []string, jwtSecret []byte, disableGzip bool) http.Handler {
+	return newHTTPHandlerStack(srv, cors, vhosts, rpcEndpointConfig{jwtSecret: jwtSecret}.jwtHandlerKeys(), disableGzip)
+}
+
+func newHTTPHandlerStack(srv http.Handler, cors []string, vhosts []string, jwtKeys []*jwtKey, disableGzip bool) http.Handler {
 	// Wrap the CORS-handler within a host-handler
 	handler := newCorsHandler(srv, cors)
 	handler = newVHostHandler(vhosts, handler)
-	if len(jwtSecret) != 0 {
-		handler = newJWTHandler(jwtSecret, handler)
+	if len(jwtKeys) != 0 {
+		handler = newJWTHandler(jwtKeys, handler)
 	}
 	if disableGzip {
 		return handler
@@ -420,8 +507,12 @@ func NewHTTPHandlerStack(srv http.Handler, cors []string, vhosts []string, jwtSe
 
 // NewWSHandlerStack returns a wrapped ws-related handler.
 func NewWSHandlerStack(srv http.Handler, jwtSecret []byte) http.Handler {
-	if len(jwtSecret) != 0 {
-		return newJWTHandler(jwtSecret, srv)
+	return newWSHandlerStack(srv, rpcEndpointConfig{jwtSecret: jwtSecret}.jwtHandlerKeys())
+}
+
+func newWSHandlerStack(srv http.Handler, jwtKeys []*jwtKey) http.Handler {
+	if len(jwtKeys) != 0 {
+		return newJWTHandler(jwtKeys, srv)
 	}
 	return srv
 }
@@ -576,6 +667,12 @@ func (w *gzipResponseWriter) Flush() {
 	}
 }
 
+// Unwrap returns the underlying response writer. This allows handlers to use
+// http.ResponseController on the wrapped writer.
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.resp
+}
+
 func (w *gzipResponseWriter) close() {
 	if w.gz == nil {
 		return
@@ -591,6 +688,12 @@ func newGzipHandler(next http.Handler) http.Handler {
 			next.ServeHTTP(w, r)
 			return
 		}
+		// Event streams are long-lived and flushed per message, so they are
+		// not compressed.
+		if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+			next.ServeHTTP(w, r)
+			return
+		}
 
 		wrapper := &gzipResponseWriter{resp: w}
 		defer wrapper.close()
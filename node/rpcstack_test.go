@@ -18,16 +18,31 @@ package node
 
 import (
 	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"io"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/internal/testlog"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -452,6 +467,103 @@ func TestJWT(t *testing.T) {
 	srv.stop()
 }
 
+// TestJWTPermissions tests that clients authenticated with named keys may only
+// call the methods permitted by the key and their token.
+func TestJWTPermissions(t *testing.T) {
+	var (
+		dir        = t.TempDir()
+		secret     = bytes.Repeat([]byte{0x42}, 32)
+		secretFile = filepath.Join(dir, "reader.hex")
+		pubFile    = filepath.Join(dir, "tracer.pem")
+	)
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(secretFile, []byte(hexutil.Encode(secret)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pubFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := loadJWTKeys([]JWTKeyConfig{
+		{Name: "reader", Secret: secretFile, Permissions: rpc.Permissions{Allow: []string{"test"}, Deny: []string{"test_sleep"}}},
+		{Name: "tracer", PublicKey: pubFile},
+	})
+	if err != nil {
+		t.Fatalf("failed to load keys: %v", err)
+	}
+	cfg := rpcEndpointConfig{jwtKeys: keys}
+	srv := createAndStartServer(t, &httpConfig{rpcEndpointConfig: cfg}, false, nil, nil)
+	defer srv.stop()
+
+	issueToken := func(method jwt.SigningMethod, key interface{}, kid string, claims testClaim) string {
+		claims["iat"] = time.Now().Unix()
+		token := jwt.NewWithClaims(method, claims)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		ss, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ss
+	}
+	var (
+		reader    = issueToken(jwt.SigningMethodHS256, secret, "", testClaim{})
+		tracer    = issueToken(jwt.SigningMethodEdDSA, priv, "tracer", testClaim{"sub": "team-a"})
+		greeter   = issueToken(jwt.SigningMethodEdDSA, priv, "tracer", testClaim{"rpc": map[string]interface{}{"allow": []string{"test_greet"}}})
+		wrongKid  = issueToken(jwt.SigningMethodEdDSA, priv, "reader", testClaim{})
+		wrongHMAC = issueToken(jwt.SigningMethodHS256, []byte("wrong"), "", testClaim{})
+	)
+	tests := []struct {
+		token   string
+		method  string
+		want    string // Expected result, empty if the call is denied
+		unauthd bool   // Whether the token is rejected entirely
+	}{
+		{token: reader, method: "test_greet", want: "Hello"},
+		{token: reader, method: "test_authSubject", want: "reader"},
+		{token: reader, method: "test_sleep"},
+		{token: reader, method: "rpc_modules"},
+		{token: tracer, method: "test_authSubject", want: "team-a"},
+		{token: greeter, method: "test_greet", want: "Hello"},
+		{token: greeter, method: "test_authSubject"},
+		{token: wrongKid, method: "test_greet", unauthd: true},
+		{token: wrongHMAC, method: "test_greet", unauthd: true},
+	}
+	for i, test := range tests {
+		client, err := rpc.DialOptions(context.Background(), "http://"+srv.listenAddr(), rpc.WithHeader("Authorization", "Bearer "+test.token))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var result interface{}
+		err = client.Call(&result, test.method)
+		client.Close()
+
+		switch {
+		case test.unauthd:
+			var httpErr rpc.HTTPError
+			if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusUnauthorized {
+				t.Errorf("test %d: expected unauthorized, got %v", i, err)
+			}
+		case test.want == "":
+			var rpcErr rpc.Error
+			if !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != -32004 {
+				t.Errorf("test %d: expected %s to be denied, got %v (%v)", i, test.method, result, err)
+			}
+		case err != nil:
+			t.Errorf("test %d: %s failed: %v", i, test.method, err)
+		case result != test.want:
+			t.Errorf("test %d: %s result mismatch: have %v, want %s", i, test.method, result, test.want)
+		}
+	}
+}
+
 func TestGzipHandler(t *testing.T) {
 	type gzipTest struct {
 		name    string
@@ -643,6 +755,120 @@ func (s *testService) Greet() string {
 	return "Hello"
 }
 
+func (s *testService) ClientSubject(ctx context.Context) string {
+	return rpc.PeerInfoFromContext(ctx).HTTP.TLSClientSubject
+}
+
+func (s *testService) AuthSubject(ctx context.Context) string {
+	return rpc.PeerInfoFromContext(ctx).HTTP.AuthSubject
+}
+
 func (s *testService) Sleep() {
 	time.Sleep(1500 * time.Millisecond)
 }
+
+// writeTestCert creates a certificate with the given common name, signed by the
+// given CA or self-signed if nil, and writes it along with its key as PEM files
+// into dir. It returns the certificate, key and paths of the files.
+func writeTestCert(t *testing.T, dir, name string, ca *x509.Certificate, caKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	if ca == nil {
+		template.IsCA, template.BasicConstraintsValid = true, true
+		ca, caKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile, keyFile := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return cert, key, certFile, keyFile
+}
+
+func TestHTTPMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey, caFile, _ := writeTestCert(t, dir, "ca", nil, nil)
+	_, _, serverCert, serverKey := writeTestCert(t, dir, "server", ca, caKey)
+	_, _, clientCert, clientKey := writeTestCert(t, dir, "client", ca, caKey)
+
+	srv := newHTTPServer(testlog.Logger(t, log.LvlDebug), rpc.DefaultHTTPTimeouts)
+	assert.NoError(t, srv.enableRPC(apis(), httpConfig{}))
+	assert.NoError(t, srv.setListenAddr("localhost", 0))
+	assert.NoError(t, srv.setTLS(TLSConfig{CertFile: serverCert, KeyFile: serverKey, ClientCAFile: caFile}))
+	assert.NoError(t, srv.start())
+	defer srv.stop()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	request := func(certs []tls.Certificate) (*http.Response, error) {
+		transport := &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: certs}}
+		transport.Protocols = new(http.Protocols)
+		transport.Protocols.SetHTTP2(true)
+		client := &http.Client{Transport: transport}
+
+		body := strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"test_clientSubject","params":[]}`)
+		return client.Post("https://"+srv.listenAddr(), "application/json", body)
+	}
+	// Clients without a certificate must be rejected
+	if resp, err := request(nil); err == nil {
+		resp.Body.Close()
+		t.Fatal("request without client certificate succeeded")
+	}
+	cert, err := tls.LoadX509KeyPair(clientCert, clientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := request([]tls.Certificate{cert})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.Proto != "HTTP/2.0" {
+		t.Errorf("expected HTTP/2.0, got %s", resp.Proto)
+	}
+	if !strings.Contains(string(result), `"result":"CN=client"`) {
+		t.Fatalf("unexpected response: %s", result)
+	}
+	// Replace the server certificate, it must be picked up without restarting
+	time.Sleep(tlsReloadInterval)
+	_, _, newCert, newKey := writeTestCert(t, t.TempDir(), "reloaded", ca, caKey)
+	for _, file := range [][2]string{{newCert, serverCert}, {newKey, serverKey}} {
+		if err := os.Rename(file[0], file[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resp, err = request([]tls.Certificate{cert})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if name := resp.TLS.PeerCertificates[0].Subject.CommonName; name != "reloaded" {
+		t.Fatalf("server certificate not reloaded: have %q, want %q", name, "reloaded")
+	}
+}
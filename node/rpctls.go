@@ -0,0 +1,185 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// tlsReloadInterval is the minimum time between two checks of the certificate
+// files for changes.
+const tlsReloadInterval = time.Second
+
+// TLSConfig configures TLS on an RPC endpoint.
+type TLSConfig struct {
+	// CertFile and KeyFile are the paths of the PEM encoded server certificate
+	// chain and private key. TLS is enabled if they are set.
+	CertFile string `toml:",omitempty"`
+	KeyFile  string `toml:",omitempty"`
+
+	// ClientCAFile is the path of a PEM encoded bundle of CA certificates. If set,
+	// clients must authenticate with a certificate issued by one of them.
+	ClientCAFile string `toml:",omitempty"`
+}
+
+// enabled reports whether TLS is configured.
+func (c TLSConfig) enabled() bool {
+	return c.CertFile != "" || c.KeyFile != "" || c.ClientCAFile != ""
+}
+
+// validate checks that the configuration is complete.
+func (c TLSConfig) validate() error {
+	if c.CertFile == "" || c.KeyFile == "" {
+		return errors.New("TLS requires both a certificate and a key file")
+	}
+	return nil
+}
+
+// tlsReloader serves the TLS certificate and client CAs of an endpoint, loading
+// them again whenever the files change, so that certificates can be rotated
+// without restarting the node.
+type tlsReloader struct {
+	config TLSConfig
+	log    log.Logger
+
+	mu        sync.Mutex
+	checked   time.Time   // Last time the files were checked for changes
+	modTimes  []time.Time // Modification times of the loaded files
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool // Nil if clients are not authenticated
+}
+
+// newTLSReloader creates a reloader for the given configuration, loading the
+// certificates initially.
+func newTLSReloader(config TLSConfig, log log.Logger) (*tlsReloader, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	r := &tlsReloader{config: config, log: log}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// files returns the paths of the configured files.
+func (r *tlsReloader) files() []string {
+	files := []string{r.config.CertFile, r.config.KeyFile}
+	if r.config.ClientCAFile != "" {
+		files = append(files, r.config.ClientCAFile)
+	}
+	return files
+}
+
+// stat retrieves the modification times of the configured files.
+func (r *tlsReloader) stat() ([]time.Time, error) {
+	var times []time.Time
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		times = append(times, info.ModTime())
+	}
+	return times, nil
+}
+
+// load reads the certificate, key and client CAs from disk. The caller must
+// hold the lock, or have exclusive access to the reloader.
+func (r *tlsReloader) load() error {
+	times, err := r.stat()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return fmt.Errorf("invalid TLS certificate: %w", err)
+	}
+	var pool *x509.CertPool
+	if r.config.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.config.ClientCAFile)
+		if err != nil {
+			return err
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates in TLS client CA file %s", r.config.ClientCAFile)
+		}
+	}
+	r.checked, r.modTimes = time.Now(), times
+	r.cert, r.clientCAs = &cert, pool
+	return nil
+}
+
+// current returns the certificate and client CAs to use, reloading them first if
+// the files changed. The previous ones stay in use if the new files are invalid.
+func (r *tlsReloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checked) < tlsReloadInterval {
+		return r.cert, r.clientCAs
+	}
+	r.checked = time.Now()
+
+	times, err := r.stat()
+	if err != nil {
+		r.log.Warn("Failed to check TLS certificate files", "err", err)
+		return r.cert, r.clientCAs
+	}
+	for i := range times {
+		if !times[i].Equal(r.modTimes[i]) {
+			if err := r.load(); err != nil {
+				r.log.Warn("Failed to reload TLS certificates", "err", err)
+			} else {
+				r.log.Info("Reloaded TLS certificates", "cert", r.config.CertFile)
+			}
+			break
+		}
+	}
+	return r.cert, r.clientCAs
+}
+
+// tlsConfig returns the server TLS configuration, which resolves the current
+// certificates on every handshake. The nextProtos are the protocols offered
+// during ALPN negotiation.
+func (r *tlsReloader) tlsConfig(nextProtos []string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, clientCAs := r.current()
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+				NextProtos:   nextProtos,
+			}
+			if clientCAs != nil {
+				config.ClientCAs = clientCAs
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return config, nil
+		},
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"errors"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/netutil"
+)
+
+var (
+	errBanned    = errors.New("banned")
+	errNotBanned = errors.New("not banned")
+)
+
+// accessList holds the rules which nodes may be connected: the networks they must
+// be in, and the banned nodes and networks. The rules can be changed while the
+// server is running, the bans are persisted in the node database.
+type accessList struct {
+	db  *enode.DB
+	now func() time.Time
+
+	mu          sync.RWMutex
+	netRestrict *netutil.Netlist           // Allowed networks, unrestricted if nil
+	nodeBans    map[enode.ID]time.Time     // Banned nodes and the expiry of their bans
+	netBans     map[netip.Prefix]time.Time // Banned networks and the expiry of their bans
+}
+
+// newAccessList creates the access list, loading the stored bans.
+func newAccessList(db *enode.DB, netRestrict *netutil.Netlist) *accessList {
+	l := &accessList{
+		db:          db,
+		now:         time.Now,
+		netRestrict: netRestrict,
+		nodeBans:    make(map[enode.ID]time.Time),
+		netBans:     make(map[netip.Prefix]time.Time),
+	}
+	for target, expires := range db.Bans() {
+		if id, err := enode.ParseID(target); err == nil {
+			l.nodeBans[id] = expires
+		} else if network, err := netip.ParsePrefix(target); err == nil {
+			l.netBans[network] = expires
+		}
+	}
+	return l
+}
+
+// banActive reports whether a ban with the given expiry is in effect.
+func banActive(expires, now time.Time) bool {
+	return expires.IsZero() || now.Before(expires)
+}
+
+// checkNode returns an error if the given node may not be connected.
+func (l *accessList) checkNode(n *enode.Node) error {
+	if err := l.checkID(n.ID()); err != nil {
+		return err
+	}
+	return l.checkIP(n.IPAddr())
+}
+
+// checkID returns an error if the node with the given ID is banned.
+func (l *accessList) checkID(id enode.ID) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if expires, ok := l.nodeBans[id]; ok && banActive(expires, l.now()) {
+		return errBanned
+	}
+	return nil
+}
+
+// checkIP returns an error if the given IP is not in the allowed networks or
+// in a banned one.
+func (l *accessList) checkIP(ip netip.Addr) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	ip = ip.Unmap()
+	if l.netRestrict != nil && !l.netRestrict.ContainsAddr(ip) {
+		return errNetRestrict
+	}
+	for network, expires := range l.netBans {
+		if network.Contains(ip) && banActive(expires, l.now()) {
+			return errBanned
+		}
+	}
+	return nil
+}
+
+// setNetRestrict replaces the allowed networks.
+func (l *accessList) setNetRestrict(list *netutil.Netlist) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.netRestrict = list
+}
+
+// banNode bans a node until the given time, or permanently if it is zero.
+func (l *accessList) banNode(id enode.ID, expires time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.db.UpdateBan(id.String(), expires); err != nil {
+		return err
+	}
+	l.nodeBans[id] = expires
+	return nil
+}
+
+// unbanNode lifts the ban of a node.
+func (l *accessList) unbanNode(id enode.ID) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	expires, ok := l.nodeBans[id]
+	if !ok || !banActive(expires, l.now()) {
+		return errNotBanned
+	}
+	if err := l.db.DeleteBan(id.String()); err != nil {
+		return err
+	}
+	delete(l.nodeBans, id)
+	return nil
+}
+
+// banNetwork bans all nodes in a network until the given time, or permanently
+// if it is zero.
+func (l *accessList) banNetwork(network netip.Prefix, expires time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	network = network.Masked()
+	if err := l.db.UpdateBan(network.String(), expires); err != nil {
+		return err
+	}
+	l.netBans[network] = expires
+	return nil
+}
+
+// unbanNetwork lifts the ban of a network.
+func (l *accessList) unbanNetwork(network netip.Prefix) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	network = network.Masked()
+	expires, ok := l.netBans[network]
+	if !ok || !banActive(expires, l.now()) {
+		return errNotBanned
+	}
+	if err := l.db.DeleteBan(network.String()); err != nil {
+		return err
+	}
+	delete(l.netBans, network)
+	return nil
+}
+
+// SetNetRestrict replaces the list of networks which nodes must be in to be
+// connected, lifting the restriction if nil. Connected peers outside of the new
+// list are disconnected.
+func (srv *Server) SetNetRestrict(list *netutil.Netlist) error {
+	acl := srv.accessRules()
+	if acl == nil {
+		return errServerStopped
+	}
+	acl.setNetRestrict(list)
+	srv.disconnectDenied(acl)
+	return nil
+}
+
+// BanNode bans a node until the given time, or permanently if it is zero. The
+// node is disconnected, and neither dialed nor accepted while the ban lasts.
+func (srv *Server) BanNode(id enode.ID, expires time.Time) error {
+	acl := srv.accessRules()
+	if acl == nil {
+		return errServerStopped
+	}
+	if err := acl.banNode(id, expires); err != nil {
+		return err
+	}
+	srv.disconnectDenied(acl)
+	return nil
+}
+
+// UnbanNode lifts the ban of a node.
+func (srv *Server) UnbanNode(id enode.ID) error {
+	acl := srv.accessRules()
+	if acl == nil {
+		return errServerStopped
+	}
+	return acl.unbanNode(id)
+}
+
+// BanNetwork bans all nodes in a network until the given time, or permanently
+// if it is zero. Nodes in the network are disconnected, and neither dialed nor
+// accepted while the ban lasts.
+func (srv *Server) BanNetwork(network netip.Prefix, expires time.Time) error {
+	acl := srv.accessRules()
+	if acl == nil {
+		return errServerStopped
+	}
+	if err := acl.banNetwork(network, expires); err != nil {
+		return err
+	}
+	srv.disconnectDenied(acl)
+	return nil
+}
+
+// UnbanNetwork lifts the ban of a network.
+func (srv *Server) UnbanNetwork(network netip.Prefix) error {
+	acl := srv.accessRules()
+	if acl == nil {
+		return errServerStopped
+	}
+	return acl.unbanNetwork(network)
+}
+
+// accessRules returns the access list, or nil if the server is not running.
+func (srv *Server) accessRules() *accessList {
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+
+	if !srv.running {
+		return nil
+	}
+	return srv.acl
+}
+
+// disconnectDenied disconnects the peers which are no longer allowed.
+func (srv *Server) disconnectDenied(acl *accessList) {
+	for _, p := range srv.Peers() {
+		err := acl.checkID(p.ID())
+		if ip := netutil.AddrAddr(p.RemoteAddr()); err == nil && ip.IsValid() {
+			err = acl.checkIP(ip)
+		}
+		if err != nil {
+			srv.log.Debug("Disconnecting denied peer", "id", p.ID(), "addr", p.RemoteAddr(), "err", err)
+			p.Disconnect(DiscRequested)
+		}
+	}
+}
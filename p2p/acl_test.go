@@ -0,0 +1,142 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/internal/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethereum/go-ethereum/p2p/netutil"
+)
+
+func TestAccessList(t *testing.T) {
+	db, err := enode.OpenDB("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	restrict, _ := netutil.ParseNetlist("10.0.0.0/8")
+	var (
+		now  = time.Unix(1700000000, 0)
+		acl  = newAccessList(db, restrict)
+		id   = uintID(1)
+		addr = netip.MustParseAddr
+	)
+	acl.now = func() time.Time { return now }
+
+	check := func(ip string, want error) {
+		t.Helper()
+		if err := acl.checkIP(addr(ip)); !errors.Is(err, want) {
+			t.Fatalf("wrong result for %s: have %v, want %v", ip, err, want)
+		}
+	}
+	check("192.168.1.1", errNetRestrict)
+	check("10.1.2.3", nil)
+
+	// Ban a network for an hour and a node forever
+	acl.banNetwork(netip.MustParsePrefix("10.1.2.3/16"), now.Add(time.Hour))
+	acl.banNode(id, time.Time{})
+	check("10.1.2.3", errBanned)
+	check("::ffff:10.1.2.3", errBanned)
+	check("10.2.0.1", nil)
+	if err := acl.checkNode(newNode(id, "10.2.0.1:30303")); !errors.Is(err, errBanned) {
+		t.Fatalf("banned node allowed: %v", err)
+	}
+	// The bans are restored from the database, the network ban expires
+	acl = newAccessList(db, nil)
+	acl.now = func() time.Time { return now.Add(2 * time.Hour) }
+
+	check("192.168.1.1", nil)
+	check("10.1.2.3", nil)
+	if err := acl.checkID(id); !errors.Is(err, errBanned) {
+		t.Fatalf("banned node allowed after reload: %v", err)
+	}
+	if err := acl.unbanNetwork(netip.MustParsePrefix("10.1.0.0/16")); !errors.Is(err, errNotBanned) {
+		t.Fatalf("wrong error for unbanning expired ban: %v", err)
+	}
+	if err := acl.unbanNode(id); err != nil {
+		t.Fatalf("failed to unban node: %v", err)
+	}
+	if err := acl.checkID(id); err != nil {
+		t.Fatalf("unbanned node not allowed: %v", err)
+	}
+	if bans := db.Bans(); len(bans) != 1 {
+		t.Fatalf("wrong number of stored bans: %v", bans)
+	}
+}
+
+func TestServerBan(t *testing.T) {
+	remote := newkey()
+	srv := &Server{
+		Config: Config{
+			PrivateKey:  newkey(),
+			MaxPeers:    10,
+			NoDial:      true,
+			NoDiscovery: true,
+			Logger:      testlog.Logger(t, log.LvlTrace),
+		},
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatalf("could not start: %v", err)
+	}
+	defer srv.Stop()
+
+	newconn := func(id enode.ID) *conn {
+		fd, _ := net.Pipe()
+		tx := newTestTransport(&remote.PublicKey, fd, nil)
+		node := enode.SignNull(new(enr.Record), id)
+		return &conn{fd: fd, transport: tx, flags: inboundConn, node: node, cont: make(chan error)}
+	}
+	id := randomID()
+	if err := srv.checkpoint(newconn(id), srv.checkpointAddPeer); err != nil {
+		t.Fatalf("could not add conn: %v", err)
+	}
+	// Banning the node must disconnect it and keep it from connecting again
+	if err := srv.BanNode(id, time.Time{}); err != nil {
+		t.Fatalf("could not ban node: %v", err)
+	}
+	for i := 0; srv.PeerCount() != 0; i++ {
+		if i == 100 {
+			t.Fatal("banned peer not disconnected")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := srv.checkpoint(newconn(id), srv.checkpointPostHandshake); err != DiscUselessPeer {
+		t.Fatalf("wrong error for banned conn: %v", err)
+	}
+	if err := srv.UnbanNode(id); err != nil {
+		t.Fatalf("could not unban node: %v", err)
+	}
+	if err := srv.checkpoint(newconn(id), srv.checkpointPostHandshake); err != nil {
+		t.Fatalf("unexpected error for unbanned conn: %v", err)
+	}
+	// Inbound connections from banned networks are rejected before the handshake
+	if err := srv.BanNetwork(netip.MustParsePrefix("192.0.2.0/24"), time.Time{}); err != nil {
+		t.Fatalf("could not ban network: %v", err)
+	}
+	if err := srv.checkInboundConn(netip.MustParseAddr("192.0.2.1")); !errors.Is(err, errBanned) {
+		t.Fatalf("wrong error for banned network: %v", err)
+	}
+}
@@ -81,7 +81,8 @@ type Config struct {
 
 	// Connectivity can be restricted to certain IP networks.
 	// If this option is set to a non-nil value, only hosts which match one of the
-	// IP networks contained in the list are considered.
+	// IP networks contained in the list are considered. The list can be replaced
+	// while the server is running using SetNetRestrict.
 	NetRestrict *netutil.Netlist `toml:",omitempty"`
 
 	// NodeDatabase is the path to the database containing the previously seen
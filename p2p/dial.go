@@ -33,7 +33,6 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
-	"github.com/ethereum/go-ethereum/p2p/netutil"
 )
 
 const (
@@ -137,15 +136,16 @@ type dialScheduler struct {
 type dialSetupFunc func(net.Conn, connFlag, *enode.Node) error
 
 type dialConfig struct {
-	self           enode.ID         // our own ID
-	maxDialPeers   int              // maximum number of dialed peers
-	maxActiveDials int              // maximum number of active dials
-	netRestrict    *netutil.Netlist // IP netrestrict list, disabled if nil
+	self           enode.ID    // our own ID
+	maxDialPeers   int         // maximum number of dialed peers
+	maxActiveDials int         // maximum number of active dials
+	acl            *accessList // peer access rules, disabled if nil
 	resolver       nodeResolver
 	dialer         NodeDialer
 	log            log.Logger
 	clock          mclock.Clock
 	rand           *mrand.Rand
+	reputation     func(enode.ID) float64 // reputation score of nodes, optional
 }
 
 func (cfg dialConfig) withDefaults() dialConfig {
@@ -270,7 +270,7 @@ loop:
 
 		select {
 		case node := <-nodesCh:
-			if err := d.checkDial(node); err != nil {
+			if err := d.checkDynDial(node); err != nil {
 				d.log.Trace("Discarding dial candidate", "id", node.ID(), "ip", node.IPAddr(), "reason", err)
 			} else {
 				d.startDial(newDialTask(node, dynDialedConn))
@@ -428,8 +428,10 @@ func (d *dialScheduler) checkDial(n *enode.Node) error {
 	if _, ok := d.pendingInbound[n.ID()]; ok {
 		return errPendingInbound
 	}
-	if d.netRestrict != nil && !d.netRestrict.ContainsAddr(n.IPAddr()) {
-		return errNetRestrict
+	if d.acl != nil {
+		if err := d.acl.checkNode(n); err != nil {
+			return err
+		}
 	}
 	if d.history.contains(string(n.ID().Bytes())) {
 		return errRecentlyDialed
@@ -437,6 +439,21 @@ func (d *dialScheduler) checkDial(n *enode.Node) error {
 	return nil
 }
 
+// checkDynDial returns an error if the given node should not be dialed dynamically.
+// Nodes with a negative reputation are dialed with decreasing probability, those
+// below reputationDialThreshold not at all. Static nodes are exempt from this.
+func (d *dialScheduler) checkDynDial(n *enode.Node) error {
+	if err := d.checkDial(n); err != nil {
+		return err
+	}
+	if d.reputation != nil {
+		if score := d.reputation(n.ID()); score < 0 && d.rand.Float64()*-reputationDialThreshold < -score {
+			return errBadReputation
+		}
+	}
+	return nil
+}
+
 // startStaticDials starts n static dial tasks.
 func (d *dialScheduler) startStaticDials(n int) (started int) {
 	for started = 0; started < n && len(d.staticPool) > 0; started++ {
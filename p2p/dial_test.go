@@ -132,11 +132,11 @@ func TestDialSchedNetRestrict(t *testing.T) {
 		newNode(uintID(0x08), "127.0.2.8:30303"),
 	}
 	config := dialConfig{
-		netRestrict:    new(netutil.Netlist),
+		acl:            &accessList{netRestrict: new(netutil.Netlist)},
 		maxActiveDials: 10,
 		maxDialPeers:   10,
 	}
-	config.netRestrict.Add("127.0.2.0/24")
+	config.acl.netRestrict.Add("127.0.2.0/24")
 	runDialTest(t, config, []dialTestRound{
 		{
 			discovered:   nodes,
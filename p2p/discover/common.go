@@ -51,9 +51,10 @@ type Config struct {
 	// All remaining settings are optional.
 
 	// Packet handling configuration:
-	NetRestrict   *netutil.Netlist  // list of allowed IP networks
-	Unhandled     chan<- ReadPacket // unhandled packets are sent on this channel
-	V5RespTimeout time.Duration     // timeout for v5 queries
+	NetRestrict   *netutil.Netlist        // list of allowed IP networks
+	NodeFilter    func(*enode.Node) error // rejects nodes in responses if it returns an error
+	Unhandled     chan<- ReadPacket       // unhandled packets are sent on this channel
+	V5RespTimeout time.Duration           // timeout for v5 queries
 
 	// Node table configuration:
 	Bootnodes               []*enode.Node // list of bootstrap nodes
@@ -221,6 +221,12 @@ func (tab *Table) setFallbackNodes(nodes []*enode.Node) error {
 			tab.log.Error("Bootstrap node filtered by netrestrict", "id", n.ID(), "ip", n.IPAddr())
 			continue
 		}
+		if tab.cfg.NodeFilter != nil {
+			if err := tab.cfg.NodeFilter(n); err != nil {
+				tab.log.Error("Bootstrap node filtered", "id", n.ID(), "ip", n.IPAddr(), "err", err)
+				continue
+			}
+		}
 		nursery = append(nursery, n)
 	}
 	tab.nursery = nursery
@@ -69,6 +69,7 @@ type UDPv4 struct {
 	conn        UDPConn
 	log         log.Logger
 	netrestrict *netutil.Netlist
+	nodeFilter  func(*enode.Node) error
 	priv        *ecdsa.PrivateKey
 	localNode   *enode.LocalNode
 	db          *enode.DB
@@ -134,6 +135,7 @@ func ListenV4(c UDPConn, ln *enode.LocalNode, cfg Config) (*UDPv4, error) {
 		conn:            newMeteredConn(c),
 		priv:            cfg.PrivateKey,
 		netrestrict:     cfg.NetRestrict,
+		nodeFilter:      cfg.NodeFilter,
 		localNode:       ln,
 		db:              ln.Database(),
 		gotreply:        make(chan reply),
@@ -621,8 +623,15 @@ func (t *UDPv4) nodeFromRPC(sender netip.AddrPort, rn v4wire.Node) (*enode.Node,
 		return nil, err
 	}
 	n := enode.NewV4(key, rn.IP, int(rn.TCP), int(rn.UDP))
-	err = n.ValidateComplete()
-	return n, err
+	if err := n.ValidateComplete(); err != nil {
+		return n, err
+	}
+	if t.nodeFilter != nil {
+		if err := t.nodeFilter(n); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
 }
 
 func nodeToRPC(n *enode.Node) v4wire.Node {
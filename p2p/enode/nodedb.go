@@ -21,6 +21,7 @@ import (
 	"crypto/rand"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"net/netip"
 	"os"
 	"sync"
@@ -54,12 +55,21 @@ const (
 	// Local information is keyed by ID only, the full key is "local:<ID>:seq".
 	// Use localItemKey to create those keys.
 	dbLocalSeq = "seq"
+
+	// Reputations are keyed by ID only, the full key is "rep:<ID>". They are kept
+	// apart from the discovery data, which expires much sooner.
+	dbReputationPrefix = "rep:"
+
+	// Bans are keyed by the banned node ID or network, the full key is
+	// "ban:<target>".
+	dbBanPrefix = "ban:"
 )
 
 const (
-	dbNodeExpiration = 24 * time.Hour // Time after which an unseen node should be dropped.
-	dbCleanupCycle   = time.Hour      // Time period for running the expiration task.
-	dbVersion        = 9
+	dbNodeExpiration       = 24 * time.Hour      // Time after which an unseen node should be dropped.
+	dbReputationExpiration = 30 * 24 * time.Hour // Time after which an unchanged reputation should be dropped.
+	dbCleanupCycle         = time.Hour           // Time period for running the expiration task.
+	dbVersion              = 9
 )
 
 var (
@@ -315,6 +325,8 @@ func (db *DB) expirer() {
 		select {
 		case <-tick.C:
 			db.expireNodes()
+			db.expireReputations()
+			db.expireBans()
 		case <-db.quit:
 			return
 		}
@@ -360,6 +372,32 @@ func (db *DB) expireNodes() {
 	}
 }
 
+// expireReputations deletes all reputations that have not been updated for some time.
+func (db *DB) expireReputations() {
+	it := db.lvl.NewIterator(util.BytesPrefix([]byte(dbReputationPrefix)), nil)
+	defer it.Release()
+
+	threshold := time.Now().Add(-dbReputationExpiration)
+	for it.Next() {
+		if rep, ok := decodeReputation(it.Value()); !ok || rep.Updated.Before(threshold) {
+			db.lvl.Delete(it.Key(), nil)
+		}
+	}
+}
+
+// expireBans deletes all bans that have expired.
+func (db *DB) expireBans() {
+	it := db.lvl.NewIterator(util.BytesPrefix([]byte(dbBanPrefix)), nil)
+	defer it.Release()
+
+	now := time.Now()
+	for it.Next() {
+		if expires, ok := decodeBanExpiry(it.Value()); !ok || (!expires.IsZero() && !now.Before(expires)) {
+			db.lvl.Delete(it.Key(), nil)
+		}
+	}
+}
+
 // LastPingReceived retrieves the time of the last ping packet received from
 // a remote node.
 func (db *DB) LastPingReceived(id ID, ip netip.Addr) time.Time {
@@ -442,6 +480,120 @@ func (db *DB) storeLocalSeq(id ID, n uint64) {
 	db.storeUint64(localItemKey(id, dbLocalSeq), n)
 }
 
+// Reputation is the locally assigned reputation of a node.
+type Reputation struct {
+	Score   float64   // Reputation score, the higher the better
+	Updated time.Time // Time the score was last changed
+}
+
+// reputationKey returns the database key for the reputation of a node.
+func reputationKey(id ID) []byte {
+	return append([]byte(dbReputationPrefix), id[:]...)
+}
+
+// decodeReputation decodes a stored reputation, consisting of the score as
+// float64 bits and the unix time of the update.
+func decodeReputation(blob []byte) (Reputation, bool) {
+	if len(blob) < 8 {
+		return Reputation{}, false
+	}
+	updated, read := binary.Varint(blob[8:])
+	if read <= 0 {
+		return Reputation{}, false
+	}
+	return Reputation{
+		Score:   math.Float64frombits(binary.BigEndian.Uint64(blob)),
+		Updated: time.Unix(updated, 0),
+	}, true
+}
+
+// Reputation retrieves the stored reputation of a node. The zero value is
+// returned for unknown nodes.
+func (db *DB) Reputation(id ID) Reputation {
+	blob, err := db.lvl.Get(reputationKey(id), nil)
+	if err != nil {
+		return Reputation{}
+	}
+	rep, _ := decodeReputation(blob)
+	return rep
+}
+
+// UpdateReputation stores the reputation of a node.
+func (db *DB) UpdateReputation(id ID, rep Reputation) error {
+	blob := make([]byte, 8+binary.MaxVarintLen64)
+	binary.BigEndian.PutUint64(blob, math.Float64bits(rep.Score))
+	blob = blob[:8+binary.PutVarint(blob[8:], rep.Updated.Unix())]
+	return db.lvl.Put(reputationKey(id), blob, nil)
+}
+
+// DeleteReputation deletes the stored reputation of a node.
+func (db *DB) DeleteReputation(id ID) error {
+	return db.lvl.Delete(reputationKey(id), nil)
+}
+
+// Reputations retrieves the stored reputations of all nodes.
+func (db *DB) Reputations() map[ID]Reputation {
+	it := db.lvl.NewIterator(util.BytesPrefix([]byte(dbReputationPrefix)), nil)
+	defer it.Release()
+
+	reps := make(map[ID]Reputation)
+	for it.Next() {
+		var id ID
+		if len(it.Key()) != len(dbReputationPrefix)+len(id) {
+			continue
+		}
+		copy(id[:], it.Key()[len(dbReputationPrefix):])
+		if rep, ok := decodeReputation(it.Value()); ok {
+			reps[id] = rep
+		}
+	}
+	return reps
+}
+
+// decodeBanExpiry decodes the stored expiry time of a ban, which is zero for
+// permanent bans.
+func decodeBanExpiry(blob []byte) (time.Time, bool) {
+	expires, read := binary.Varint(blob)
+	if read <= 0 {
+		return time.Time{}, false
+	}
+	if expires == 0 {
+		return time.Time{}, true
+	}
+	return time.Unix(expires, 0), true
+}
+
+// UpdateBan stores a ban of the given target, which is a node ID or a network.
+// The zero expiry time makes the ban permanent.
+func (db *DB) UpdateBan(target string, expires time.Time) error {
+	var unix int64
+	if !expires.IsZero() {
+		unix = expires.Unix()
+	}
+	blob := make([]byte, binary.MaxVarintLen64)
+	blob = blob[:binary.PutVarint(blob, unix)]
+	return db.lvl.Put([]byte(dbBanPrefix+target), blob, nil)
+}
+
+// DeleteBan deletes the stored ban of the given target.
+func (db *DB) DeleteBan(target string) error {
+	return db.lvl.Delete([]byte(dbBanPrefix+target), nil)
+}
+
+// Bans retrieves all stored bans and their expiry times.
+func (db *DB) Bans() map[string]time.Time {
+	it := db.lvl.NewIterator(util.BytesPrefix([]byte(dbBanPrefix)), nil)
+	defer it.Release()
+
+	bans := make(map[string]time.Time)
+	for it.Next() {
+		if expires, ok := decodeBanExpiry(it.Value()); ok {
+			bans[string(it.Key()[len(dbBanPrefix):])] = expires
+		}
+	}
+	return bans
+}
+
 // QuerySeeds retrieves random nodes to be used as potential seed nodes
 // for bootstrapping.
 func (db *DB) QuerySeeds(n int, maxAge time.Duration) []*Node {
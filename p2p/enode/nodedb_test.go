@@ -470,3 +470,65 @@ func TestDBExpireV5(t *testing.T) {
 	db.UpdateFindFailsV5(ID{}, ip, 4)
 	db.expireNodes()
 }
+
+// This test checks that reputations are stored, retrieved and expired.
+func TestDBReputation(t *testing.T) {
+	db, _ := OpenDB("")
+	defer db.Close()
+
+	var (
+		good  = ID{1}
+		bad   = ID{2}
+		stale = ID{3}
+		now   = time.Now().Truncate(time.Second)
+	)
+	if rep := db.Reputation(good); rep.Score != 0 {
+		t.Fatalf("unknown node has reputation %v", rep.Score)
+	}
+	db.UpdateReputation(good, Reputation{Score: 42.5, Updated: now})
+	db.UpdateReputation(bad, Reputation{Score: -10, Updated: now})
+	db.UpdateReputation(stale, Reputation{Score: 5, Updated: now.Add(-dbReputationExpiration - time.Minute)})
+
+	if rep := db.Reputation(good); rep.Score != 42.5 || !rep.Updated.Equal(now) {
+		t.Fatalf("reputation mismatch: have %+v, want score 42.5 at %v", rep, now)
+	}
+	if reps := db.Reputations(); len(reps) != 3 || reps[bad].Score != -10 {
+		t.Fatalf("reputations mismatch: %+v", reps)
+	}
+	db.expireReputations()
+	if reps := db.Reputations(); len(reps) != 2 {
+		t.Fatalf("reputation count mismatch after expiration: have %d, want 2", len(reps))
+	}
+	db.DeleteReputation(good)
+	if rep := db.Reputation(good); rep.Score != 0 {
+		t.Fatalf("deleted reputation still present: %+v", rep)
+	}
+	// Reputations must not interfere with the discovery data.
+	db.expireNodes()
+	if seeds := db.QuerySeeds(10, time.Hour); len(seeds) != 0 {
+		t.Fatalf("unexpected seeds: %v", seeds)
+	}
+}
+
+func TestDBBans(t *testing.T) {
+	db, _ := OpenDB("")
+	defer db.Close()
+
+	now := time.Now().Truncate(time.Second)
+	db.UpdateBan("10.0.0.0/8", time.Time{})
+	db.UpdateBan(ID{1}.String(), now.Add(time.Hour))
+	db.UpdateBan(ID{2}.String(), now.Add(-time.Minute))
+
+	bans := db.Bans()
+	if len(bans) != 3 || !bans["10.0.0.0/8"].IsZero() || !bans[ID{1}.String()].Equal(now.Add(time.Hour)) {
+		t.Fatalf("bans mismatch: %v", bans)
+	}
+	db.expireBans()
+	if bans := db.Bans(); len(bans) != 2 {
+		t.Fatalf("ban count mismatch after expiration: have %d, want 2", len(bans))
+	}
+	db.DeleteBan("10.0.0.0/8")
+	if bans := db.Bans(); len(bans) != 1 {
+		t.Fatalf("ban count mismatch after deletion: have %d, want 1", len(bans))
+	}
+}
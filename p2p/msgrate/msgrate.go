@@ -343,6 +343,37 @@ func (t *Trackers) meanCapacities() map[uint64]float64 {
 	return capacities
 }
 
+// RelativeCapacities returns the capacity of each tracked peer relative to the
+// mean capacity of all peers, averaged over the message kinds. A peer with the
+// average throughput has a relative capacity of one.
+func (t *Trackers) RelativeCapacities() map[string]float64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	var (
+		means    = t.meanCapacities()
+		relative = make(map[string]float64, len(t.trackers))
+	)
+	for id, tt := range t.trackers {
+		var sum float64
+		var kinds int
+		tt.lock.RLock()
+		for key, mean := range means {
+			if mean > 0 {
+				sum += tt.capacity[key] / mean
+				kinds++
+			}
+		}
+		tt.lock.RUnlock()
+
+		relative[id] = 1
+		if kinds > 0 {
+			relative[id] = sum / float64(kinds)
+		}
+	}
+	return relative
+}
+
 // TargetRoundTrip returns the current target round trip time for a request to
 // complete in.The returned RTT is slightly under the estimated RTT. The reason
 // is that message rate estimation is a 2 dimensional problem which is solvable
@@ -16,7 +16,12 @@
 
 package msgrate
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
 
 func TestCapacityOverflow(t *testing.T) {
 	tracker := NewTracker(nil, 1)
@@ -26,3 +31,14 @@ func TestCapacityOverflow(t *testing.T) {
 		t.Fatalf("Negative: %v", int32(cap))
 	}
 }
+
+func TestRelativeCapacities(t *testing.T) {
+	trackers := NewTrackers(log.Root())
+	trackers.Track("fast", NewTracker(map[uint64]float64{0: 30, 1: 60}, time.Second))
+	trackers.Track("slow", NewTracker(map[uint64]float64{0: 10, 1: 20}, time.Second))
+
+	rel := trackers.RelativeCapacities()
+	if rel["fast"] != 1.5 || rel["slow"] != 0.5 {
+		t.Fatalf("relative capacities mismatch: have %v, want fast 1.5, slow 0.5", rel)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"net"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/mclock"
@@ -115,6 +116,10 @@ type Peer struct {
 	pingRecv chan struct{}
 	disc     chan DiscReason
 
+	// violations counts the protocol violations reported by the protocols,
+	// which lower the reputation of the peer when it disconnects.
+	violations atomic.Int32
+
 	// events receives message send / receive events if set
 	events   *event.Feed
 	testPipe *MsgPipeRW // for testing
@@ -267,6 +272,13 @@ func (p *Peer) Log() log.Logger {
 	return p.log
 }
 
+// ReportViolation records that the peer violated a protocol, e.g. by sending an
+// invalid message. The violations lower the reputation of the peer once it is
+// disconnected, protocols still need to disconnect it themselves.
+func (p *Peer) ReportViolation() {
+	p.violations.Add(1)
+}
+
 func (p *Peer) run() (remoteRequested bool, err error) {
 	var (
 		writeStart = make(chan struct{}, 1)
@@ -554,7 +566,8 @@ type PeerInfo struct {
 		Trusted       bool   `json:"trusted"`
 		Static        bool   `json:"static"`
 	} `json:"network"`
-	Protocols map[string]interface{} `json:"protocols"` // Sub-protocol specific metadata fields
+	Protocols  map[string]interface{} `json:"protocols"`  // Sub-protocol specific metadata fields
+	Reputation float64                `json:"reputation"` // Locally assigned reputation score
 }
 
 // Info gathers and returns a collection of metadata known about a peer.
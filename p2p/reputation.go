@@ -0,0 +1,181 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+const (
+	// Reputation scores are kept within these bounds.
+	MinReputation = -100
+	MaxReputation = 100
+
+	// reputationHalfLife is the time after which a score decays to half its
+	// value, so that old merits and faults are eventually forgotten.
+	reputationHalfLife = 7 * 24 * time.Hour
+
+	// violationPenalty is subtracted from the score of a peer for each protocol
+	// violation.
+	violationPenalty = 10
+
+	// reputationDialThreshold is the score below which nodes are not dialed.
+	// Nodes with negative scores above it are dialed with decreasing probability.
+	reputationDialThreshold = -50
+
+	// reputationSeeds is the maximum number of nodes with good reputation which
+	// are dialed on startup, and reputationSeedScore is the minimum score of them.
+	reputationSeeds     = 32
+	reputationSeedScore = 10
+)
+
+var errBadReputation = errors.New("bad reputation")
+
+// reputationTable tracks the reputation scores of nodes, persisting them in the
+// node database. Scores decay towards zero over time.
+type reputationTable struct {
+	db  *enode.DB
+	now func() time.Time
+	mu  sync.Mutex // Serializes updates
+}
+
+func newReputationTable(db *enode.DB) *reputationTable {
+	return &reputationTable{db: db, now: time.Now}
+}
+
+// decayed returns the score of a stored reputation at the given time.
+func decayed(rep enode.Reputation, now time.Time) float64 {
+	if rep.Score == 0 || !now.After(rep.Updated) {
+		return rep.Score
+	}
+	return rep.Score * math.Pow(0.5, float64(now.Sub(rep.Updated))/float64(reputationHalfLife))
+}
+
+// score returns the current score of a node.
+func (t *reputationTable) score(id enode.ID) float64 {
+	return decayed(t.db.Reputation(id), t.now())
+}
+
+// adjust adds the given delta to the score of a node, returning the new score.
+func (t *reputationTable) adjust(id enode.ID, delta float64) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.set(id, t.score(id)+delta)
+}
+
+// set sets the score of a node, returning the new score. The caller must hold the lock.
+func (t *reputationTable) set(id enode.ID, score float64) float64 {
+	score = max(MinReputation, min(MaxReputation, score))
+	if score == 0 {
+		t.db.DeleteReputation(id)
+	} else {
+		t.db.UpdateReputation(id, enode.Reputation{Score: score, Updated: t.now()})
+	}
+	return score
+}
+
+// seeds returns the known records of the nodes with the best reputation.
+func (t *reputationTable) seeds() []*enode.Node {
+	type entry struct {
+		id    enode.ID
+		score float64
+	}
+	var (
+		now     = t.now()
+		entries []entry
+	)
+	for id, rep := range t.db.Reputations() {
+		if score := decayed(rep, now); score >= reputationSeedScore {
+			entries = append(entries, entry{id, score})
+		}
+	}
+	slices.SortFunc(entries, func(a, b entry) int { return cmp.Compare(b.score, a.score) })
+
+	var nodes []*enode.Node
+	for _, e := range entries {
+		if len(nodes) == reputationSeeds {
+			break
+		}
+		if n := t.db.Node(e.id); n != nil && n.TCP() != 0 {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// Reputation returns the reputation score of a node, which is zero for unknown
+// nodes or if the server is not running.
+func (srv *Server) Reputation(id enode.ID) float64 {
+	if t := srv.reputations(); t != nil {
+		return t.score(id)
+	}
+	return 0
+}
+
+// AdjustReputation adds the given delta to the reputation score of a node. The
+// score is bounded by MinReputation and MaxReputation. Protocols use this to
+// credit peers for useful work.
+func (srv *Server) AdjustReputation(id enode.ID, delta float64) {
+	if t := srv.reputations(); t != nil {
+		t.adjust(id, delta)
+	}
+}
+
+// SetReputation sets the reputation score of a node, returning the score after
+// bounding it by MinReputation and MaxReputation.
+func (srv *Server) SetReputation(id enode.ID, score float64) (float64, error) {
+	t := srv.reputations()
+	if t == nil {
+		return 0, errServerStopped
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.set(id, score), nil
+}
+
+// reputations returns the reputation table, or nil if the server is not running.
+func (srv *Server) reputations() *reputationTable {
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+
+	if !srv.running {
+		return nil
+	}
+	return srv.reputation
+}
+
+// penalizeViolations lowers the reputation of a disconnected peer for the protocol
+// violations it committed.
+func (srv *Server) penalizeViolations(pd peerDrop) {
+	violations := pd.violations.Load()
+	if perr, ok := pd.err.(*peerError); ok && !pd.requested && (perr.code == errInvalidMsgCode || perr.code == errInvalidMsg) {
+		violations++
+	}
+	if violations > 0 {
+		score := srv.reputation.adjust(pd.ID(), -violationPenalty*float64(violations))
+		srv.log.Debug("Penalized peer for protocol violations", "id", pd.ID(), "violations", violations, "reputation", score)
+	}
+}
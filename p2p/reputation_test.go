@@ -0,0 +1,112 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"errors"
+	"math"
+	mrand "math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+func TestReputationTable(t *testing.T) {
+	db, err := enode.OpenDB("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var (
+		now   = time.Unix(1700000000, 0)
+		table = newReputationTable(db)
+		id    = uintID(1)
+	)
+	table.now = func() time.Time { return now }
+
+	if score := table.adjust(id, 20); score != 20 {
+		t.Fatalf("wrong score after credit: have %v, want 20", score)
+	}
+	if score := table.adjust(id, 200); score != MaxReputation {
+		t.Fatalf("score not bounded: have %v, want %v", score, MaxReputation)
+	}
+	// The score halves with every half-life
+	now = now.Add(reputationHalfLife)
+	if score := table.score(id); math.Abs(score-MaxReputation/2) > 1e-9 {
+		t.Fatalf("wrong decayed score: have %v, want %v", score, MaxReputation/2)
+	}
+	// Resetting the score forgets the node
+	table.set(id, 0)
+	if reps := db.Reputations(); len(reps) != 0 {
+		t.Fatalf("reset reputation still stored: %v", reps)
+	}
+}
+
+func TestReputationSeeds(t *testing.T) {
+	db, err := enode.OpenDB("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	table := newReputationTable(db)
+	scores := map[uint16]float64{1: 50, 2: 5, 3: 80, 4: -20, 5: 30}
+	for i, score := range scores {
+		if i != 5 {
+			// Node 5 has no known record
+			db.UpdateNode(newNode(uintID(i), "127.0.0.1:30303"))
+		}
+		table.adjust(uintID(i), score)
+	}
+	seeds := table.seeds()
+	if len(seeds) != 2 || seeds[0].ID() != uintID(3) || seeds[1].ID() != uintID(1) {
+		t.Fatalf("wrong seeds: %v", seeds)
+	}
+}
+
+func TestReputationDialCheck(t *testing.T) {
+	scores := map[enode.ID]float64{
+		uintID(1): 20,
+		uintID(2): -20,
+		uintID(3): 2 * reputationDialThreshold,
+	}
+	d := &dialScheduler{dialConfig: dialConfig{
+		rand:       mrand.New(mrand.NewSource(1)),
+		reputation: func(id enode.ID) float64 { return scores[id] },
+	}}
+	skipped := make(map[enode.ID]int)
+	for i := 0; i < 1000; i++ {
+		for id := range scores {
+			if err := d.checkDynDial(newNode(id, "127.0.0.1:30303")); errors.Is(err, errBadReputation) {
+				skipped[id]++
+			} else if err != nil {
+				t.Fatalf("unexpected dial error: %v", err)
+			}
+		}
+	}
+	if skipped[uintID(1)] != 0 {
+		t.Errorf("node with good reputation skipped %d times", skipped[uintID(1)])
+	}
+	if n := skipped[uintID(2)]; n < 300 || n > 500 {
+		t.Errorf("node with negative reputation skipped %d times, want about 400", n)
+	}
+	if skipped[uintID(3)] != 1000 {
+		t.Errorf("node with bad reputation dialed %d times", 1000-skipped[uintID(3)])
+	}
+}
@@ -97,6 +97,9 @@ type Server struct {
 	discmix   *enode.FairMix
 	dialsched *dialScheduler
 
+	reputation *reputationTable
+	acl        *accessList
+
 	// This is read by the NAT port mapping loop.
 	portMappingRegister chan *portMapping
 
@@ -430,6 +433,8 @@ func (srv *Server) setupLocalNode() error {
 		return err
 	}
 	srv.nodedb = db
+	srv.reputation = newReputationTable(db)
+	srv.acl = newAccessList(db, srv.NetRestrict)
 	srv.localnode = enode.NewLocalNode(db, srv.PrivateKey)
 	srv.localnode.SetFallbackIP(net.IP{127, 0, 0, 1})
 	// TODO: check conflicts
@@ -450,6 +455,11 @@ func (srv *Server) setupDiscovery() error {
 	if srv.NoDiscovery {
 		return nil
 	}
+	// Reconnect to the nodes with the best reputation first.
+	if seeds := srv.reputation.seeds(); len(seeds) > 0 {
+		srv.log.Debug("Dialing nodes with good reputation", "count", len(seeds))
+		srv.discmix.AddSource(enode.WithSourceName("reputation", enode.IterNodes(seeds)))
+	}
 	conn, err := srv.setupUDPListening()
 	if err != nil {
 		return err
@@ -469,11 +479,11 @@ func (srv *Server) setupDiscovery() error {
 	// Start discovery services.
 	if srv.Config.DiscoveryV4 {
 		cfg := discover.Config{
-			PrivateKey:  srv.PrivateKey,
-			NetRestrict: srv.NetRestrict,
-			Bootnodes:   srv.BootstrapNodes,
-			Unhandled:   unhandled,
-			Log:         srv.log,
+			PrivateKey: srv.PrivateKey,
+			NodeFilter: srv.acl.checkNode,
+			Bootnodes:  srv.BootstrapNodes,
+			Unhandled:  unhandled,
+			Log:        srv.log,
 		}
 		ntab, err := discover.ListenV4(conn, srv.localnode, cfg)
 		if err != nil {
@@ -483,10 +493,10 @@ func (srv *Server) setupDiscovery() error {
 	}
 	if srv.Config.DiscoveryV5 {
 		cfg := discover.Config{
-			PrivateKey:  srv.PrivateKey,
-			NetRestrict: srv.NetRestrict,
-			Bootnodes:   srv.BootstrapNodesV5,
-			Log:         srv.log,
+			PrivateKey: srv.PrivateKey,
+			NodeFilter: srv.acl.checkNode,
+			Bootnodes:  srv.BootstrapNodesV5,
+			Log:        srv.log,
 		}
 		srv.discv5, err = discover.ListenV5(sconn, srv.localnode, cfg)
 		if err != nil {
@@ -529,9 +539,10 @@ func (srv *Server) setupDialScheduler() {
 		maxDialPeers:   srv.MaxDialedConns(),
 		maxActiveDials: srv.MaxPendingPeers,
 		log:            srv.Logger,
-		netRestrict:    srv.NetRestrict,
+		acl:            srv.acl,
 		dialer:         srv.Dialer,
 		clock:          srv.clock,
+		reputation:     srv.reputation.score,
 	}
 	if srv.discv4 != nil {
 		config.resolver = srv.discv4
@@ -720,6 +731,7 @@ running:
 			delete(peers, pd.ID())
 			srv.log.Debug("Removing p2p peer", "peercount", len(peers), "id", pd.ID(), "duration", d, "req", pd.requested, "err", pd.err)
 			srv.dialsched.peerRemoved(pd.rw)
+			srv.penalizeViolations(pd)
 			if pd.Inbound() {
 				inboundCount--
 				activeInboundPeerGauge.Dec(1)
@@ -763,6 +775,8 @@ func (srv *Server) postHandshakeChecks(peers map[enode.ID]*Peer, inboundCount in
 		return DiscAlreadyConnected
 	case c.node.ID() == srv.localnode.ID():
 		return DiscSelf
+	case srv.acl.checkID(c.node.ID()) != nil:
+		return DiscUselessPeer
 	default:
 		return nil
 	}
@@ -854,9 +868,9 @@ func (srv *Server) checkInboundConn(remoteIP netip.Addr) error {
 		// This case happens for internal test connections without remote address.
 		return nil
 	}
-	// Reject connections that do not match NetRestrict.
-	if srv.NetRestrict != nil && !srv.NetRestrict.ContainsAddr(remoteIP) {
-		return errors.New("not in netrestrict list")
+	// Reject connections that are not allowed by the access list.
+	if err := srv.acl.checkIP(remoteIP); err != nil {
+		return err
 	}
 	// Reject Internet peers that try too often.
 	now := srv.clock.Now()
@@ -1068,7 +1082,9 @@ func (srv *Server) PeersInfo() []*PeerInfo {
 	infos := make([]*PeerInfo, 0, srv.PeerCount())
 	for _, peer := range srv.Peers() {
 		if peer != nil {
-			infos = append(infos, peer.Info())
+			info := peer.Info()
+			info.Reputation = srv.Reputation(peer.ID())
+			infos = append(infos, info)
 		}
 	}
 	// Sort the result array alphabetically by node identifier
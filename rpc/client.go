@@ -85,9 +85,19 @@ type Client struct {
 	// This function, if non-nil, is called when the connection is lost.
 	reconnectFunc reconnectFunc
 
+	// This is set when subscriptions are resumed after the connection is lost.
+	resubscriber *resubscriber
+
+	// This is set for multi-endpoint clients, which send all requests through
+	// the clients of the pool.
+	pool *endpointPool
+
 	// config fields
 	batchItemLimit       int
 	batchResponseMaxSize int
+	rateLimiter          *rateLimiter
+	recorder             *Recorder
+	recordPeer           PeerInfo // the server, for recording
 
 	// writeConn is used for writing to the connection on the caller's goroutine. It should
 	// only be accessed outside of dispatch, with the write lock held. The write lock is
@@ -120,6 +130,8 @@ func (c *Client) newClientConn(conn ServerCodec) *clientConn {
 	ctx = context.WithValue(ctx, clientContextKey{}, c)
 	ctx = context.WithValue(ctx, peerInfoContextKey{}, conn.peerInfo())
 	handler := newHandler(ctx, conn, c.idgen, c.services, c.batchItemLimit, c.batchResponseMaxSize, nil)
+	handler.rateLimiter = c.rateLimiter
+	handler.recorder = c.recorder
 	return &clientConn{conn, handler}
 }
 
@@ -140,6 +152,7 @@ type requestOp struct {
 	err         error
 	resp        chan []*jsonrpcMessage // the response goes here
 	sub         *ClientSubscription    // set for Subscribe requests.
+	resubscribe bool                   // true when sub is being resumed
 	hadResponse bool                   // true when the request was responded to
 }
 
@@ -189,37 +202,42 @@ func DialContext(ctx context.Context, rawurl string) (*Client, error) {
 // The context is used to cancel or time out the initial connection establishment. It does
 // not affect subsequent interactions with the client.
 //
-// The client reconnects automatically when the connection is lost.
+// The client reconnects automatically when the connection is lost. Use WithEndpoints
+// to create a client which fails over between several servers.
 func DialOptions(ctx context.Context, rawurl string, options ...ClientOption) (*Client, error) {
-	u, err := url.Parse(rawurl)
-	if err != nil {
-		return nil, err
-	}
-
 	cfg := new(clientConfig)
 	for _, opt := range options {
 		opt.applyOption(cfg)
 	}
+	if len(cfg.endpoints) > 0 {
+		return newPoolClient(ctx, append([]string{rawurl}, cfg.endpoints...), cfg)
+	}
+
+	reconnect, err := newClientTransport(rawurl, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(ctx, cfg, reconnect)
+}
 
-	var reconnect reconnectFunc
+// newClientTransport creates the connect function for the given URL.
+func newClientTransport(rawurl string, cfg *clientConfig) (reconnectFunc, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
 	switch u.Scheme {
 	case "http", "https":
-		reconnect = newClientTransportHTTP(rawurl, cfg)
+		return newClientTransportHTTP(rawurl, cfg), nil
 	case "ws", "wss":
-		rc, err := newClientTransportWS(rawurl, cfg)
-		if err != nil {
-			return nil, err
-		}
-		reconnect = rc
+		return newClientTransportWS(rawurl, cfg)
 	case "stdio":
-		reconnect = newClientTransportIO(os.Stdin, os.Stdout)
+		return newClientTransportIO(os.Stdin, os.Stdout), nil
 	case "":
-		reconnect = newClientTransportIPC(rawurl)
+		return newClientTransportIPC(rawurl), nil
 	default:
 		return nil, fmt.Errorf("no known transport for URL scheme %q", u.Scheme)
 	}
-
-	return newClient(ctx, cfg, reconnect)
 }
 
 // ClientFromContext retrieves the client from the context, if any. This can be used to perform
@@ -247,6 +265,8 @@ func initClient(conn ServerCodec, services *serviceRegistry, cfg *clientConfig)
 		idgen:                cfg.idgen,
 		batchItemLimit:       cfg.batchItemLimit,
 		batchResponseMaxSize: cfg.batchResponseLimit,
+		rateLimiter:          cfg.rateLimiter,
+		recorder:             cfg.recorder,
 		writeConn:            conn,
 		close:                make(chan struct{}),
 		closing:              make(chan struct{}),
@@ -263,9 +283,20 @@ func initClient(conn ServerCodec, services *serviceRegistry, cfg *clientConfig)
 	if c.idgen == nil {
 		c.idgen = randomIDGenerator()
 	}
+	if c.recorder != nil {
+		if isHTTP {
+			c.recordPeer = PeerInfo{Transport: "http", RemoteAddr: conn.remoteAddr()}
+		} else {
+			c.recordPeer = conn.peerInfo()
+		}
+	}
 
 	// Launch the main loop.
 	if !isHTTP {
+		if cfg.reconnect != nil {
+			c.resubscriber = newResubscriber(c, *cfg.reconnect)
+			go c.resubscriber.loop()
+		}
 		go c.dispatch(conn)
 	}
 	return c
@@ -296,6 +327,10 @@ func (c *Client) SupportedModules() (map[string]string, error) {
 
 // Close closes the client, aborting any in-flight requests.
 func (c *Client) Close() {
+	if c.pool != nil {
+		c.pool.close()
+		return
+	}
 	if c.isHTTP {
 		return
 	}
@@ -310,6 +345,10 @@ func (c *Client) Close() {
 // This method only works for clients using HTTP, it doesn't have
 // any effect for clients using another transport.
 func (c *Client) SetHeader(key, value string) {
+	if c.pool != nil {
+		c.pool.setHeader(key, value)
+		return
+	}
 	if !c.isHTTP {
 		return
 	}
@@ -338,6 +377,11 @@ func (c *Client) CallContext(ctx context.Context, result interface{}, method str
 	if result != nil && reflect.TypeOf(result).Kind() != reflect.Pointer {
 		return fmt.Errorf("call result parameter must be pointer or nil interface: %v", result)
 	}
+	if c.pool != nil {
+		return c.pool.do(ctx, func(member *Client) error {
+			return member.CallContext(ctx, result, method, args...)
+		})
+	}
 	msg, err := c.newMessage(method, args...)
 	if err != nil {
 		return err
@@ -347,6 +391,7 @@ func (c *Client) CallContext(ctx context.Context, result interface{}, method str
 		resp: make(chan []*jsonrpcMessage, 1),
 	}
 
+	start := time.Now()
 	if c.isHTTP {
 		err = c.sendHTTP(ctx, op, msg)
 	} else {
@@ -362,6 +407,9 @@ func (c *Client) CallContext(ctx context.Context, result interface{}, method str
 		return err
 	}
 	resp := batchresp[0]
+	if c.recorder != nil {
+		c.recorder.record(RecordSourceClient, c.recordPeer, msg, resp, start)
+	}
 	switch {
 	case resp.Error != nil:
 		return resp.decodeError()
@@ -400,6 +448,11 @@ func (c *Client) BatchCallContext(ctx context.Context, b []BatchElem) error {
 	if len(b) == 0 {
 		return &invalidRequestError{"empty batch"}
 	}
+	if c.pool != nil {
+		return c.pool.do(ctx, func(member *Client) error {
+			return member.BatchCallContext(ctx, b)
+		})
+	}
 	var (
 		msgs = make([]*jsonrpcMessage, len(b))
 		byID = make(map[string]int, len(b))
@@ -419,6 +472,7 @@ func (c *Client) BatchCallContext(ctx context.Context, b []BatchElem) error {
 	}
 
 	var err error
+	start := time.Now()
 	if c.isHTTP {
 		err = c.sendBatchHTTP(ctx, op, msgs)
 	} else {
@@ -447,6 +501,9 @@ func (c *Client) BatchCallContext(ctx context.Context, b []BatchElem) error {
 			continue
 		}
 		delete(byID, string(resp.ID))
+		if c.recorder != nil {
+			c.recorder.record(RecordSourceClient, c.recordPeer, msgs[index], resp, start)
+		}
 
 		// Assign result and error.
 		elem := &b[index]
@@ -471,6 +528,11 @@ func (c *Client) BatchCallContext(ctx context.Context, b []BatchElem) error {
 
 // Notify sends a notification, i.e. a method call that doesn't expect a response.
 func (c *Client) Notify(ctx context.Context, method string, args ...interface{}) error {
+	if c.pool != nil {
+		return c.pool.do(ctx, func(member *Client) error {
+			return member.Notify(ctx, method, args...)
+		})
+	}
 	op := new(requestOp)
 	msg, err := c.newMessage(method, args...)
 	if err != nil {
@@ -478,6 +540,9 @@ func (c *Client) Notify(ctx context.Context, method string, args ...interface{})
 	}
 	msg.ID = nil
 
+	if c.recorder != nil {
+		c.recorder.record(RecordSourceClient, c.recordPeer, msg, nil, time.Now())
+	}
 	if c.isHTTP {
 		return c.sendHTTP(ctx, op, msg)
 	}
@@ -510,6 +575,9 @@ func (c *Client) Subscribe(ctx context.Context, namespace string, channel interf
 	if chanVal.IsNil() {
 		panic("channel given to Subscribe must not be nil")
 	}
+	if c.pool != nil {
+		return c.pool.subscribe(ctx, c, namespace, chanVal, args)
+	}
 	if c.isHTTP {
 		return nil, ErrNotificationsUnsupported
 	}
@@ -521,7 +589,10 @@ func (c *Client) Subscribe(ctx context.Context, namespace string, channel interf
 	op := &requestOp{
 		ids:  []json.RawMessage{msg.ID},
 		resp: make(chan []*jsonrpcMessage, 1),
-		sub:  newClientSubscription(c, namespace, chanVal),
+		sub:  newClientSubscription(c, namespace, msg.Params, chanVal),
+	}
+	if c.resubscriber != nil && namespace == "eth" && len(args) > 0 && args[0] == "logs" {
+		op.sub.logs = new(logCursor)
 	}
 
 	// Send the subscription request.
@@ -532,6 +603,9 @@ func (c *Client) Subscribe(ctx context.Context, namespace string, channel interf
 	if _, err := op.wait(ctx, c); err != nil {
 		return nil, err
 	}
+	if op.sub.logs != nil {
+		c.initLogCursor(ctx, op.sub)
+	}
 	return op.sub, nil
 }
 
@@ -539,6 +613,9 @@ func (c *Client) Subscribe(ctx context.Context, namespace string, channel interf
 // transport. When this returns false, Subscribe and related methods will return
 // ErrNotificationsUnsupported.
 func (c *Client) SupportsSubscriptions() bool {
+	if c.pool != nil {
+		return c.pool.supportsSubscriptions()
+	}
 	return !c.isHTTP
 }
 
@@ -680,6 +757,7 @@ func (c *Client) dispatch(codec ServerCodec) {
 
 		case err := <-c.readErr:
 			conn.handler.log.Debug("RPC connection read error", "err", err)
+			c.resubscribeLost(conn)
 			conn.close(err, lastOp)
 			reading = false
 
@@ -692,6 +770,7 @@ func (c *Client) dispatch(codec ServerCodec) {
 				// In those cases the caller will notice first and reconnect. Closing the
 				// handler terminates all waiting requests (closing op.resp) except for
 				// lastOp, which will be transferred to the new handler.
+				c.resubscribeLost(conn)
 				conn.close(errClientReconnected, lastOp)
 				c.drainRead()
 			}
@@ -725,6 +804,14 @@ func (c *Client) dispatch(codec ServerCodec) {
 	}
 }
 
+// resubscribeLost hands the subscriptions of a broken connection to the resubscriber,
+// if subscriptions should be resumed.
+func (c *Client) resubscribeLost(conn *clientConn) {
+	if c.resubscriber != nil {
+		c.resubscriber.add(conn.handler.takeClientSubscriptions())
+	}
+}
+
 // drainRead drops read messages until an error occurs.
 func (c *Client) drainRead() {
 	for {
@@ -18,6 +18,7 @@ package rpc
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"go.opentelemetry.io/otel/propagation"
@@ -43,6 +44,16 @@ type clientConfig struct {
 	idgen              func() ID
 	batchItemLimit     int
 	batchResponseLimit int
+	rateLimiter        *rateLimiter
+	recorder           *Recorder
+
+	// Reconnect options
+	reconnect *ReconnectPolicy
+
+	// Multi-endpoint options
+	endpoints           []string
+	endpointSelection   EndpointSelection
+	healthCheckInterval time.Duration
 }
 
 func (cfg *clientConfig) initHeaders() {
@@ -160,3 +171,91 @@ func WithBatchResponseSizeLimit(sizeLimit int) ClientOption {
 		cfg.batchResponseLimit = sizeLimit
 	})
 }
+
+// WithRecorder configures the client to write all method calls it sends, and their
+// responses, to the given recorder. Calls made by the server to methods registered
+// on the client with RegisterName are recorded as well.
+func WithRecorder(r *Recorder) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.recorder = r
+	})
+}
+
+// ReconnectPolicy configures how the client restores subscriptions after the
+// connection is lost. See WithReconnect.
+type ReconnectPolicy struct {
+	// MinBackoff is the delay before the first reconnection attempt. The delay
+	// doubles after every failed attempt. If zero, it defaults to 500ms.
+	MinBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between reconnection attempts. If zero,
+	// it defaults to 30s.
+	MaxBackoff time.Duration
+
+	// MaxAttempts is the number of failed reconnection attempts after which the
+	// lost subscriptions are given up. Zero means there is no limit.
+	MaxAttempts int
+}
+
+// WithReconnect enables automatic resumption of subscriptions for websocket and IPC
+// clients.
+//
+// When the connection is lost, the client reconnects using exponential backoff and
+// re-issues all active subscriptions with their original arguments. Notifications
+// are delivered to the existing subscription channels, and the subscription Err
+// channel only receives an error when the subscription could not be restored.
+// For "logs" subscriptions in the "eth" namespace, logs emitted while the client
+// was disconnected are backfilled using eth_getLogs.
+//
+// Note that calls which are in flight while the connection breaks still fail.
+func WithReconnect(policy ReconnectPolicy) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.reconnect = &policy
+	})
+}
+
+// EndpointSelection is the strategy used by a multi-endpoint client to choose the
+// endpoint for a request.
+type EndpointSelection int
+
+const (
+	// SelectRoundRobin distributes requests evenly over all healthy endpoints.
+	SelectRoundRobin EndpointSelection = iota
+
+	// SelectLowestLatency sends requests to the healthy endpoint with the lowest
+	// latency, as measured by health checks.
+	SelectLowestLatency
+)
+
+// WithEndpoints configures additional endpoints for the client, making it a
+// multi-endpoint client. The URL given to DialOptions is the first endpoint.
+//
+// A multi-endpoint client periodically checks the health of all endpoints. Requests
+// are sent to healthy endpoints according to the configured EndpointSelection, and
+// they are retried on the next endpoint when sending fails with a transport error.
+// Note that this means a call may be executed by more than one server.
+//
+// Subscriptions are pinned to a single endpoint. When the subscription ends because
+// the endpoint failed, it is re-created on another endpoint. Notifications sent while
+// the subscription moves between endpoints can be lost.
+func WithEndpoints(urls ...string) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.endpoints = append(cfg.endpoints, urls...)
+	})
+}
+
+// WithEndpointSelection configures how a multi-endpoint client chooses the endpoint
+// for a request. The default is SelectRoundRobin.
+func WithEndpointSelection(sel EndpointSelection) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.endpointSelection = sel
+	})
+}
+
+// WithHealthCheckInterval configures how often a multi-endpoint client checks the
+// health of its endpoints. The default is 15s.
+func WithHealthCheckInterval(interval time.Duration) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.healthCheckInterval = interval
+	})
+}
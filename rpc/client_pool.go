@@ -0,0 +1,425 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	defaultHealthCheckInterval = 15 * time.Second
+	healthCheckTimeout         = 5 * time.Second
+)
+
+var errNoEndpoint = errors.New("no endpoint available")
+
+// endpointPool holds the member clients of a multi-endpoint client.
+type endpointPool struct {
+	mu        sync.Mutex // guards headers of cfg
+	cfg       *clientConfig
+	services  *serviceRegistry
+	endpoints []*endpoint
+	next      atomic.Uint64 // round-robin counter
+
+	closeOnce sync.Once
+	closing   chan struct{}
+	wg        sync.WaitGroup
+}
+
+// endpoint is a server of a multi-endpoint client.
+type endpoint struct {
+	url     string
+	isHTTP  bool
+	healthy atomic.Bool
+	latency atomic.Int64 // moving average of health check duration
+
+	mu     sync.Mutex
+	client *Client // nil until the first successful dial
+}
+
+func newPoolClient(ctx context.Context, urls []string, cfg *clientConfig) (*Client, error) {
+	p := &endpointPool{
+		cfg:      cfg,
+		services: new(serviceRegistry),
+		closing:  make(chan struct{}),
+	}
+	for _, rawurl := range urls {
+		u, err := url.Parse(rawurl)
+		if err != nil {
+			return nil, err
+		}
+		p.endpoints = append(p.endpoints, &endpoint{
+			url:    rawurl,
+			isHTTP: u.Scheme == "http" || u.Scheme == "https",
+		})
+	}
+
+	// Connect to all endpoints. It's fine if some of them are down, they
+	// will be dialed again by the health checks.
+	if err := p.checkHealth(ctx); err != nil {
+		return nil, err
+	}
+	if cfg.healthCheckInterval <= 0 {
+		cfg.healthCheckInterval = defaultHealthCheckInterval
+	}
+	p.wg.Add(1)
+	go p.healthLoop()
+
+	c := &Client{
+		services: p.services,
+		idgen:    cfg.idgen,
+		pool:     p,
+	}
+	return c, nil
+}
+
+// dial creates the client of an endpoint.
+func (p *endpointPool) dial(ctx context.Context, ep *endpoint) (*Client, error) {
+	p.mu.Lock()
+	connect, err := newClientTransport(ep.url, p.cfg)
+	p.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c := initClient(conn, p.services, p.cfg)
+	c.reconnectFunc = connect
+	return c, nil
+}
+
+// healthLoop checks the health of all endpoints periodically.
+func (p *endpointPool) healthLoop() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.cfg.healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.checkHealth(context.Background())
+		case <-p.closing:
+			return
+		}
+	}
+}
+
+// checkHealth checks all endpoints concurrently. It returns an error if
+// none of the endpoints could be dialed.
+func (p *endpointPool) checkHealth(ctx context.Context) error {
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(p.endpoints))
+	)
+	for i, ep := range p.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = p.check(ctx, ep)
+		}()
+	}
+	wg.Wait()
+
+	for _, ep := range p.endpoints {
+		if ep.get() != nil {
+			return nil
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// check dials the endpoint if necessary and measures the duration of a trivial call.
+func (p *endpointPool) check(ctx context.Context, ep *endpoint) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	c := ep.get()
+	if c == nil {
+		var err error
+		if c, err = p.dial(ctx, ep); err != nil {
+			ep.setHealthy(false, err)
+			return err
+		}
+		if !p.setClient(ep, c) {
+			return ErrClientQuit
+		}
+	}
+	start := time.Now()
+	var modules map[string]string
+	err := c.CallContext(ctx, &modules, "rpc_modules")
+	if err != nil && (isTransportError(err) || ctx.Err() != nil) {
+		ep.setHealthy(false, err)
+		return err
+	}
+	// Errors returned by the server still show the endpoint is reachable.
+	ep.observeLatency(time.Since(start))
+	ep.setHealthy(true, nil)
+	return nil
+}
+
+// setClient stores the client of an endpoint. It returns false if the
+// pool was closed in the meantime.
+func (p *endpointPool) setClient(ep *endpoint, c *Client) bool {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	select {
+	case <-p.closing:
+		c.Close()
+		return false
+	default:
+		ep.client = c
+		return true
+	}
+}
+
+func (ep *endpoint) get() *Client {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	return ep.client
+}
+
+func (ep *endpoint) setHealthy(healthy bool, err error) {
+	if ep.healthy.Swap(healthy) != healthy {
+		if healthy {
+			log.Debug("RPC endpoint is healthy", "url", ep.url)
+		} else {
+			log.Debug("RPC endpoint is unhealthy", "url", ep.url, "err", err)
+		}
+	}
+}
+
+// observeLatency adds a sample to the latency average.
+func (ep *endpoint) observeLatency(d time.Duration) {
+	prev := ep.latency.Load()
+	if prev == 0 {
+		ep.latency.Store(int64(d))
+	} else {
+		ep.latency.Store((4*prev + int64(d)) / 5)
+	}
+}
+
+// candidates returns the endpoints in the order they should be tried for a request.
+// Healthy endpoints come first, ordered by the selection strategy.
+func (p *endpointPool) candidates() []*endpoint {
+	var healthy, unhealthy []*endpoint
+	for _, ep := range p.endpoints {
+		if ep.healthy.Load() {
+			healthy = append(healthy, ep)
+		} else {
+			unhealthy = append(unhealthy, ep)
+		}
+	}
+	switch {
+	case len(healthy) == 0:
+	case p.cfg.endpointSelection == SelectLowestLatency:
+		slices.SortStableFunc(healthy, func(a, b *endpoint) int {
+			return cmp.Compare(a.latency.Load(), b.latency.Load())
+		})
+	default:
+		n := int(p.next.Add(1) % uint64(len(healthy)))
+		healthy = append(healthy[n:], healthy[:n]...)
+	}
+	return append(healthy, unhealthy...)
+}
+
+// do runs fn with the clients of the pool until it doesn't fail with a transport error.
+func (p *endpointPool) do(ctx context.Context, fn func(*Client) error) error {
+	err := errNoEndpoint
+	for _, ep := range p.candidates() {
+		c := ep.get()
+		if c == nil {
+			continue
+		}
+		if err = fn(c); !isTransportError(err) {
+			return err
+		}
+		ep.setHealthy(false, err)
+		if ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// isTransportError reports whether err is a failure to communicate with the server.
+func isTransportError(err error) bool {
+	var (
+		rpcErr    Error
+		typeErr   *json.UnmarshalTypeError
+		syntaxErr *json.SyntaxError
+	)
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return false
+	case errors.Is(err, ErrNoResult), errors.Is(err, ErrNotificationsUnsupported):
+		return false
+	case errors.As(err, &rpcErr), errors.As(err, &typeErr), errors.As(err, &syntaxErr):
+		return false
+	}
+	return true
+}
+
+func (p *endpointPool) supportsSubscriptions() bool {
+	for _, ep := range p.endpoints {
+		if !ep.isHTTP {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *endpointPool) setHeader(key, value string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, ep := range p.endpoints {
+		if c := ep.get(); c != nil {
+			c.SetHeader(key, value)
+		}
+	}
+	// Endpoints dialed later use the header as well.
+	p.cfg.setHeader(key, value)
+}
+
+func (p *endpointPool) close() {
+	p.closeOnce.Do(func() {
+		close(p.closing)
+		p.wg.Wait()
+		for _, ep := range p.endpoints {
+			if c := ep.get(); c != nil {
+				c.Close()
+			}
+		}
+	})
+}
+
+// subscribe creates a subscription on one of the endpoints. Notifications are
+// relayed to the returned subscription, which is owned by the pool client c.
+func (p *endpointPool) subscribe(ctx context.Context, c *Client, namespace string, channel reflect.Value, args []interface{}) (*ClientSubscription, error) {
+	if !p.supportsSubscriptions() {
+		return nil, ErrNotificationsUnsupported
+	}
+	params, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	r := &subscriptionRelay{
+		pool:      p,
+		namespace: namespace,
+		args:      args,
+		sub:       newClientSubscription(c, namespace, params, channel),
+	}
+	if err := r.subscribe(ctx, nil); err != nil {
+		return nil, err
+	}
+	go r.sub.run()
+	go r.loop()
+	return r.sub, nil
+}
+
+// subscriptionRelay forwards notifications of a subscription on an endpoint
+// to the subscription of the pool client.
+type subscriptionRelay struct {
+	pool      *endpointPool
+	namespace string
+	args      []interface{}
+	sub       *ClientSubscription
+
+	// current upstream subscription
+	endpoint *endpoint
+	upstream *ClientSubscription
+	ch       chan json.RawMessage
+}
+
+// subscribe creates the upstream subscription on a healthy endpoint other than exclude.
+func (r *subscriptionRelay) subscribe(ctx context.Context, exclude *endpoint) error {
+	err := errNoEndpoint
+	for _, ep := range r.pool.candidates() {
+		c := ep.get()
+		if ep == exclude || ep.isHTTP || c == nil {
+			continue
+		}
+		ch := make(chan json.RawMessage)
+		var upstream *ClientSubscription
+		upstream, err = c.Subscribe(ctx, r.namespace, ch, r.args...)
+		if err == nil {
+			r.endpoint, r.upstream, r.ch = ep, upstream, ch
+			r.sub.setID(upstream.id())
+			return nil
+		}
+		if !isTransportError(err) {
+			return err
+		}
+		ep.setHealthy(false, err)
+	}
+	return err
+}
+
+// loop forwards notifications until the subscription ends, moving the upstream
+// subscription to another endpoint when its endpoint fails.
+func (r *subscriptionRelay) loop() {
+	for {
+		select {
+		case val := <-r.ch:
+			if !r.sub.deliver(val) {
+				r.upstream.Unsubscribe()
+				return
+			}
+
+		case err := <-r.upstream.Err():
+			select {
+			case <-r.pool.closing:
+				r.sub.close(ErrClientQuit)
+				return
+			default:
+			}
+			if err != nil && !isTransportError(err) {
+				r.sub.close(err)
+				return
+			}
+			log.Debug("Moving RPC subscription to another endpoint", "from", r.endpoint.url, "err", err)
+			r.endpoint.setHealthy(false, err)
+			ctx, cancel := context.WithTimeout(context.Background(), subscribeTimeout)
+			err = r.subscribe(ctx, r.endpoint)
+			cancel()
+			if err != nil {
+				r.sub.close(err)
+				return
+			}
+
+		case <-r.sub.forwardDone:
+			r.upstream.Unsubscribe()
+			return
+		}
+	}
+}
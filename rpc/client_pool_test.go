@@ -0,0 +1,170 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingServer is an HTTP server which counts echo calls.
+func countingServer(t *testing.T) (*httptest.Server, *atomic.Int64) {
+	srv := newTestServer()
+	t.Cleanup(srv.Stop)
+	count := new(atomic.Int64)
+	hs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count.Add(1)
+		srv.ServeHTTP(w, r)
+	}))
+	t.Cleanup(hs.Close)
+	return hs, count
+}
+
+func TestClientPoolRoundRobin(t *testing.T) {
+	t.Parallel()
+
+	hs1, count1 := countingServer(t)
+	hs2, count2 := countingServer(t)
+	client, err := DialOptions(context.Background(), hs1.URL, WithEndpoints(hs2.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	count1.Store(0)
+	count2.Store(0)
+	for i := 0; i < 10; i++ {
+		var resp echoResult
+		if err := client.Call(&resp, "test_echo", "hello", i, &echoArgs{"world"}); err != nil {
+			t.Fatal(err)
+		}
+		if resp.Int != i {
+			t.Fatalf("wrong result: %v", resp)
+		}
+	}
+	if count1.Load() != 5 || count2.Load() != 5 {
+		t.Fatalf("requests not balanced: %d, %d", count1.Load(), count2.Load())
+	}
+}
+
+func TestClientPoolFailover(t *testing.T) {
+	t.Parallel()
+
+	hs1, count1 := countingServer(t)
+	hs2, count2 := countingServer(t)
+	client, err := DialOptions(context.Background(), hs1.URL, WithEndpoints(hs2.URL), WithEndpointSelection(SelectLowestLatency))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// Shut down one of the servers. All calls should succeed on the other one.
+	hs1.Close()
+	count2.Store(0)
+	for i := 0; i < 5; i++ {
+		var resp echoResult
+		if err := client.Call(&resp, "test_echo", "hello", i, &echoArgs{"world"}); err != nil {
+			t.Fatalf("call %d failed: %v", i, err)
+		}
+	}
+	if count2.Load() != 5 {
+		t.Fatalf("wrong number of requests on healthy endpoint: %d", count2.Load())
+	}
+	if count1.Load() > 2 {
+		t.Fatalf("too many requests on failed endpoint: %d", count1.Load())
+	}
+
+	// RPC errors are not retried.
+	count2.Store(0)
+	if err := client.Call(nil, "test_returnError"); err == nil {
+		t.Fatal("expected error")
+	}
+	if count2.Load() != 1 {
+		t.Fatalf("failing call was retried")
+	}
+}
+
+func TestClientPoolSubscriptionFailover(t *testing.T) {
+	t.Parallel()
+
+	var (
+		services  [2]*logsTestService
+		servers   [2]*httptest.Server
+		listeners [2]*killableListener
+	)
+	for i := range servers {
+		services[i] = &logsTestService{subs: make(map[*Notifier]ID)}
+		srv := NewServer()
+		defer srv.Stop()
+		if err := srv.RegisterName("eth", services[i]); err != nil {
+			t.Fatal(err)
+		}
+		servers[i] = httptest.NewUnstartedServer(srv.WebsocketHandler([]string{"*"}))
+		listeners[i] = &killableListener{Listener: servers[i].Listener}
+		servers[i].Listener = listeners[i]
+		servers[i].Start()
+		defer servers[i].Close()
+	}
+	client, err := DialOptions(context.Background(), "ws://"+servers[0].Listener.Addr().String(),
+		WithEndpoints("ws://"+servers[1].Listener.Addr().String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ch := make(chan testLog)
+	sub, err := client.EthSubscribe(context.Background(), ch, "logs", map[string]any{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	// Find the server hosting the subscription.
+	pinned := -1
+	for i, s := range services {
+		s.mu.Lock()
+		if len(s.subs) > 0 {
+			pinned = i
+		}
+		s.mu.Unlock()
+	}
+	if pinned < 0 {
+		t.Fatal("subscription not created on any server")
+	}
+	services[pinned].emit(testLog{BlockNumber: 1})
+	expectLogs(t, ch, sub, []testLog{{BlockNumber: 1}})
+
+	// Kill the server. The subscription should move to the other one.
+	servers[pinned].Close()
+	listeners[pinned].killConns()
+	other := services[1-pinned]
+	for {
+		other.mu.Lock()
+		n := len(other.subs)
+		other.mu.Unlock()
+		if n > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	other.emit(testLog{BlockNumber: 2})
+	expectLogs(t, ch, sub, []testLog{{BlockNumber: 2}})
+}
@@ -0,0 +1,352 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	defaultMinReconnectBackoff = 500 * time.Millisecond
+	defaultMaxReconnectBackoff = 30 * time.Second
+)
+
+// resubscriber restores the subscriptions of a client after its connection was lost.
+type resubscriber struct {
+	client *Client
+	policy ReconnectPolicy
+
+	mu   sync.Mutex
+	lost []*ClientSubscription
+	wake chan struct{}
+}
+
+func newResubscriber(c *Client, policy ReconnectPolicy) *resubscriber {
+	if policy.MinBackoff <= 0 {
+		policy.MinBackoff = defaultMinReconnectBackoff
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = defaultMaxReconnectBackoff
+	}
+	policy.MaxBackoff = max(policy.MaxBackoff, policy.MinBackoff)
+	return &resubscriber{client: c, policy: policy, wake: make(chan struct{}, 1)}
+}
+
+// add queues subscriptions for resumption.
+func (r *resubscriber) add(subs []*ClientSubscription) {
+	if len(subs) == 0 {
+		return
+	}
+	r.mu.Lock()
+	for _, sub := range subs {
+		if !slices.Contains(r.lost, sub) {
+			r.lost = append(r.lost, sub)
+		}
+	}
+	r.mu.Unlock()
+
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// take removes all queued subscriptions.
+func (r *resubscriber) take() []*ClientSubscription {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	subs := r.lost
+	r.lost = nil
+	return subs
+}
+
+// closeAll ends all queued subscriptions with the given error.
+func (r *resubscriber) closeAll(err error) {
+	for _, sub := range r.take() {
+		sub.close(err)
+	}
+}
+
+// loop is the main loop of the resubscriber. It runs until the client is closed.
+func (r *resubscriber) loop() {
+	for {
+		select {
+		case <-r.wake:
+			r.run()
+		case <-r.client.closing:
+			r.closeAll(ErrClientQuit)
+			return
+		}
+	}
+}
+
+// run resumes queued subscriptions until none are left, backing off
+// exponentially while the server can't be reached.
+func (r *resubscriber) run() {
+	var (
+		delay    = r.policy.MinBackoff
+		attempts int
+	)
+	for {
+		subs := r.take()
+		if len(subs) == 0 {
+			return
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-r.client.closing:
+			timer.Stop()
+			r.add(subs)
+			return
+		}
+
+		failed, err := r.resubscribe(subs)
+		if len(failed) == 0 {
+			delay, attempts = r.policy.MinBackoff, 0
+			continue
+		}
+		attempts++
+		if r.policy.MaxAttempts > 0 && attempts >= r.policy.MaxAttempts {
+			log.Warn("Giving up on RPC subscriptions", "count", len(failed), "attempts", attempts, "err", err)
+			r.add(failed)
+			r.closeAll(err)
+			return
+		}
+		log.Debug("Failed to resume RPC subscriptions", "count", len(failed), "attempts", attempts, "err", err)
+		r.add(failed)
+		delay = min(2*delay, r.policy.MaxBackoff)
+	}
+}
+
+// resubscribe resumes the given subscriptions. When the server can't be reached,
+// it returns the subscriptions which weren't resumed yet.
+func (r *resubscriber) resubscribe(subs []*ClientSubscription) ([]*ClientSubscription, error) {
+	for i, sub := range subs {
+		if sub.done() {
+			continue
+		}
+		err := r.client.resubscribe(sub)
+		if err == nil {
+			continue
+		}
+		var rpcErr Error
+		if errors.As(err, &rpcErr) {
+			// The server rejected the subscription, retrying won't help.
+			sub.close(err)
+			continue
+		}
+		return subs[i:], err
+	}
+	return nil, nil
+}
+
+// resubscribe re-issues the subscribe call of sub. The new subscription ID is
+// registered with the handler of the current connection.
+func (c *Client) resubscribe(sub *ClientSubscription) error {
+	ctx, cancel := context.WithTimeout(context.Background(), subscribeTimeout)
+	defer cancel()
+
+	msg := &jsonrpcMessage{Version: vsn, ID: c.nextID(), Method: sub.namespace + subscribeMethodSuffix, Params: sub.params}
+	op := &requestOp{
+		ids:         []json.RawMessage{msg.ID},
+		resp:        make(chan []*jsonrpcMessage, 1),
+		sub:         sub,
+		resubscribe: true,
+	}
+	if sub.logs != nil {
+		// Hold back notifications of the new subscription until the logs
+		// which were missed are delivered.
+		sub.logs.pause()
+	}
+	if err := c.send(ctx, op, msg); err != nil {
+		return err
+	}
+	if _, err := op.wait(ctx, c); err != nil {
+		return err
+	}
+	if sub.done() {
+		// Unsubscribe was called while the subscription was being resumed.
+		sub.requestUnsubscribe()
+		return nil
+	}
+	if sub.logs != nil {
+		backfill, err := c.backfillLogs(ctx, sub)
+		if err != nil {
+			var rpcErr Error
+			if !errors.As(err, &rpcErr) {
+				// Drop the new subscription, it will be created again
+				// on the next attempt.
+				sub.requestUnsubscribe()
+			}
+			return err
+		}
+		sub.logs.resume(sub, backfill)
+	}
+	return nil
+}
+
+// done reports whether the forwarding loop of the subscription has ended.
+func (sub *ClientSubscription) done() bool {
+	select {
+	case <-sub.forwardDone:
+		return true
+	default:
+		return false
+	}
+}
+
+// logCursor tracks the position of the last log delivered on a "logs" subscription,
+// so logs emitted while the client was disconnected can be fetched using eth_getLogs.
+type logCursor struct {
+	mu      sync.Mutex
+	valid   bool
+	number  uint64 // block number of the last delivered log
+	index   uint64 // index of the last delivered log, MaxUint64 if the whole block was seen
+	paused  bool
+	pending []json.RawMessage
+}
+
+// cursorLog holds the log fields relevant for the cursor.
+type cursorLog struct {
+	BlockNumber *hexutil.Uint64 `json:"blockNumber"`
+	Index       *hexutil.Uint64 `json:"logIndex"`
+	Removed     bool            `json:"removed"`
+}
+
+func decodeCursorLog(raw json.RawMessage) (number, index uint64, removed, ok bool) {
+	var l cursorLog
+	if err := json.Unmarshal(raw, &l); err != nil || l.BlockNumber == nil || l.Index == nil {
+		return 0, 0, false, false
+	}
+	return uint64(*l.BlockNumber), uint64(*l.Index), l.Removed, true
+}
+
+// advance moves the cursor to the given log. If the log was removed by a reorg,
+// the cursor moves back to the position just before it.
+func (lc *logCursor) advance(raw json.RawMessage) {
+	number, index, removed, ok := decodeCursorLog(raw)
+	switch {
+	case !ok:
+		return
+	case !removed:
+		lc.valid, lc.number, lc.index = true, number, index
+	case index > 0:
+		lc.valid, lc.number, lc.index = true, number, index-1
+	case number > 0:
+		lc.valid, lc.number, lc.index = true, number-1, math.MaxUint64
+	default:
+		lc.valid = false
+	}
+}
+
+// seen reports whether the given log is at or before the cursor.
+func (lc *logCursor) seen(raw json.RawMessage) bool {
+	number, index, removed, ok := decodeCursorLog(raw)
+	if !ok || removed || !lc.valid {
+		return false
+	}
+	return number < lc.number || (number == lc.number && index <= lc.index)
+}
+
+func (lc *logCursor) pause() {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.paused = true
+}
+
+// resume delivers the backfilled logs and the notifications received while the
+// subscription was paused, skipping logs which were delivered before.
+func (lc *logCursor) resume(sub *ClientSubscription, backfill []json.RawMessage) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	pending := append(backfill, lc.pending...)
+	lc.pending, lc.paused = nil, false
+	for _, raw := range pending {
+		if lc.seen(raw) {
+			continue
+		}
+		lc.advance(raw)
+		select {
+		case sub.in <- raw:
+		case <-sub.forwardDone:
+			return
+		}
+	}
+}
+
+// initLogCursor sets the cursor of a new logs subscription to the current head
+// block, so logs can be backfilled even when none were delivered before the
+// connection is lost.
+func (c *Client) initLogCursor(ctx context.Context, sub *ClientSubscription) {
+	var head hexutil.Uint64
+	if err := c.CallContext(ctx, &head, "eth_blockNumber"); err != nil {
+		log.Debug("Failed to get head block for logs subscription", "err", err)
+		return
+	}
+	sub.logs.mu.Lock()
+	defer sub.logs.mu.Unlock()
+
+	if !sub.logs.valid {
+		sub.logs.valid, sub.logs.number, sub.logs.index = true, uint64(head), math.MaxUint64
+	}
+}
+
+// backfillLogs fetches the logs matching the subscription's filter criteria
+// starting at the block of the cursor.
+func (c *Client) backfillLogs(ctx context.Context, sub *ClientSubscription) ([]json.RawMessage, error) {
+	sub.logs.mu.Lock()
+	valid, from := sub.logs.valid, sub.logs.number
+	sub.logs.mu.Unlock()
+	if !valid {
+		return nil, nil
+	}
+
+	var args []json.RawMessage
+	if err := json.Unmarshal(sub.params, &args); err != nil {
+		return nil, err
+	}
+	var crit map[string]json.RawMessage
+	if len(args) > 1 {
+		if err := json.Unmarshal(args[1], &crit); err != nil {
+			return nil, err
+		}
+	}
+	if crit == nil {
+		crit = make(map[string]json.RawMessage)
+	}
+	crit["fromBlock"], _ = json.Marshal(hexutil.Uint64(from))
+	crit["toBlock"] = json.RawMessage(`"latest"`)
+	delete(crit, "blockHash")
+
+	var logs []json.RawMessage
+	if err := c.CallContext(ctx, &logs, "eth_getLogs", crit); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
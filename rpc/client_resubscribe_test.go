@@ -0,0 +1,246 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+type testLog struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	Index       hexutil.Uint64 `json:"logIndex"`
+	Removed     bool           `json:"removed,omitempty"`
+}
+
+// logsTestService is a minimal stand-in for the log filtering API of package eth/filters.
+type logsTestService struct {
+	mu   sync.Mutex
+	logs []testLog
+	subs map[*Notifier]ID
+}
+
+func (s *logsTestService) BlockNumber() hexutil.Uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.logs) == 0 {
+		return 0
+	}
+	return s.logs[len(s.logs)-1].BlockNumber
+}
+
+func (s *logsTestService) GetLogs(crit map[string]any) []testLog {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	from, _ := hexutil.DecodeUint64(crit["fromBlock"].(string))
+	var logs []testLog
+	for _, l := range s.logs {
+		if uint64(l.BlockNumber) >= from {
+			logs = append(logs, l)
+		}
+	}
+	return logs
+}
+
+func (s *logsTestService) Logs(ctx context.Context, crit map[string]any) (*Subscription, error) {
+	notifier, _ := NotifierFromContext(ctx)
+	sub := notifier.CreateSubscription()
+
+	s.mu.Lock()
+	s.subs[notifier] = sub.ID
+	s.mu.Unlock()
+	go func() {
+		<-sub.Err()
+		s.mu.Lock()
+		delete(s.subs, notifier)
+		s.mu.Unlock()
+	}()
+	return sub, nil
+}
+
+// emit adds a log and sends it to all subscribers.
+func (s *logsTestService) emit(l testLog) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.logs = append(s.logs, l)
+	for n, id := range s.subs {
+		n.Notify(id, l)
+	}
+}
+
+// killableListener tracks accepted connections so they can be broken by the test.
+type killableListener struct {
+	net.Listener
+
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+func (l *killableListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err == nil {
+		l.mu.Lock()
+		l.conns = append(l.conns, c)
+		l.mu.Unlock()
+	}
+	return c, err
+}
+
+func (l *killableListener) killConns() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, c := range l.conns {
+		c.Close()
+	}
+	l.conns = nil
+}
+
+func TestClientResubscribeLogs(t *testing.T) {
+	t.Parallel()
+
+	service := &logsTestService{subs: make(map[*Notifier]ID)}
+	server := NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	hs := httptest.NewUnstartedServer(server.WebsocketHandler([]string{"*"}))
+	kl := &killableListener{Listener: hs.Listener}
+	hs.Listener = kl
+	hs.Start()
+	defer hs.Close()
+
+	client, err := DialOptions(context.Background(), "ws://"+hs.Listener.Addr().String(), WithReconnect(ReconnectPolicy{
+		MinBackoff: 100 * time.Millisecond,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	service.emit(testLog{BlockNumber: 1, Index: 0})
+	ch := make(chan testLog, 10)
+	sub, err := client.EthSubscribe(context.Background(), ch, "logs", map[string]any{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	want := []testLog{{BlockNumber: 1, Index: 1}, {BlockNumber: 2, Index: 0}}
+	waitForSubscribers := func() {
+		for {
+			service.mu.Lock()
+			n := len(service.subs)
+			service.mu.Unlock()
+			if n > 0 {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	waitForSubscribers()
+	service.emit(want[0])
+	service.emit(want[1])
+	expectLogs(t, ch, sub, want)
+
+	// Break the connection and emit logs while the client is disconnected.
+	kl.killConns()
+	missed := []testLog{{BlockNumber: 2, Index: 1}, {BlockNumber: 3, Index: 0}}
+	for _, l := range missed {
+		service.emit(l)
+	}
+	expectLogs(t, ch, sub, missed)
+
+	// Logs emitted after resubscribing should arrive on the same channel.
+	waitForSubscribers()
+	live := []testLog{{BlockNumber: 4, Index: 0}}
+	service.emit(live[0])
+	expectLogs(t, ch, sub, live)
+
+	select {
+	case l := <-ch:
+		t.Fatalf("unexpected log %v", l)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestClientResubscribeGiveUp(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	hs := httptest.NewUnstartedServer(server.WebsocketHandler([]string{"*"}))
+	kl := &killableListener{Listener: hs.Listener}
+	hs.Listener = kl
+	hs.Start()
+
+	client, err := DialOptions(context.Background(), "ws://"+hs.Listener.Addr().String(), WithReconnect(ReconnectPolicy{
+		MinBackoff:  10 * time.Millisecond,
+		MaxAttempts: 3,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ch := make(chan int, 1)
+	sub, err := client.Subscribe(context.Background(), "nftest", ch, "someSubscription", 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-ch
+
+	// Shut down the server, all attempts to resume the subscription fail.
+	hs.Close()
+	kl.killConns()
+
+	select {
+	case err := <-sub.Err():
+		if err == nil {
+			t.Fatal("nil error after giving up")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription not closed after giving up")
+	}
+}
+
+func expectLogs(t *testing.T, ch <-chan testLog, sub *ClientSubscription, want []testLog) {
+	t.Helper()
+
+	for _, w := range want {
+		select {
+		case l := <-ch:
+			if l != w {
+				t.Fatalf("wrong log: got %v, want %v", l, w)
+			}
+		case err := <-sub.Err():
+			t.Fatal("subscription error:", err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for log %v", w)
+		}
+	}
+}
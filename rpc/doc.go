@@ -98,6 +98,11 @@ Subscriptions are deleted when the user sends an unsubscribe request or when the
 connection which was used to create the subscription is closed. This can be initiated by
 the client and server. The server will close the connection for any write error.
 
+Plain HTTP requests cannot create subscriptions, except when the client accepts a
+"text/event-stream" response. In that case, the response and all subsequent
+notifications are delivered as server-sent events, and the response is kept open until
+the client closes the connection.
+
 For more information about subscriptions, see https://geth.ethereum.org/docs/interacting-with-geth/rpc/pubsub
 
 # Reverse Calls
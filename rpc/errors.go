@@ -55,12 +55,16 @@ var (
 	_ Error = new(invalidMessageError)
 	_ Error = new(invalidParamsError)
 	_ Error = new(internalServerError)
+	_ Error = new(limitExceededError)
+	_ Error = new(methodDeniedError)
 )
 
 const (
 	errcodeDefault          = -32000
 	errcodeTimeout          = -32002
 	errcodeResponseTooLarge = -32003
+	errcodeMethodDenied     = -32004
+	errcodeLimitExceeded    = -32005
 	errcodePanic            = -32603
 	errcodeMarshalError     = -32603
 
@@ -154,3 +158,21 @@ type internalServerError struct {
 func (e *internalServerError) ErrorCode() int { return e.code }
 
 func (e *internalServerError) Error() string { return e.message }
+
+// limitExceededError is returned when a client exceeds its rate limit.
+type limitExceededError struct{ method string }
+
+func (e *limitExceededError) ErrorCode() int { return errcodeLimitExceeded }
+
+func (e *limitExceededError) Error() string {
+	return fmt.Sprintf("rate limit exceeded for %s", e.method)
+}
+
+// methodDeniedError is returned when a client calls a method it has no permission for.
+type methodDeniedError struct{ method string }
+
+func (e *methodDeniedError) ErrorCode() int { return errcodeMethodDenied }
+
+func (e *methodDeniedError) Error() string {
+	return fmt.Sprintf("permission denied for %s", e.method)
+}
@@ -68,6 +68,8 @@ type handler struct {
 	allowSubscribe       bool
 	batchRequestLimit    int
 	batchResponseMaxSize int
+	rateLimiter          *rateLimiter
+	recorder             *Recorder
 	tracerProvider       trace.TracerProvider
 
 	subLock    sync.Mutex
@@ -411,6 +413,16 @@ func (h *handler) cancelAllRequests(err error, inflightReq *requestOp) {
 	}
 }
 
+// takeClientSubscriptions removes all active client subscriptions without closing them.
+func (h *handler) takeClientSubscriptions() []*ClientSubscription {
+	subs := make([]*ClientSubscription, 0, len(h.clientSubs))
+	for id, sub := range h.clientSubs {
+		delete(h.clientSubs, id)
+		subs = append(subs, sub)
+	}
+	return subs
+}
+
 func (h *handler) addSubscriptions(nn []*Notifier) {
 	h.subLock.Lock()
 	defer h.subLock.Unlock()
@@ -422,6 +434,14 @@ func (h *handler) addSubscriptions(nn []*Notifier) {
 	}
 }
 
+// hasServerSubscriptions reports whether any subscriptions are active on the connection.
+func (h *handler) hasServerSubscriptions() bool {
+	h.subLock.Lock()
+	defer h.subLock.Unlock()
+
+	return len(h.serverSubs) > 0
+}
+
 // cancelServerSubscriptions removes all subscriptions and closes their error channels.
 func (h *handler) cancelServerSubscriptions(err error) {
 	h.subLock.Lock()
@@ -464,10 +484,15 @@ func (h *handler) handleResponses(batch []*jsonrpcMessage, handleCall func(*json
 			if msg.Error != nil {
 				op.err = msg.decodeError()
 			} else {
-				op.err = json.Unmarshal(msg.Result, &op.sub.subid)
+				var subid string
+				op.err = json.Unmarshal(msg.Result, &subid)
 				if op.err == nil {
-					go op.sub.run()
-					h.clientSubs[op.sub.subid] = op.sub
+					op.sub.setID(subid)
+					// Resumed subscriptions already have a running forwarding loop.
+					if !op.resubscribe {
+						go op.sub.run()
+					}
+					h.clientSubs[subid] = op.sub
 				}
 			}
 		}
@@ -515,8 +540,13 @@ func (h *handler) handleSubscriptionResult(msg *jsonrpcMessage) {
 }
 
 // handleCallMsg executes a call message and returns the answer.
-func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) (answer *jsonrpcMessage) {
 	start := time.Now()
+	if h.recorder != nil {
+		defer func() {
+			h.recorder.record(RecordSourceServer, PeerInfoFromContext(ctx.ctx), msg, answer, start)
+		}()
+	}
 	switch {
 	case msg.isNotification():
 		// Notifications don't get a response written to the client, but the
@@ -555,6 +585,14 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 	if len(msg.Method) > maxMethodNameLength {
 		return msg.errorResponse(&invalidRequestError{fmt.Sprintf("method name too long: %d > %d", len(msg.Method), maxMethodNameLength)})
 	}
+	if h.rateLimiter != nil {
+		if err := h.rateLimiter.allow(PeerInfoFromContext(cp.ctx), msg.Method); err != nil {
+			return msg.errorResponse(err)
+		}
+	}
+	if err := checkPermissions(PeerInfoFromContext(cp.ctx), msg.Method); err != nil {
+		return msg.errorResponse(err)
+	}
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg)
 	}
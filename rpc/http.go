@@ -25,9 +25,11 @@ import (
 	"io"
 	"math"
 	"mime"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -37,8 +39,11 @@ import (
 )
 
 const (
-	defaultBodyLimit = 5 * 1024 * 1024
-	contentType      = "application/json"
+	defaultBodyLimit       = 5 * 1024 * 1024
+	contentType            = "application/json"
+	eventStreamContentType = "text/event-stream"
+	sseKeepaliveInterval   = 30 * time.Second
+	sseKeepaliveTimeout    = 5 * time.Second
 )
 
 // https://www.jsonrpc.org/historical/json-rpc-over-http.html#id13
@@ -334,7 +339,109 @@ func (t *httpServerConn) RemoteAddr() string {
 // SetWriteDeadline does nothing and always returns nil.
 func (t *httpServerConn) SetWriteDeadline(time.Time) error { return nil }
 
+// sseServerConn is the connection of a HTTP request served as an event stream.
+// Messages are written as server-sent events and flushed out immediately.
+type sseServerConn struct {
+	io.Reader
+	r  *http.Request
+	w  http.ResponseWriter
+	rc *http.ResponseController
+
+	mu     sync.Mutex // protects w and closed
+	closed bool
+}
+
+func (s *Server) newSSEServerConn(r *http.Request, w http.ResponseWriter) (ServerCodec, *sseServerConn) {
+	body := io.LimitReader(r.Body, int64(s.httpBodyLimit))
+	conn := &sseServerConn{Reader: body, r: r, w: w, rc: http.NewResponseController(w)}
+
+	var buf []byte
+	encodeMsg := func(ctx context.Context, msg *jsonrpcMessage, isError bool) error {
+		buf = appendMessage(buf[:0], msg)
+		return conn.writeEvent(buf)
+	}
+	encodeBatch := func(ctx context.Context, msgs []*jsonrpcMessage, isError bool) error {
+		buf = appendBatch(buf[:0], msgs)
+		return conn.writeEvent(buf)
+	}
+
+	dec := json.NewDecoder(conn)
+	dec.UseNumber()
+
+	return NewFuncCodec(conn, encodeMsg, encodeBatch, dec.Decode), conn
+}
+
+// writeEvent sends data as a single 'message' event.
+func (t *sseServerConn) writeEvent(data []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return net.ErrClosed
+	}
+	// Multi-line payloads are sent as multiple data fields. The client joins them
+	// back together with newlines.
+	var event []byte
+	for line := range bytes.SplitSeq(data, []byte{'\n'}) {
+		event = append(event, "data: "...)
+		event = append(event, line...)
+		event = append(event, '\n')
+	}
+	event = append(event, '\n')
+	if _, err := t.w.Write(event); err != nil {
+		return err
+	}
+	return t.rc.Flush()
+}
+
+// writeKeepalive sends a comment line, which keeps intermediate proxies from closing
+// an idle stream and detects clients that have gone away.
+func (t *sseServerConn) writeKeepalive() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return net.ErrClosed
+	}
+	t.rc.SetWriteDeadline(time.Now().Add(sseKeepaliveTimeout))
+	if _, err := io.WriteString(t.w, ": keepalive\n\n"); err != nil {
+		return err
+	}
+	return t.rc.Flush()
+}
+
+// Close marks the stream as finished. The response writer must not be used after the
+// HTTP handler has returned, so any later writes will fail.
+func (t *sseServerConn) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.closed = true
+	return nil
+}
+
+// RemoteAddr returns the peer address of the underlying connection.
+func (t *sseServerConn) RemoteAddr() string {
+	return t.r.RemoteAddr
+}
+
+// SetWriteDeadline sets the write deadline of the underlying connection. This overrides
+// the write timeout of the HTTP server, which would otherwise end the stream.
+func (t *sseServerConn) SetWriteDeadline(deadline time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return net.ErrClosed
+	}
+	return t.rc.SetWriteDeadline(deadline)
+}
+
 // ServeHTTP serves JSON-RPC requests over HTTP.
+//
+// Clients which accept a "text/event-stream" response receive it as a stream of
+// server-sent events. In this mode, subscriptions are allowed and the response is kept
+// open until the client disconnects, so notifications can be delivered to the client.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Permit dumb empty requests for remote health-checks (AWS)
 	if r.Method == http.MethodGet && r.ContentLength == 0 && r.URL.RawQuery == "" {
@@ -352,12 +459,24 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	connInfo.HTTP.Host = r.Host
 	connInfo.HTTP.Origin = r.Header.Get("Origin")
 	connInfo.HTTP.UserAgent = r.Header.Get("User-Agent")
+	connInfo.HTTP.AuthSubject, connInfo.HTTP.Authenticated = authSubjectFromContext(r.Context())
+	connInfo.HTTP.TLSClientSubject = tlsClientSubject(r)
+	connInfo.HTTP.Permissions = permissionsFromContext(r.Context())
 	ctx := r.Context()
 	ctx = context.WithValue(ctx, peerInfoContextKey{}, connInfo)
 
 	// Extract trace context from incoming headers.
 	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(r.Header))
 
+	if acceptsEventStream(r) {
+		w.Header().Set("content-type", eventStreamContentType)
+		w.Header().Set("cache-control", "no-cache")
+		codec, conn := s.newSSEServerConn(r, w)
+		defer codec.close()
+		s.serveEventStream(ctx, codec, conn)
+		return
+	}
+
 	// All checks passed, create a codec that reads directly from the request body
 	// until EOF, writes the response to w, and orders the server to process a
 	// single request.
@@ -367,6 +486,31 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.serveSingleRequest(ctx, codec)
 }
 
+// acceptsEventStream reports whether the client wants the response delivered as a
+// stream of server-sent events.
+func acceptsEventStream(r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		return false
+	}
+	for _, accept := range r.Header.Values("accept") {
+		for part := range strings.SplitSeq(accept, ",") {
+			if mt, _, err := mime.ParseMediaType(strings.TrimSpace(part)); err == nil && mt == eventStreamContentType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// tlsClientSubject returns the subject of the verified client certificate of a
+// request, or an empty string if the client didn't authenticate with one.
+func tlsClientSubject(r *http.Request) string {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return r.TLS.VerifiedChains[0][0].Subject.String()
+}
+
 // validateRequest returns a non-zero response code and error message if the
 // request is invalid.
 func (s *Server) validateRequest(r *http.Request) (int, error) {
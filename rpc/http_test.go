@@ -17,8 +17,10 @@
 package rpc
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -263,3 +265,92 @@ func TestNewContextWithHeaders(t *testing.T) {
 		t.Error("call failed:", err)
 	}
 }
+
+// readEvent reads the data of the next server-sent event from r.
+func readEvent(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+
+	var data []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("can't read event: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			if len(data) > 0 {
+				return strings.Join(data, "\n")
+			}
+		case strings.HasPrefix(line, "data: "):
+			data = append(data, strings.TrimPrefix(line, "data: "))
+		}
+	}
+}
+
+func postEventStream(t *testing.T, url, body string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("content-type", contentType)
+	req.Header.Set("accept", eventStreamContentType)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ct := resp.Header.Get("content-type"); ct != eventStreamContentType {
+		t.Fatalf("wrong content type %q", ct)
+	}
+	return resp
+}
+
+// This checks that subscriptions can be consumed over an HTTP event stream.
+func TestHTTPEventStreamSubscription(t *testing.T) {
+	t.Parallel()
+
+	s := newTestServer()
+	defer s.Stop()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	resp := postEventStream(t, ts.URL, `{"jsonrpc":"2.0","id":1,"method":"nftest_subscribe","params":["someSubscription",3,10]}`)
+	defer resp.Body.Close()
+
+	r := bufio.NewReader(resp.Body)
+	want := `{"jsonrpc":"2.0","id":1,"result":"0x1"}`
+	if ev := readEvent(t, r); ev != want {
+		t.Fatalf("wrong subscription response:\nhave %s\nwant %s", ev, want)
+	}
+	for i := 0; i < 3; i++ {
+		want := fmt.Sprintf(`{"jsonrpc":"2.0","method":"nftest_subscription","params":{"subscription":"0x1","result":%d}}`, 10+i)
+		if ev := readEvent(t, r); ev != want {
+			t.Fatalf("wrong notification %d:\nhave %s\nwant %s", i, ev, want)
+		}
+	}
+}
+
+// This checks that the event stream ends after the response when the request
+// did not create any subscriptions.
+func TestHTTPEventStreamCall(t *testing.T) {
+	t.Parallel()
+
+	s := newTestServer()
+	defer s.Stop()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	resp := postEventStream(t, ts.URL, `[{"jsonrpc":"2.0","id":1,"method":"nftest_echo","params":[1]},{"jsonrpc":"2.0","id":2,"method":"nftest_echo","params":[2]}]`)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "data: " + `[{"jsonrpc":"2.0","id":1,"result":1},{"jsonrpc":"2.0","id":2,"result":2}]` + "\n\n"
+	if string(body) != want {
+		t.Fatalf("wrong response:\nhave %q\nwant %q", body, want)
+	}
+}
@@ -0,0 +1,338 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	openRPCVersion = "1.2.6"
+
+	// openRPCDiscoverMethod is the method name defined by the OpenRPC specification
+	// for service discovery. It is an alias of rpc_discover.
+	openRPCDiscoverMethod = "rpc.discover"
+)
+
+// OpenRPCDocument is an OpenRPC service description.
+// See https://spec.open-rpc.org for the specification.
+type OpenRPCDocument struct {
+	OpenRPC    string            `json:"openrpc"`
+	Info       OpenRPCInfo       `json:"info"`
+	Methods    []*OpenRPCMethod  `json:"methods"`
+	Components OpenRPCComponents `json:"components"`
+}
+
+// OpenRPCInfo contains metadata about the API.
+type OpenRPCInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OpenRPCMethod describes a method.
+type OpenRPCMethod struct {
+	Name   string                      `json:"name"`
+	Params []*OpenRPCContentDescriptor `json:"params"`
+	Result *OpenRPCContentDescriptor   `json:"result"`
+
+	// Subscriptions describes the parameters of the available subscriptions
+	// when the method is a *_subscribe method. This is an extension of the
+	// OpenRPC specification.
+	Subscriptions map[string][]*OpenRPCContentDescriptor `json:"x-subscriptions,omitempty"`
+}
+
+// OpenRPCContentDescriptor describes a method parameter or result.
+type OpenRPCContentDescriptor struct {
+	Name     string      `json:"name"`
+	Required bool        `json:"required,omitempty"`
+	Schema   *JSONSchema `json:"schema"`
+}
+
+// OpenRPCComponents holds schemas referenced by methods.
+type OpenRPCComponents struct {
+	Schemas map[string]*JSONSchema `json:"schemas"`
+}
+
+// JSONSchema is the subset of JSON Schema used in OpenRPC documents.
+type JSONSchema struct {
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+	OneOf                []*JSONSchema          `json:"oneOf,omitempty"`
+}
+
+var (
+	quantitySchema = &JSONSchema{Title: "hex encoded unsigned integer", Type: "string", Pattern: "^0x(0|[1-9a-f][0-9a-f]*)$"}
+	bytesSchema    = &JSONSchema{Title: "hex encoded bytes", Type: "string", Pattern: "^0x([0-9a-fA-F]{2})*$"}
+	hashSchema     = &JSONSchema{Title: "32 byte hex value", Type: "string", Pattern: "^0x[0-9a-fA-F]{64}$"}
+	addressSchema  = &JSONSchema{Title: "hex encoded address", Type: "string", Pattern: "^0x[0-9a-fA-F]{40}$"}
+	blockTagSchema = &JSONSchema{Title: "block tag", Type: "string", Enum: []string{"earliest", "finalized", "safe", "latest", "pending"}}
+
+	blockNumberSchema = &JSONSchema{Title: "block number or tag", OneOf: []*JSONSchema{quantitySchema, blockTagSchema}}
+)
+
+// knownSchemas contains the schemas of types which have a custom JSON encoding.
+var knownSchemas = map[reflect.Type]*JSONSchema{
+	reflect.TypeFor[BlockNumber]():    blockNumberSchema,
+	reflect.TypeFor[ID]():             {Title: "subscription ID", Type: "string"},
+	reflect.TypeFor[hexutil.Big]():    quantitySchema,
+	reflect.TypeFor[hexutil.Uint64](): quantitySchema,
+	reflect.TypeFor[hexutil.Uint]():   quantitySchema,
+	reflect.TypeFor[hexutil.Bytes]():  bytesSchema,
+	reflect.TypeFor[common.Hash]():    hashSchema,
+	reflect.TypeFor[common.Address](): addressSchema,
+	reflect.TypeFor[big.Int]():        {Type: "integer"},
+	reflect.TypeFor[BlockNumberOrHash](): {
+		Title: "block number, tag or hash",
+		OneOf: []*JSONSchema{
+			blockNumberSchema,
+			hashSchema,
+			{
+				Type: "object",
+				Properties: map[string]*JSONSchema{
+					"blockNumber":      blockNumberSchema,
+					"blockHash":        hashSchema,
+					"requireCanonical": {Type: "boolean"},
+				},
+			},
+		},
+	},
+}
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// openRPCDocument creates the OpenRPC description of all registered services.
+func (r *serviceRegistry) openRPCDocument() *OpenRPCDocument {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		gen = &schemaGenerator{defs: make(map[string]*JSONSchema), names: make(map[reflect.Type]string)}
+		doc = &OpenRPCDocument{
+			OpenRPC: openRPCVersion,
+			Info:    OpenRPCInfo{Title: "JSON-RPC API", Version: "1.0"},
+			Methods: []*OpenRPCMethod{},
+		}
+	)
+	for _, name := range sortedKeys(r.services) {
+		svc := r.services[name]
+		for _, method := range sortedKeys(svc.callbacks) {
+			cb := svc.callbacks[method]
+			doc.Methods = append(doc.Methods, &OpenRPCMethod{
+				Name:   name + serviceMethodSeparator + method,
+				Params: gen.params(cb.argTypes),
+				Result: &OpenRPCContentDescriptor{Name: "result", Schema: gen.schema(cb.resultType())},
+			})
+		}
+		if len(svc.subscriptions) == 0 {
+			continue
+		}
+		names := sortedKeys(svc.subscriptions)
+		subscribe := &OpenRPCMethod{
+			Name: name + subscribeMethodSuffix,
+			Params: []*OpenRPCContentDescriptor{
+				{Name: "subscription", Required: true, Schema: &JSONSchema{Type: "string", Enum: names}},
+			},
+			Result:        &OpenRPCContentDescriptor{Name: "id", Schema: knownSchemas[reflect.TypeFor[ID]()]},
+			Subscriptions: make(map[string][]*OpenRPCContentDescriptor, len(names)),
+		}
+		for _, sub := range names {
+			subscribe.Subscriptions[sub] = gen.params(svc.subscriptions[sub].argTypes)
+		}
+		unsubscribe := &OpenRPCMethod{
+			Name: name + unsubscribeMethodSuffix,
+			Params: []*OpenRPCContentDescriptor{
+				{Name: "id", Required: true, Schema: knownSchemas[reflect.TypeFor[ID]()]},
+			},
+			Result: &OpenRPCContentDescriptor{Name: "result", Schema: &JSONSchema{Type: "boolean"}},
+		}
+		doc.Methods = append(doc.Methods, subscribe, unsubscribe)
+	}
+	doc.Components.Schemas = gen.defs
+	return doc
+}
+
+// resultType returns the type of the non-error return value, or nil if the callback
+// doesn't have one.
+func (c *callback) resultType() reflect.Type {
+	fntype := c.fn.Type()
+	if fntype.NumOut() == 0 || c.errPos == 0 {
+		return nil
+	}
+	return fntype.Out(0)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// schemaGenerator derives JSON schemas from Go types. Named struct types are added
+// to the component schemas and referenced from their uses.
+type schemaGenerator struct {
+	defs  map[string]*JSONSchema
+	names map[reflect.Type]string
+}
+
+// params creates the descriptors of method parameters. Pointer-typed arguments at the
+// end of the parameter list are optional.
+func (g *schemaGenerator) params(types []reflect.Type) []*OpenRPCContentDescriptor {
+	params := make([]*OpenRPCContentDescriptor, len(types))
+	required := false
+	for i := len(types) - 1; i >= 0; i-- {
+		required = required || types[i].Kind() != reflect.Pointer
+		params[i] = &OpenRPCContentDescriptor{
+			Name:     fmt.Sprintf("arg%d", i),
+			Required: required,
+			Schema:   g.schema(types[i]),
+		}
+	}
+	return params
+}
+
+// schema returns the schema of values of type t.
+func (g *schemaGenerator) schema(t reflect.Type) *JSONSchema {
+	if t == nil {
+		return &JSONSchema{Type: "null"}
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if s, ok := knownSchemas[t]; ok {
+		return s
+	}
+	ptr := reflect.PointerTo(t)
+	switch {
+	case t.Implements(jsonMarshalerType) || ptr.Implements(jsonMarshalerType):
+		// The encoding is not known, so any value is allowed.
+		return &JSONSchema{Title: t.String()}
+	case t.Implements(textMarshalerType) || ptr.Implements(textMarshalerType):
+		return &JSONSchema{Title: t.String(), Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &JSONSchema{Title: "base64 encoded bytes", Type: "string"}
+		}
+		return &JSONSchema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Array:
+		return &JSONSchema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return g.ref(t)
+	default:
+		// Interfaces, channels, functions, etc.
+		return &JSONSchema{}
+	}
+}
+
+// ref returns a reference to the component schema of the named struct type t.
+func (g *schemaGenerator) ref(t reflect.Type) *JSONSchema {
+	name, ok := g.names[t]
+	if !ok {
+		name = path.Base(t.PkgPath()) + "." + t.Name()
+		for i := 2; g.defs[name] != nil; i++ {
+			name = fmt.Sprintf("%s.%s%d", path.Base(t.PkgPath()), t.Name(), i)
+		}
+		// Register the name before creating the schema, since the type may be
+		// recursive.
+		g.names[t] = name
+		g.defs[name] = new(JSONSchema)
+		*g.defs[name] = *g.structSchema(t)
+	}
+	return &JSONSchema{Ref: "#/components/schemas/" + name}
+}
+
+// structSchema creates the object schema of struct type t, following the rules of
+// package encoding/json.
+func (g *schemaGenerator) structSchema(t reflect.Type) *JSONSchema {
+	s := &JSONSchema{Title: t.Name(), Type: "object", Properties: make(map[string]*JSONSchema)}
+	g.addFields(s, t)
+	return s
+}
+
+func (g *schemaGenerator) addFields(s *JSONSchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(s, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fs := g.schema(f.Type)
+		if slices.Contains(strings.Split(opts, ","), "string") {
+			fs = &JSONSchema{Type: "string"}
+		}
+		s.Properties[name] = fs
+		if !slices.Contains(strings.Split(opts, ","), "omitempty") && f.Type.Kind() != reflect.Pointer {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
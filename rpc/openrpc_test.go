@@ -0,0 +1,115 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestOpenRPCDiscover(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	var doc OpenRPCDocument
+	if err := client.Call(&doc, openRPCDiscoverMethod); err != nil {
+		t.Fatal(err)
+	}
+	methods := make(map[string]*OpenRPCMethod)
+	for _, m := range doc.Methods {
+		methods[m.Name] = m
+	}
+
+	echo := methods["test_echo"]
+	if echo == nil {
+		t.Fatal("test_echo missing from document")
+	}
+	if len(echo.Params) != 3 {
+		t.Fatalf("wrong number of test_echo params: %d", len(echo.Params))
+	}
+	for i, want := range []bool{true, true, false} {
+		if echo.Params[i].Required != want {
+			t.Errorf("test_echo param %d: required is %t, want %t", i, echo.Params[i].Required, want)
+		}
+	}
+	if ref := echo.Result.Schema.Ref; ref != "#/components/schemas/rpc.echoResult" {
+		t.Fatalf("wrong test_echo result schema ref %q", ref)
+	}
+	result := doc.Components.Schemas["rpc.echoResult"]
+	if result == nil {
+		t.Fatal("echoResult schema missing from components")
+	}
+	if !reflect.DeepEqual(result.Required, []string{"String", "Int"}) {
+		t.Errorf("wrong required fields of echoResult: %v", result.Required)
+	}
+	if ref := result.Properties["Args"].Ref; ref != "#/components/schemas/rpc.echoArgs" {
+		t.Errorf("wrong schema ref for echoResult.Args: %q", ref)
+	}
+
+	sub := methods["nftest_subscribe"]
+	if sub == nil {
+		t.Fatal("nftest_subscribe missing from document")
+	}
+	if !reflect.DeepEqual(sub.Params[0].Schema.Enum, []string{"hangSubscription", "someSubscription"}) {
+		t.Errorf("wrong subscription names: %v", sub.Params[0].Schema.Enum)
+	}
+	if params := sub.Subscriptions["someSubscription"]; len(params) != 2 || params[0].Schema.Type != "integer" {
+		t.Errorf("wrong someSubscription params: %v", params)
+	}
+	if methods["nftest_unsubscribe"] == nil {
+		t.Error("nftest_unsubscribe missing from document")
+	}
+	if methods["rpc_discover"] == nil {
+		t.Error("rpc_discover missing from document")
+	}
+}
+
+func TestOpenRPCSchema(t *testing.T) {
+	t.Parallel()
+
+	type embedded struct {
+		Embedded hexutil.Uint64 `json:"embedded"`
+	}
+	type args struct {
+		embedded
+		From    *hexutil.Big      `json:"from"`
+		Block   BlockNumberOrHash `json:"block,omitempty"`
+		Data    []byte            `json:"data"`
+		Ignored string            `json:"-"`
+		private int
+	}
+	gen := &schemaGenerator{defs: make(map[string]*JSONSchema), names: make(map[reflect.Type]string)}
+	s := gen.structSchema(reflect.TypeFor[args]())
+
+	enc, _ := json.Marshal(s)
+	want := `{"title":"args","type":"object","properties":{` +
+		`"block":{"title":"block number, tag or hash","oneOf":[{"title":"block number or tag","oneOf":[{"title":"hex encoded unsigned integer","type":"string","pattern":"^0x(0|[1-9a-f][0-9a-f]*)$"},{"title":"block tag","type":"string","enum":["earliest","finalized","safe","latest","pending"]}]},{"title":"32 byte hex value","type":"string","pattern":"^0x[0-9a-fA-F]{64}$"},{"type":"object","properties":{"blockHash":{"title":"32 byte hex value","type":"string","pattern":"^0x[0-9a-fA-F]{64}$"},"blockNumber":{"title":"block number or tag","oneOf":[{"title":"hex encoded unsigned integer","type":"string","pattern":"^0x(0|[1-9a-f][0-9a-f]*)$"},{"title":"block tag","type":"string","enum":["earliest","finalized","safe","latest","pending"]}]},"requireCanonical":{"type":"boolean"}}}]},` +
+		`"data":{"title":"base64 encoded bytes","type":"string"},` +
+		`"embedded":{"title":"hex encoded unsigned integer","type":"string","pattern":"^0x(0|[1-9a-f][0-9a-f]*)$"},` +
+		`"from":{"title":"hex encoded unsigned integer","type":"string","pattern":"^0x(0|[1-9a-f][0-9a-f]*)$"}},` +
+		`"required":["embedded","data"]}`
+	if string(enc) != want {
+		t.Fatalf("wrong schema:\nhave %s\nwant %s", enc, want)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var deniedCallMeter = metrics.NewRegisteredMeter("rpc/denied", nil)
+
+// Permissions restricts the methods a client may call.
+//
+// Patterns are either namespaces like "eth", matching all methods of the namespace,
+// or method names which may contain wildcards like "debug_trace*". A method may be
+// called if it matches one of the Allow patterns, or Allow is empty, and none of the
+// Deny patterns. For example, read-only access to the eth namespace is granted by
+// allowing "eth" and denying "eth_send*" and "eth_sign*".
+type Permissions struct {
+	Allow []string `json:"allow,omitempty" toml:",omitempty"`
+	Deny  []string `json:"deny,omitempty" toml:",omitempty"`
+}
+
+// Validate checks that all patterns are well formed.
+func (p Permissions) Validate() error {
+	for _, pattern := range append(append([]string{}, p.Allow...), p.Deny...) {
+		if pattern == "" {
+			return fmt.Errorf("empty method pattern")
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid method pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Allows reports whether the given method may be called.
+func (p Permissions) Allows(method string) bool {
+	for _, pattern := range p.Deny {
+		if matchMethod(pattern, method) {
+			return false
+		}
+	}
+	if len(p.Allow) == 0 {
+		return true
+	}
+	for _, pattern := range p.Allow {
+		if matchMethod(pattern, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchMethod reports whether a method matches a namespace or method pattern.
+func matchMethod(pattern, method string) bool {
+	if !strings.ContainsAny(pattern, serviceMethodSeparator+"*?[") {
+		return strings.HasPrefix(method, pattern+serviceMethodSeparator)
+	}
+	ok, _ := path.Match(pattern, method)
+	return ok
+}
+
+type permissionsContextKey struct{}
+
+// NewContextWithPermissions wraps the given context, restricting the methods the
+// client may call. If several permissions are given, a method may only be called
+// if all of them allow it. HTTP handlers which authenticate requests in front of
+// Server.ServeHTTP or Server.WebsocketHandler use this to scope the client's access.
+func NewContextWithPermissions(ctx context.Context, perms ...Permissions) context.Context {
+	return context.WithValue(ctx, permissionsContextKey{}, perms)
+}
+
+// permissionsFromContext returns the permissions set by NewContextWithPermissions.
+func permissionsFromContext(ctx context.Context) []Permissions {
+	perms, _ := ctx.Value(permissionsContextKey{}).([]Permissions)
+	return perms
+}
+
+// checkPermissions returns an error if the client may not call the given method,
+// logging the denied call for auditing.
+func checkPermissions(peer PeerInfo, method string) error {
+	for _, perms := range peer.HTTP.Permissions {
+		if !perms.Allows(method) {
+			deniedCallMeter.Mark(1)
+			log.Warn("Denied RPC call", "method", method, "subject", peer.HTTP.AuthSubject, "cert", peer.HTTP.TLSClientSubject, "transport", peer.Transport, "remote", peer.RemoteAddr)
+			return &methodDeniedError{method}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPermissions(t *testing.T) {
+	readOnly := Permissions{Allow: []string{"eth"}, Deny: []string{"eth_send*", "eth_sign*"}}
+	tracing := Permissions{Allow: []string{"debug_trace*"}}
+
+	tests := []struct {
+		perms  Permissions
+		method string
+		want   bool
+	}{
+		{Permissions{}, "admin_addPeer", true},
+		{readOnly, "eth_blockNumber", true},
+		{readOnly, "eth_sendRawTransaction", false},
+		{readOnly, "eth_signTransaction", false},
+		{readOnly, "ethx_foo", false},
+		{readOnly, "debug_traceCall", false},
+		{tracing, "debug_traceTransaction", true},
+		{tracing, "debug_setHead", false},
+		{Permissions{Deny: []string{"admin"}}, "admin_peers", false},
+		{Permissions{Deny: []string{"admin"}}, "net_version", true},
+	}
+	for i, test := range tests {
+		if have := test.perms.Allows(test.method); have != test.want {
+			t.Errorf("test %d: %v allows %s: have %v, want %v", i, test.perms, test.method, have, test.want)
+		}
+	}
+	if err := (Permissions{Allow: []string{"eth_[a"}}).Validate(); err == nil {
+		t.Error("malformed pattern accepted")
+	}
+}
+
+func TestPermissionsDispatch(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+
+	httpsrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := NewContextWithPermissions(r.Context(), Permissions{Allow: []string{"test"}}, Permissions{Deny: []string{"test_echo"}})
+		server.ServeHTTP(w, r.WithContext(ctx))
+	}))
+	defer httpsrv.Close()
+
+	client, err := DialHTTP(httpsrv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if err := client.Call(nil, "test_null"); err != nil {
+		t.Fatalf("permitted call failed: %v", err)
+	}
+	for _, method := range []string{"test_echo", "rpc_modules"} {
+		var rpcErr Error
+		if err := client.Call(nil, method); !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != errcodeMethodDenied {
+			t.Errorf("%s: expected permission denied, got %v", method, err)
+		}
+	}
+}
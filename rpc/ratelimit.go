@@ -0,0 +1,145 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/metrics"
+	"golang.org/x/time/rate"
+)
+
+// maxRateLimitClients is the number of client buckets tracked by the rate limiter.
+// When more clients are active, the least recently seen ones are forgotten.
+const maxRateLimitClients = 16384
+
+var (
+	rateLimitAllowedMeter  = metrics.NewRegisteredMeter("rpc/ratelimit/allowed", nil)
+	rateLimitRejectedMeter = metrics.NewRegisteredMeter("rpc/ratelimit/rejected", nil)
+	rateLimitClientsGauge  = metrics.NewRegisteredGauge("rpc/ratelimit/clients", nil)
+)
+
+// RateLimitConfig configures per-client rate limiting of method calls.
+//
+// Every client has a token bucket which refills at a fixed rate. Each method call
+// takes tokens from the bucket according to the cost of the method, and calls are
+// rejected with a 'limit exceeded' error while the bucket is empty. Clients which
+// authenticated with a JWT are tracked by the token's subject, clients which
+// authenticated with a TLS certificate by the certificate's subject, and all other
+// clients by their IP address.
+type RateLimitConfig struct {
+	// IPRate is the number of tokens per second added to the bucket of an
+	// unauthenticated client. Zero disables rate limiting by IP address.
+	IPRate float64 `toml:",omitempty"`
+
+	// IPBurst is the bucket size of unauthenticated clients. If zero, the bucket
+	// size is the rate rounded up.
+	IPBurst int `toml:",omitempty"`
+
+	// SubjectRate is the number of tokens per second added to the bucket of a client
+	// which authenticated with a JWT or a TLS certificate. Zero disables rate
+	// limiting by subject.
+	SubjectRate float64 `toml:",omitempty"`
+
+	// SubjectBurst is the bucket size of authenticated clients. If zero, the bucket
+	// size is the rate rounded up.
+	SubjectBurst int `toml:",omitempty"`
+
+	// MethodCosts is the number of tokens taken by calls to specific methods.
+	// Calls to methods not listed here take one token. Calls which cost more than
+	// the bucket size are always rejected.
+	MethodCosts map[string]int `toml:",omitempty"`
+}
+
+// enabled reports whether any limits are configured.
+func (cfg *RateLimitConfig) enabled() bool {
+	return cfg.IPRate > 0 || cfg.SubjectRate > 0
+}
+
+// cost returns the number of tokens taken by a call to the given method.
+func (cfg *RateLimitConfig) cost(method string) int {
+	if c, ok := cfg.MethodCosts[method]; ok {
+		return c
+	}
+	return 1
+}
+
+// rateLimiter tracks the token buckets of clients.
+type rateLimiter struct {
+	cfg RateLimitConfig
+
+	mu      sync.Mutex
+	clients lru.BasicLRU[string, *rate.Limiter]
+}
+
+func newRateLimiter(cfg RateLimitConfig) *rateLimiter {
+	return &rateLimiter{
+		cfg:     cfg,
+		clients: lru.NewBasicLRU[string, *rate.Limiter](maxRateLimitClients),
+	}
+}
+
+// allow takes tokens for a call to the given method from the bucket of the client.
+// It returns an error if the bucket doesn't hold enough tokens.
+func (l *rateLimiter) allow(peer PeerInfo, method string) error {
+	key, limit, burst := l.bucket(peer)
+	if limit == 0 {
+		return nil
+	}
+	now := time.Now()
+
+	l.mu.Lock()
+	bucket, ok := l.clients.Get(key)
+	if !ok {
+		bucket = rate.NewLimiter(limit, burst)
+		l.clients.Add(key, bucket)
+		rateLimitClientsGauge.Update(int64(l.clients.Len()))
+	}
+	l.mu.Unlock()
+
+	if !bucket.AllowN(now, l.cfg.cost(method)) {
+		rateLimitRejectedMeter.Mark(1)
+		return &limitExceededError{method}
+	}
+	rateLimitAllowedMeter.Mark(1)
+	return nil
+}
+
+// bucket returns the key and parameters of the bucket used for the given client.
+func (l *rateLimiter) bucket(peer PeerInfo) (key string, limit rate.Limit, burst int) {
+	if peer.HTTP.AuthSubject != "" {
+		return "sub:" + peer.HTTP.AuthSubject, rate.Limit(l.cfg.SubjectRate), bucketSize(l.cfg.SubjectRate, l.cfg.SubjectBurst)
+	}
+	if peer.HTTP.TLSClientSubject != "" {
+		return "cert:" + peer.HTTP.TLSClientSubject, rate.Limit(l.cfg.SubjectRate), bucketSize(l.cfg.SubjectRate, l.cfg.SubjectBurst)
+	}
+	host, _, err := net.SplitHostPort(peer.RemoteAddr)
+	if err != nil {
+		host = peer.RemoteAddr
+	}
+	return "ip:" + host, rate.Limit(l.cfg.IPRate), bucketSize(l.cfg.IPRate, l.cfg.IPBurst)
+}
+
+func bucketSize(r float64, burst int) int {
+	if burst > 0 {
+		return burst
+	}
+	return max(int(r+0.999), 1)
+}
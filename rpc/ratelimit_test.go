@@ -0,0 +1,100 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRateLimitHTTP(t *testing.T) {
+	t.Parallel()
+
+	s := newTestServer()
+	s.SetRateLimits(RateLimitConfig{
+		IPRate:      0.001,
+		IPBurst:     3,
+		MethodCosts: map[string]int{"test_echo": 2},
+	})
+	defer s.Stop()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	c, err := DialHTTP(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	// The first call takes two tokens, the second one takes the last token.
+	if err := c.Call(nil, "test_echo", "x", 1); err != nil {
+		t.Fatal("first call failed:", err)
+	}
+	if err := c.Call(nil, "test_noArgsRets"); err != nil {
+		t.Fatal("second call failed:", err)
+	}
+	err = c.Call(nil, "test_noArgsRets")
+	var rpcErr Error
+	if !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != errcodeLimitExceeded {
+		t.Fatalf("wrong error for call over limit: %v", err)
+	}
+}
+
+func TestRateLimitSubject(t *testing.T) {
+	t.Parallel()
+
+	s := newTestServer()
+	s.SetRateLimits(RateLimitConfig{
+		IPRate:       0.001,
+		IPBurst:      1,
+		SubjectRate:  0.001,
+		SubjectBurst: 2,
+	})
+	defer s.Stop()
+
+	// Requests are authenticated based on a header here, which stands in for
+	// the JWT handler of package node.
+	auth := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if sub := r.Header.Get("x-subject"); sub != "" {
+			r = r.WithContext(NewContextWithAuthSubject(r.Context(), sub))
+		}
+		s.ServeHTTP(w, r)
+	})
+	ts := httptest.NewServer(auth)
+	defer ts.Close()
+
+	call := func(subject string) error {
+		c, err := DialOptions(t.Context(), ts.URL, WithHeader("x-subject", subject))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer c.Close()
+		return c.Call(nil, "test_noArgsRets")
+	}
+	for i, subject := range []string{"a", "a", "b", "b", ""} {
+		if err := call(subject); err != nil {
+			t.Fatalf("call %d (subject %q) failed: %v", i, subject, err)
+		}
+	}
+	for i, subject := range []string{"a", "b", ""} {
+		if err := call(subject); err == nil {
+			t.Fatalf("call %d (subject %q) over limit succeeded", i, subject)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Sources of recorded calls.
+const (
+	RecordSourceServer = "server"
+	RecordSourceClient = "client"
+)
+
+// RecordedCall is an entry of a recording. Recordings are stored in JSONL format,
+// i.e. one JSON-encoded RecordedCall per line.
+type RecordedCall struct {
+	Time     time.Time       `json:"time"`
+	Duration time.Duration   `json:"duration"` // in nanoseconds
+	Source   string          `json:"source"`
+	Peer     PeerInfo        `json:"peer"`
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response,omitempty"` // not set for notifications
+}
+
+// Recorder writes method calls and their responses to a recording. Use Server.SetRecorder
+// to record the calls handled by a server, and WithRecorder to record the calls sent by
+// a client. A Recorder can be shared by several servers and clients.
+type Recorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+// NewRecorder creates a recorder writing to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// Err returns the first error that occurred while writing the recording. Calls are no
+// longer recorded after a write error.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// record writes a call to the recording.
+func (r *Recorder) record(source string, peer PeerInfo, req, resp *jsonrpcMessage, start time.Time) {
+	entry := &RecordedCall{
+		Time:     start,
+		Duration: time.Since(start),
+		Source:   source,
+		Peer:     peer,
+	}
+	var err error
+	if entry.Request, err = json.Marshal(req); err != nil {
+		return
+	}
+	if resp != nil && !req.isNotification() {
+		if entry.Response, err = json.Marshal(resp); err != nil {
+			return
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	if r.err = r.enc.Encode(entry); r.err != nil {
+		log.Warn("Failed to write RPC recording", "err", r.err)
+	}
+}
+
+// ReadRecording reads all entries of a recording.
+func ReadRecording(r io.Reader) ([]*RecordedCall, error) {
+	var (
+		calls []*RecordedCall
+		dec   = json.NewDecoder(bufio.NewReader(r))
+	)
+	for {
+		call := new(RecordedCall)
+		if err := dec.Decode(call); err == io.EOF {
+			return calls, nil
+		} else if err != nil {
+			return calls, fmt.Errorf("invalid recording entry %d: %v", len(calls), err)
+		}
+		calls = append(calls, call)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	t.Parallel()
+
+	var (
+		serverRec bytes.Buffer
+		clientRec bytes.Buffer
+	)
+	server := newTestServer()
+	server.SetRecorder(NewRecorder(&serverRec))
+	defer server.Stop()
+	hs := httptest.NewServer(server)
+	defer hs.Close()
+
+	client, err := DialOptions(context.Background(), hs.URL, WithRecorder(NewRecorder(&clientRec)), WithHeader("user-agent", "recorder-test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	client.Call(nil, "test_echo", "x", 1, &echoArgs{"y"})
+	client.Call(nil, "test_returnError")
+	client.BatchCall([]BatchElem{
+		{Method: "test_echo", Args: []any{"z", 2}, Result: new(echoResult)},
+		{Method: "test_noArgsRets"},
+	})
+
+	for _, rec := range []*bytes.Buffer{&serverRec, &clientRec} {
+		calls, err := ReadRecording(bytes.NewReader(rec.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(calls) != 4 {
+			t.Fatalf("wrong number of recorded calls: %d", len(calls))
+		}
+		if calls[0].Source == RecordSourceServer && calls[0].Peer.HTTP.UserAgent != "recorder-test" {
+			t.Errorf("wrong peer info in server recording: %+v", calls[0].Peer)
+		}
+		if calls[0].Source == RecordSourceClient && calls[0].Peer.RemoteAddr != hs.URL {
+			t.Errorf("wrong peer info in client recording: %+v", calls[0].Peer)
+		}
+
+		// Replaying against the same server shouldn't find any differences.
+		mismatches, err := Replay(context.Background(), client, calls)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(mismatches) != 0 {
+			t.Fatalf("unexpected mismatches in %s recording: %s", calls[0].Source, mismatches[0].Response)
+		}
+
+		// Change a recorded result, this should be reported.
+		calls[0].Response = json.RawMessage(`{"jsonrpc":"2.0","id":1,"result":{"String":"other","Int":1,"Args":{"S":"y"}}}`)
+		mismatches, err = Replay(context.Background(), client, calls)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(mismatches) != 1 || mismatches[0].Call != calls[0] {
+			t.Fatalf("wrong mismatches: %v", mismatches)
+		}
+	}
+}
+
+func TestRecordTestScript(t *testing.T) {
+	t.Parallel()
+
+	var rec bytes.Buffer
+	server := newTestServer()
+	server.SetRecorder(NewRecorder(&rec))
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	client.Call(nil, "test_echo", "x", 3, &echoArgs{"foo"})
+	client.Call(nil, "test_echo", "x")
+	client.Notify(context.Background(), "test_echo", "x", 4)
+	client.Call(nil, "test_noArgsRets")
+	client.Close()
+
+	calls, err := ReadRecording(&rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "recorded.js")
+	var script bytes.Buffer
+	if err := WriteTestScript(&script, calls); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, script.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runTestScript(t, file)
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// ReplayMismatch is a recorded call which got a different response when replayed.
+type ReplayMismatch struct {
+	Call     *RecordedCall
+	Response json.RawMessage // the response received during replay
+}
+
+// Replay sends the calls of a recording to the server of c, and compares the responses
+// with the recorded ones. Request IDs are not compared. Notifications and calls to
+// subscription methods are skipped because their responses can't be compared.
+//
+// The returned error is non-nil only when a call could not be sent.
+func Replay(ctx context.Context, c *Client, calls []*RecordedCall) ([]*ReplayMismatch, error) {
+	var mismatches []*ReplayMismatch
+	for i, call := range calls {
+		var req jsonrpcMessage
+		if err := json.Unmarshal(call.Request, &req); err != nil {
+			return mismatches, fmt.Errorf("invalid request in call %d: %v", i, err)
+		}
+		if len(call.Response) == 0 || !req.isCall() || isSubscriptionMethod(req.Method) {
+			continue
+		}
+		resp, err := c.sendRaw(ctx, req.Method, req.Params)
+		if err != nil {
+			return mismatches, fmt.Errorf("call %d (%s) failed: %v", i, req.Method, err)
+		}
+		var recorded jsonrpcMessage
+		if err := json.Unmarshal(call.Response, &recorded); err != nil {
+			return mismatches, fmt.Errorf("invalid response in call %d: %v", i, err)
+		}
+		if !sameResponse(&recorded, resp) {
+			enc, _ := json.Marshal(resp)
+			mismatches = append(mismatches, &ReplayMismatch{Call: call, Response: enc})
+		}
+	}
+	return mismatches, nil
+}
+
+func isSubscriptionMethod(method string) bool {
+	return strings.HasSuffix(method, subscribeMethodSuffix) || strings.HasSuffix(method, unsubscribeMethodSuffix)
+}
+
+// sameResponse compares the result and error of two responses.
+func sameResponse(a, b *jsonrpcMessage) bool {
+	if (a.Error == nil) != (b.Error == nil) {
+		return false
+	}
+	if a.Error != nil {
+		return sameJSON(a.Error, b.Error)
+	}
+	return sameJSON(a.Result, b.Result)
+}
+
+func sameJSON(a, b json.RawMessage) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// sendRaw performs a call with already encoded parameters and returns the response.
+func (c *Client) sendRaw(ctx context.Context, method string, params json.RawMessage) (*jsonrpcMessage, error) {
+	if c.pool != nil {
+		var resp *jsonrpcMessage
+		err := c.pool.do(ctx, func(member *Client) (err error) {
+			resp, err = member.sendRaw(ctx, method, params)
+			return err
+		})
+		return resp, err
+	}
+	msg := &jsonrpcMessage{Version: vsn, ID: c.nextID(), Method: method, Params: params}
+	op := &requestOp{
+		ids:  []json.RawMessage{msg.ID},
+		resp: make(chan []*jsonrpcMessage, 1),
+	}
+	var err error
+	if c.isHTTP {
+		err = c.sendHTTP(ctx, op, msg)
+	} else {
+		err = c.send(ctx, op, msg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	batchresp, err := op.wait(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	return batchresp[0], nil
+}
+
+// WriteTestScript writes the calls of a recording in the format of the test scripts
+// in rpc/testdata, where requests are prefixed by "-->" and responses by "<--".
+// Calls are written in the order they were recorded.
+func WriteTestScript(w io.Writer, calls []*RecordedCall) error {
+	for _, call := range calls {
+		if _, err := fmt.Fprintf(w, "--> %s\n", call.Request); err != nil {
+			return err
+		}
+		if len(call.Response) > 0 {
+			if _, err := fmt.Fprintf(w, "<-- %s\n", call.Response); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
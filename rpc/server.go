@@ -23,6 +23,7 @@ import (
 	"net"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 	"go.opentelemetry.io/otel/trace"
@@ -56,6 +57,8 @@ type Server struct {
 	batchResponseLimit int
 	httpBodyLimit      int
 	wsReadLimit        int64
+	rateLimiter        *rateLimiter
+	recorder           *Recorder
 	tracerProvider     trace.TracerProvider
 }
 
@@ -101,6 +104,28 @@ func (s *Server) SetWebsocketReadLimit(limit int64) {
 	s.wsReadLimit = limit
 }
 
+// SetRateLimits enables per-client rate limiting of method calls. Calls exceeding the
+// limit are answered with a 'limit exceeded' error.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetRateLimits(cfg RateLimitConfig) {
+	if cfg.enabled() {
+		s.rateLimiter = newRateLimiter(cfg)
+	} else {
+		s.rateLimiter = nil
+	}
+}
+
+// SetRecorder configures the server to write all method calls it handles, and their
+// responses, to the given recorder. Passing nil disables recording.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetRecorder(r *Recorder) {
+	s.recorder = r
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either an RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
@@ -126,6 +151,8 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 		idgen:              s.idgen,
 		batchItemLimit:     s.batchItemLimit,
 		batchResponseLimit: s.batchResponseLimit,
+		rateLimiter:        s.rateLimiter,
+		recorder:           s.recorder,
 	}
 	c := initClient(codec, &s.services, cfg)
 	<-codec.closed()
@@ -170,6 +197,38 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 
 	h := newHandler(ctx, codec, s.idgen, &s.services, s.batchItemLimit, s.batchResponseLimit, s.tracerProvider)
 	h.allowSubscribe = false
+	h.rateLimiter = s.rateLimiter
+	h.recorder = s.recorder
+	defer h.close(io.EOF, nil)
+
+	reqs, batch, err := codec.readBatch()
+	if err != nil {
+		if msg := messageForReadError(err); msg != "" {
+			resp := errorMessage(&invalidMessageError{msg})
+			codec.writeJSON(ctx, resp, true)
+		}
+		return
+	}
+	if batch {
+		h.handleBatch(reqs)
+	} else {
+		h.handleMsg(reqs[0])
+	}
+}
+
+// serveEventStream reads and processes a single RPC request (or batch) from the given
+// codec. Unlike serveSingleRequest, subscriptions are allowed. When the request created
+// any subscriptions, the codec is kept open for notifications until the client
+// disconnects or the server is stopped.
+func (s *Server) serveEventStream(ctx context.Context, codec ServerCodec, conn *sseServerConn) {
+	if !s.trackCodec(codec) {
+		return
+	}
+	defer s.untrackCodec(codec)
+
+	h := newHandler(ctx, codec, s.idgen, &s.services, s.batchItemLimit, s.batchResponseLimit, s.tracerProvider)
+	h.rateLimiter = s.rateLimiter
+	h.recorder = s.recorder
 	defer h.close(io.EOF, nil)
 
 	reqs, batch, err := codec.readBatch()
@@ -185,6 +244,26 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 	} else {
 		h.handleMsg(reqs[0])
 	}
+	h.callWG.Wait()
+	if !h.hasServerSubscriptions() {
+		return
+	}
+
+	keepalive := time.NewTicker(sseKeepaliveInterval)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-keepalive.C:
+			if err := conn.writeKeepalive(); err != nil {
+				h.log.Debug("Event stream keepalive failed", "err", err)
+				return
+			}
+		case <-ctx.Done():
+			return
+		case <-codec.closed():
+			return
+		}
+	}
 }
 
 func messageForReadError(err error) string {
@@ -234,6 +313,12 @@ func (s *RPCService) Modules() map[string]string {
 	return modules
 }
 
+// Discover returns an OpenRPC document describing the methods and subscriptions
+// offered by the server. This method is also available as "rpc.discover".
+func (s *RPCService) Discover() *OpenRPCDocument {
+	return s.server.services.openRPCDocument()
+}
+
 // PeerInfo contains information about the remote end of the network connection.
 //
 // This is available within RPC method handlers through the context. Call
@@ -255,6 +340,21 @@ type PeerInfo struct {
 		UserAgent string
 		Origin    string
 		Host      string
+
+		// Whether the client authenticated with a JWT, and the subject of the
+		// token. These are not set when the endpoint doesn't require
+		// authentication. The subject is empty if the token doesn't have one.
+		Authenticated bool
+		AuthSubject   string
+
+		// The subject of the client certificate, if the connection uses TLS and
+		// the client authenticated with a verified certificate.
+		TLSClientSubject string
+
+		// Restrictions on the methods the client may call, set when the client
+		// authenticated with a scoped token. A method may only be called if all
+		// of them allow it.
+		Permissions []Permissions
 	}
 }
 
@@ -268,3 +368,20 @@ func PeerInfoFromContext(ctx context.Context) PeerInfo {
 	info, _ := ctx.Value(peerInfoContextKey{}).(PeerInfo)
 	return info
 }
+
+type authSubjectContextKey struct{}
+
+// NewContextWithAuthSubject wraps the given context, marking the client as
+// authenticated with the given subject, which may be empty. HTTP handlers which
+// authenticate requests in front of Server.ServeHTTP or Server.WebsocketHandler
+// use this to make the subject available through PeerInfo.
+func NewContextWithAuthSubject(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, authSubjectContextKey{}, subject)
+}
+
+// authSubjectFromContext returns the subject set by NewContextWithAuthSubject,
+// and whether the client was authenticated at all.
+func authSubjectFromContext(ctx context.Context) (string, bool) {
+	subject, ok := ctx.Value(authSubjectContextKey{}).(string)
+	return subject, ok
+}
@@ -97,6 +97,9 @@ func serviceAndMethod(name string) (service, method string, ok bool) {
 
 // callback returns the callback corresponding to the given RPC method name.
 func (r *serviceRegistry) callback(name string) (cb *callback) {
+	if name == openRPCDiscoverMethod {
+		name = MetadataApi + serviceMethodSeparator + "discover"
+	}
 	s, m, found := serviceAndMethod(name)
 	if !found {
 		return nil
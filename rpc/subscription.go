@@ -211,7 +211,11 @@ type ClientSubscription struct {
 	etype     reflect.Type
 	channel   reflect.Value
 	namespace string
-	subid     string
+	params    json.RawMessage // original subscribe arguments, for resubscribing
+	logs      *logCursor      // set for resumable log subscriptions
+
+	mu    sync.Mutex // guards subid, which changes when the subscription is resumed
+	subid string
 
 	// The in channel receives notification values from client dispatcher.
 	in chan json.RawMessage
@@ -232,10 +236,11 @@ type ClientSubscription struct {
 // This is the sentinel value sent on sub.quit when Unsubscribe is called.
 var errUnsubscribed = errors.New("unsubscribed")
 
-func newClientSubscription(c *Client, namespace string, channel reflect.Value) *ClientSubscription {
+func newClientSubscription(c *Client, namespace string, params json.RawMessage, channel reflect.Value) *ClientSubscription {
 	sub := &ClientSubscription{
 		client:      c,
 		namespace:   namespace,
+		params:      params,
 		etype:       channel.Type().Elem(),
 		channel:     channel,
 		in:          make(chan json.RawMessage),
@@ -274,6 +279,18 @@ func (sub *ClientSubscription) Unsubscribe() {
 
 // deliver is called by the client's message dispatcher to send a notification value.
 func (sub *ClientSubscription) deliver(result json.RawMessage) (ok bool) {
+	if sub.logs != nil {
+		sub.logs.mu.Lock()
+		defer sub.logs.mu.Unlock()
+
+		// While the subscription is being resumed, notifications are held back
+		// until the backfilled logs have been delivered.
+		if sub.logs.paused {
+			sub.logs.pending = append(sub.logs.pending, result)
+			return true
+		}
+		sub.logs.advance(result)
+	}
 	select {
 	case sub.in <- result:
 		return true
@@ -374,9 +391,28 @@ func (sub *ClientSubscription) unmarshal(result json.RawMessage) (interface{}, e
 }
 
 func (sub *ClientSubscription) requestUnsubscribe() error {
+	if sub.client.pool != nil {
+		// Subscriptions of multi-endpoint clients are relayed from a subscription
+		// on one of the endpoints, which is ended by the relay.
+		return nil
+	}
 	var result interface{}
 	ctx, cancel := context.WithTimeout(context.Background(), unsubscribeTimeout)
 	defer cancel()
-	err := sub.client.CallContext(ctx, &result, sub.namespace+unsubscribeMethodSuffix, sub.subid)
+	err := sub.client.CallContext(ctx, &result, sub.namespace+unsubscribeMethodSuffix, sub.id())
 	return err
 }
+
+// id returns the current server-side ID of the subscription.
+func (sub *ClientSubscription) id() string {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	return sub.subid
+}
+
+// setID is called by the client's handler when the server has confirmed the subscription.
+func (sub *ClientSubscription) setID(id string) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	sub.subid = id
+}
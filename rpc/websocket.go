@@ -61,6 +61,10 @@ func (s *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 			return
 		}
 		codec := newWebsocketCodec(conn, r.Host, r.Header, s.wsReadLimit)
+		info := &codec.(*websocketCodec).info
+		info.HTTP.AuthSubject, info.HTTP.Authenticated = authSubjectFromContext(r.Context())
+		info.HTTP.TLSClientSubject = tlsClientSubject(r)
+		info.HTTP.Permissions = permissionsFromContext(r.Context())
 		s.ServeCodec(codec, 0)
 	})
 }